@@ -0,0 +1,61 @@
+// Copyright 2025 Marc-Antoine Ruel. All rights reserved.
+// Use of this source code is governed under the Apache License, Version 2.0
+// that can be found in the LICENSE file.
+
+package genaitools
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"math"
+
+	"github.com/maruel/genai"
+)
+
+// AccurateSum sums a list of numbers using Neumaier compensated summation.
+//
+// Naive float64 summation loses precision on long lists of decimals or when
+// adding numbers of very different magnitudes; the compensation keeps the
+// error independent of the number of values.
+var AccurateSum = genai.ToolDef{
+	Name:        "accurate_sum",
+	Description: "Sums a list of numbers precisely, without the rounding errors of naive floating point summation, and returns the total.",
+	Callback:    doAccurateSum,
+}
+
+type accurateSumArgs struct {
+	Numbers []json.Number `json:"numbers" jsonschema:"description=Numbers to sum"`
+}
+
+func doAccurateSum(ctx context.Context, args *accurateSumArgs) (string, error) {
+	values := make([]float64, len(args.Numbers))
+	for i, n := range args.Numbers {
+		f, err := n.Float64()
+		if err != nil {
+			return "", fmt.Errorf("couldn't understand number %d %q: %w", i, n, err)
+		}
+		values[i] = f
+	}
+	sum := neumaierSum(values)
+	if math.IsInf(sum, 0) || math.IsNaN(sum) {
+		return "", fmt.Errorf("sum overflows: %v", sum)
+	}
+	return formatFloat(sum), nil
+}
+
+// neumaierSum is Kahan summation improved by Neumaier to also compensate when
+// the next value is larger than the running sum.
+func neumaierSum(values []float64) float64 {
+	sum, c := 0., 0.
+	for _, v := range values {
+		t := sum + v
+		if math.Abs(sum) >= math.Abs(v) {
+			c += (sum - t) + v
+		} else {
+			c += (v - t) + sum
+		}
+		sum = t
+	}
+	return sum + c
+}
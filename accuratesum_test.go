@@ -0,0 +1,67 @@
+// Copyright 2025 Marc-Antoine Ruel. All rights reserved.
+// Use of this source code is governed under the Apache License, Version 2.0
+// that can be found in the LICENSE file.
+
+package genaitools
+
+import (
+	"context"
+	"encoding/json"
+	"strings"
+	"testing"
+)
+
+func TestAccurateSum(t *testing.T) {
+	callback := AccurateSum.Callback.(func(context.Context, *accurateSumArgs) (string, error))
+	tenths := make([]json.Number, 10)
+	for i := range tenths {
+		tenths[i] = "0.1"
+	}
+	cents := make([]json.Number, 1000)
+	for i := range cents {
+		cents[i] = "0.01"
+	}
+	tests := []struct {
+		name     string
+		numbers  []json.Number
+		expected string
+		naive    string
+	}{
+		{"tenths", tenths, "1", "1.000000"},
+		{"cents", cents, "10", "10.000000"},
+		{"cancellation", []json.Number{"1e16", "1", "-1e16"}, "1", "0"},
+		{"large_small", []json.Number{"1", "1e100", "1", "-1e100"}, "2", "0"},
+		{"integers", []json.Number{"1", "2", "3"}, "6", "6"},
+		{"empty", nil, "0", "0"},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := callback(t.Context(), &accurateSumArgs{Numbers: tt.numbers})
+			if err != nil {
+				t.Fatalf("Unexpected error: %v", err)
+			}
+			if got != tt.expected {
+				t.Fatalf("Expected %q but got %q", tt.expected, got)
+			}
+			naive := 0.
+			for _, n := range tt.numbers {
+				f, _ := n.Float64()
+				naive += f
+			}
+			if s := formatFloat(naive); s != tt.naive {
+				t.Fatalf("Expected naive sum %q but got %q", tt.naive, s)
+			}
+		})
+	}
+	for _, tt := range []struct {
+		numbers   []json.Number
+		errSubstr string
+	}{
+		{[]json.Number{"1", "abc"}, `number 1 "abc"`},
+		{[]json.Number{"1e308", "1e308"}, "overflows"},
+	} {
+		if _, err := callback(t.Context(), &accurateSumArgs{Numbers: tt.numbers}); err == nil || !strings.Contains(err.Error(), tt.errSubstr) {
+			t.Fatalf("Expected error containing %q but got %v", tt.errSubstr, err)
+		}
+	}
+}
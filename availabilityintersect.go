@@ -0,0 +1,160 @@
+// Copyright 2025 Marc-Antoine Ruel. All rights reserved.
+// Use of this source code is governed under the Apache License, Version 2.0
+// that can be found in the LICENSE file.
+
+package genaitools
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"slices"
+	"time"
+
+	"github.com/maruel/genai"
+)
+
+// AvailabilityIntersect combines two sets of time intervals, e.g. the free
+// slots of two people.
+//
+// Overlapping or adjacent intervals within each set are merged first. It
+// returns the intersection (the times in both sets), the union (the times in
+// either set) and the difference (the times in a but not in b), each as
+// sorted, non-overlapping intervals. Intervals are half-open: the end is
+// exclusive.
+var AvailabilityIntersect = genai.ToolDef{
+	Name:        "availability_intersect",
+	Description: "Combines two lists of time intervals like the free slots of two calendars and returns as JSON their intersection (common windows), union and difference (in a but not in b), each merged and sorted.",
+	Callback:    doAvailabilityIntersect,
+}
+
+type availabilityIntersectArgs struct {
+	A []timeInterval `json:"a" jsonschema:"description=First list of intervals"`
+	B []timeInterval `json:"b" jsonschema:"description=Second list of intervals"`
+}
+
+type timeInterval struct {
+	Start string `json:"start" jsonschema:"description=Start in RFC3339 format or date as YYYY-MM-DD"`
+	End   string `json:"end" jsonschema:"description=Exclusive end in RFC3339 format or date as YYYY-MM-DD"`
+}
+
+type availabilityIntersectResult struct {
+	Intersection []timeInterval `json:"intersection"`
+	Union        []timeInterval `json:"union"`
+	Difference   []timeInterval `json:"difference"`
+}
+
+// interval is a parsed timeInterval.
+type interval struct {
+	start, end time.Time
+}
+
+func doAvailabilityIntersect(ctx context.Context, args *availabilityIntersectArgs) (string, error) {
+	a, err := parseIntervals("a", args.A)
+	if err != nil {
+		return "", err
+	}
+	b, err := parseIntervals("b", args.B)
+	if err != nil {
+		return "", err
+	}
+	a, b = mergeIntervals(a), mergeIntervals(b)
+	res := availabilityIntersectResult{
+		Intersection: formatIntervals(intersectIntervals(a, b)),
+		Union:        formatIntervals(mergeIntervals(append(slices.Clone(a), b...))),
+		Difference:   formatIntervals(subtractIntervals(a, b)),
+	}
+	out, err := json.Marshal(res)
+	return string(out), err
+}
+
+func parseIntervals(name string, in []timeInterval) ([]interval, error) {
+	out := make([]interval, len(in))
+	for i, v := range in {
+		var err error
+		if out[i].start, err = parseTimestamp(fmt.Sprintf("%s[%d].start", name, i), v.Start); err != nil {
+			return nil, err
+		}
+		if out[i].end, err = parseTimestamp(fmt.Sprintf("%s[%d].end", name, i), v.End); err != nil {
+			return nil, err
+		}
+		if !out[i].start.Before(out[i].end) {
+			return nil, fmt.Errorf("invalid %s[%d]: start %s must be before end %s", name, i, v.Start, v.End)
+		}
+	}
+	return out, nil
+}
+
+// mergeIntervals sorts the intervals and merges the overlapping or adjacent
+// ones.
+func mergeIntervals(in []interval) []interval {
+	slices.SortFunc(in, func(x, y interval) int { return x.start.Compare(y.start) })
+	var out []interval
+	for _, v := range in {
+		if n := len(out); n > 0 && !v.start.After(out[n-1].end) {
+			if v.end.After(out[n-1].end) {
+				out[n-1].end = v.end
+			}
+			continue
+		}
+		out = append(out, v)
+	}
+	return out
+}
+
+// intersectIntervals returns the intersection of two merged lists.
+func intersectIntervals(a, b []interval) []interval {
+	var out []interval
+	for i, j := 0, 0; i < len(a) && j < len(b); {
+		start, end := a[i].start, a[i].end
+		if b[j].start.After(start) {
+			start = b[j].start
+		}
+		if b[j].end.Before(end) {
+			end = b[j].end
+		}
+		if start.Before(end) {
+			out = append(out, interval{start, end})
+		}
+		if a[i].end.Before(b[j].end) {
+			i++
+		} else {
+			j++
+		}
+	}
+	return out
+}
+
+// subtractIntervals returns the parts of the merged list a not covered by the
+// merged list b.
+func subtractIntervals(a, b []interval) []interval {
+	var out []interval
+	j := 0
+	for _, v := range a {
+		start := v.start
+		for ; j < len(b) && !b[j].start.After(v.end); j++ {
+			if b[j].end.After(start) {
+				if b[j].start.After(start) {
+					out = append(out, interval{start, b[j].start})
+				}
+				start = b[j].end
+			}
+			if b[j].end.After(v.end) {
+				// b[j] may also cover the next interval of a.
+				break
+			}
+		}
+		if start.Before(v.end) {
+			out = append(out, interval{start, v.end})
+		}
+	}
+	return out
+}
+
+func formatIntervals(in []interval) []timeInterval {
+	out := make([]timeInterval, len(in))
+	for i, v := range in {
+		out[i] = timeInterval{Start: v.start.Format(time.RFC3339), End: v.end.Format(time.RFC3339)}
+	}
+	return out
+}
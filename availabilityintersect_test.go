@@ -0,0 +1,88 @@
+// Copyright 2025 Marc-Antoine Ruel. All rights reserved.
+// Use of this source code is governed under the Apache License, Version 2.0
+// that can be found in the LICENSE file.
+
+package genaitools
+
+import (
+	"context"
+	"strings"
+	"testing"
+)
+
+func TestAvailabilityIntersect(t *testing.T) {
+	callback := AvailabilityIntersect.Callback.(func(context.Context, *availabilityIntersectArgs) (string, error))
+	iv := func(start, end string) timeInterval {
+		return timeInterval{Start: "2025-03-10T" + start + ":00Z", End: "2025-03-10T" + end + ":00Z"}
+	}
+	tests := []struct {
+		name      string
+		args      availabilityIntersectArgs
+		expected  string
+		errSubstr string
+	}{
+		{
+			"overlap",
+			availabilityIntersectArgs{
+				A: []timeInterval{iv("09:00", "12:00"), iv("14:00", "17:00")},
+				B: []timeInterval{iv("11:00", "15:00")},
+			},
+			`{"intersection":[{"start":"2025-03-10T11:00:00Z","end":"2025-03-10T12:00:00Z"},{"start":"2025-03-10T14:00:00Z","end":"2025-03-10T15:00:00Z"}],` +
+				`"union":[{"start":"2025-03-10T09:00:00Z","end":"2025-03-10T17:00:00Z"}],` +
+				`"difference":[{"start":"2025-03-10T09:00:00Z","end":"2025-03-10T11:00:00Z"},{"start":"2025-03-10T15:00:00Z","end":"2025-03-10T17:00:00Z"}]}`,
+			"",
+		},
+		{
+			"merge_unsorted_adjacent",
+			availabilityIntersectArgs{
+				A: []timeInterval{iv("10:00", "11:00"), iv("09:00", "10:00"), iv("09:30", "09:45")},
+				B: []timeInterval{iv("09:15", "09:30"), iv("09:45", "10:15")},
+			},
+			`{"intersection":[{"start":"2025-03-10T09:15:00Z","end":"2025-03-10T09:30:00Z"},{"start":"2025-03-10T09:45:00Z","end":"2025-03-10T10:15:00Z"}],` +
+				`"union":[{"start":"2025-03-10T09:00:00Z","end":"2025-03-10T11:00:00Z"}],` +
+				`"difference":[{"start":"2025-03-10T09:00:00Z","end":"2025-03-10T09:15:00Z"},{"start":"2025-03-10T09:30:00Z","end":"2025-03-10T09:45:00Z"},{"start":"2025-03-10T10:15:00Z","end":"2025-03-10T11:00:00Z"}]}`,
+			"",
+		},
+		{
+			"b_spans_several_a",
+			availabilityIntersectArgs{
+				A: []timeInterval{iv("09:00", "10:00"), iv("11:00", "12:00"), iv("13:00", "14:00")},
+				B: []timeInterval{iv("09:30", "11:30")},
+			},
+			`{"intersection":[{"start":"2025-03-10T09:30:00Z","end":"2025-03-10T10:00:00Z"},{"start":"2025-03-10T11:00:00Z","end":"2025-03-10T11:30:00Z"}],` +
+				`"union":[{"start":"2025-03-10T09:00:00Z","end":"2025-03-10T12:00:00Z"},{"start":"2025-03-10T13:00:00Z","end":"2025-03-10T14:00:00Z"}],` +
+				`"difference":[{"start":"2025-03-10T09:00:00Z","end":"2025-03-10T09:30:00Z"},{"start":"2025-03-10T11:30:00Z","end":"2025-03-10T12:00:00Z"},{"start":"2025-03-10T13:00:00Z","end":"2025-03-10T14:00:00Z"}]}`,
+			"",
+		},
+		{
+			"disjoint_dates",
+			availabilityIntersectArgs{
+				A: []timeInterval{{Start: "2025-03-10", End: "2025-03-11"}},
+				B: []timeInterval{{Start: "2025-03-11T09:00:00+01:00", End: "2025-03-11T10:00:00+01:00"}},
+			},
+			`{"intersection":[],"union":[{"start":"2025-03-10T00:00:00Z","end":"2025-03-11T00:00:00Z"},{"start":"2025-03-11T09:00:00+01:00","end":"2025-03-11T10:00:00+01:00"}],"difference":[{"start":"2025-03-10T00:00:00Z","end":"2025-03-11T00:00:00Z"}]}`,
+			"",
+		},
+		{"empty", availabilityIntersectArgs{}, `{"intersection":[],"union":[],"difference":[]}`, ""},
+		{"reversed", availabilityIntersectArgs{A: []timeInterval{iv("10:00", "09:00")}}, "", "invalid a[0]: start 2025-03-10T10:00:00Z must be before end 2025-03-10T09:00:00Z"},
+		{"empty_interval", availabilityIntersectArgs{B: []timeInterval{iv("10:00", "10:00")}}, "", "invalid b[0]"},
+		{"bad_timestamp", availabilityIntersectArgs{A: []timeInterval{{Start: "noon", End: "2025-03-10"}}}, "", `invalid a[0].start "noon"`},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := callback(t.Context(), &tt.args)
+			if tt.errSubstr != "" {
+				if err == nil || !strings.Contains(err.Error(), tt.errSubstr) {
+					t.Fatalf("Expected error containing %q but got %v", tt.errSubstr, err)
+				}
+				return
+			}
+			if err != nil {
+				t.Fatalf("Unexpected error: %v", err)
+			}
+			if got != tt.expected {
+				t.Fatalf("Expected %q but got %q", tt.expected, got)
+			}
+		})
+	}
+}
@@ -0,0 +1,91 @@
+// Copyright 2025 Marc-Antoine Ruel. All rights reserved.
+// Use of this source code is governed under the Apache License, Version 2.0
+// that can be found in the LICENSE file.
+
+package genaitools
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"maps"
+	"math"
+	"slices"
+	"strings"
+	"unicode/utf8"
+
+	"github.com/maruel/genai"
+)
+
+// BarChart renders a horizontal ASCII bar chart.
+//
+// Bars are scaled to fit the requested width. Negative values extend to the
+// left of the zero column.
+var BarChart = genai.ToolDef{
+	Name:        "bar_chart",
+	Description: "Renders a horizontal ASCII bar chart from labeled values, to present a quick visual summary in plain text.",
+	Callback:    doBarChart,
+}
+
+type barChartItem struct {
+	Label string  `json:"label"`
+	Value float64 `json:"value"`
+}
+
+type barChartArgs struct {
+	Bars   []barChartItem     `json:"bars,omitempty" jsonschema:"description=Ordered list of labeled values. Either bars or values must be specified"`
+	Values map[string]float64 `json:"values,omitempty" jsonschema:"description=Values keyed by label; sorted by label. Either bars or values must be specified"`
+	Width  int                `json:"width,omitempty" jsonschema:"description=Width of the longest bar in characters. Defaults to 40"`
+}
+
+func doBarChart(ctx context.Context, args *barChartArgs) (string, error) {
+	bars := args.Bars
+	if len(args.Values) != 0 {
+		if len(bars) != 0 {
+			return "", errors.New("specify either bars or values, not both")
+		}
+		for _, k := range slices.Sorted(maps.Keys(args.Values)) {
+			bars = append(bars, barChartItem{Label: k, Value: args.Values[k]})
+		}
+	}
+	if len(bars) == 0 {
+		return "", errors.New("no values to chart")
+	}
+	width := args.Width
+	if width == 0 {
+		width = 40
+	}
+	if width < 1 || width > 500 {
+		return "", fmt.Errorf("invalid width %d; must be between 1 and 500", width)
+	}
+	return renderBarChart(bars, width), nil
+}
+
+func renderBarChart(bars []barChartItem, width int) string {
+	lo, hi := 0., 0.
+	labelWidth := 0
+	for _, b := range bars {
+		lo = min(lo, b.Value)
+		hi = max(hi, b.Value)
+		labelWidth = max(labelWidth, utf8.RuneCountInString(b.Label))
+	}
+	// The zero column is at the left unless there are negative values.
+	scale := 0.
+	if hi > lo {
+		scale = float64(width) / (hi - lo)
+	}
+	zero := int(math.Round(-lo * scale))
+	var sb strings.Builder
+	for _, b := range bars {
+		n := int(math.Round(math.Abs(b.Value) * scale))
+		bar := ""
+		if b.Value < 0 {
+			bar = strings.Repeat(" ", zero-n) + strings.Repeat("#", n)
+		} else {
+			bar = strings.Repeat(" ", zero) + strings.Repeat("#", n)
+		}
+		pad := strings.Repeat(" ", labelWidth-utf8.RuneCountInString(b.Label))
+		fmt.Fprintf(&sb, "%s%s |%s %s\n", b.Label, pad, bar, formatFloat(b.Value))
+	}
+	return sb.String()
+}
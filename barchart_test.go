@@ -0,0 +1,71 @@
+// Copyright 2025 Marc-Antoine Ruel. All rights reserved.
+// Use of this source code is governed under the Apache License, Version 2.0
+// that can be found in the LICENSE file.
+
+package genaitools
+
+import (
+	"context"
+	"strings"
+	"testing"
+)
+
+func TestBarChart(t *testing.T) {
+	callback := BarChart.Callback.(func(context.Context, *barChartArgs) (string, error))
+	tests := []struct {
+		name      string
+		args      barChartArgs
+		want      string
+		errSubstr string
+	}{
+		{
+			"positive",
+			barChartArgs{Bars: []barChartItem{{"apples", 10}, {"kiwis", 5}, {"figs", 0}}, Width: 10},
+			"apples |########## 10\n" +
+				"kiwis  |##### 5\n" +
+				"figs   | 0\n",
+			"",
+		},
+		{
+			"negative",
+			barChartArgs{Bars: []barChartItem{{"gain", 6}, {"loss", -4}}, Width: 10},
+			"gain |    ###### 6\n" +
+				"loss |#### -4\n",
+			"",
+		},
+		{
+			"values_sorted",
+			barChartArgs{Values: map[string]float64{"b": 1, "a": 2}, Width: 4},
+			"a |#### 2\n" +
+				"b |## 1\n",
+			"",
+		},
+		{
+			"zero_range",
+			barChartArgs{Bars: []barChartItem{{"x", 0}, {"y", 0}}},
+			"x | 0\n" +
+				"y | 0\n",
+			"",
+		},
+		{"empty", barChartArgs{}, "", "no values"},
+		{"both", barChartArgs{Bars: []barChartItem{{"a", 1}}, Values: map[string]float64{"b": 1}}, "", "not both"},
+		{"bad_width", barChartArgs{Bars: []barChartItem{{"a", 1}}, Width: -1}, "", "invalid width"},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := callback(t.Context(), &tt.args)
+			if tt.errSubstr != "" {
+				if err == nil || !strings.Contains(err.Error(), tt.errSubstr) {
+					t.Fatalf("Expected error containing %q but got %v", tt.errSubstr, err)
+				}
+				return
+			}
+			if err != nil {
+				t.Fatalf("Unexpected error: %v", err)
+			}
+			if got != tt.want {
+				t.Fatalf("unexpected output\nwant:\n%s\ngot:\n%s", tt.want, got)
+			}
+		})
+	}
+}
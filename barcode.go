@@ -0,0 +1,83 @@
+// Copyright 2025 Marc-Antoine Ruel. All rights reserved.
+// Use of this source code is governed under the Apache License, Version 2.0
+// that can be found in the LICENSE file.
+
+package genaitools
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"strings"
+
+	"github.com/maruel/genai"
+)
+
+// Barcode validates or completes an EAN-13 or UPC-A barcode.
+//
+// When the code includes the check digit, it is validated. When it is one
+// digit short, the check digit is computed. Spaces and dashes are ignored.
+var Barcode = genai.ToolDef{
+	Name:        "barcode",
+	Description: "Validates an EAN-13 or UPC-A barcode and computes its check digit. Pass the full code to validate it, or the code without its last digit to compute the check digit.",
+	Callback:    doBarcode,
+}
+
+type barcodeArgs struct {
+	Code   string `json:"code" jsonschema:"description=Digits of the barcode with or without the check digit"`
+	Format string `json:"format" jsonschema:"enum=ean13,enum=upca"`
+}
+
+type barcodeResult struct {
+	// Valid is only set when the code includes a check digit.
+	Valid      *bool  `json:"valid,omitempty"`
+	CheckDigit int    `json:"check_digit"`
+	Code       string `json:"code"`
+}
+
+func doBarcode(ctx context.Context, args *barcodeArgs) (string, error) {
+	n := 0
+	switch args.Format {
+	case "ean13":
+		n = 13
+	case "upca":
+		n = 12
+	default:
+		return "", fmt.Errorf("unknown format %q; supported formats are ean13 and upca", args.Format)
+	}
+	code := strings.NewReplacer(" ", "", "-", "").Replace(args.Code)
+	for _, c := range code {
+		if c < '0' || c > '9' {
+			return "", fmt.Errorf("invalid barcode %q; only digits are allowed", args.Code)
+		}
+	}
+	res := barcodeResult{}
+	switch len(code) {
+	case n:
+		res.CheckDigit = checkDigit(code[:n-1])
+		valid := int(code[n-1]-'0') == res.CheckDigit
+		res.Valid = &valid
+		res.Code = code[:n-1] + string(rune('0'+res.CheckDigit))
+	case n - 1:
+		res.CheckDigit = checkDigit(code)
+		res.Code = code + string(rune('0'+res.CheckDigit))
+	default:
+		return "", fmt.Errorf("invalid %s length: got %d digits, expected %d, or %d without the check digit", args.Format, len(code), n, n-1)
+	}
+	b, err := json.Marshal(&res)
+	return string(b), err
+}
+
+// checkDigit returns the GS1 mod 10 check digit of the digits: starting from
+// the rightmost digit, digits are weighted alternately 3 and 1.
+func checkDigit(digits string) int {
+	sum := 0
+	for i := range len(digits) {
+		d := int(digits[len(digits)-1-i] - '0')
+		if i%2 == 0 {
+			d *= 3
+		}
+		sum += d
+	}
+	return (10 - sum%10) % 10
+}
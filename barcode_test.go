@@ -0,0 +1,53 @@
+// Copyright 2025 Marc-Antoine Ruel. All rights reserved.
+// Use of this source code is governed under the Apache License, Version 2.0
+// that can be found in the LICENSE file.
+
+package genaitools
+
+import (
+	"context"
+	"strings"
+	"testing"
+)
+
+func TestBarcode(t *testing.T) {
+	callback := Barcode.Callback.(func(context.Context, *barcodeArgs) (string, error))
+	tests := []struct {
+		name      string
+		code      string
+		format    string
+		expected  string
+		errSubstr string
+	}{
+		{"ean13_valid", "4006381333931", "ean13", `{"valid":true,"check_digit":1,"code":"4006381333931"}`, ""},
+		{"ean13_invalid", "4006381333932", "ean13", `{"valid":false,"check_digit":1,"code":"4006381333931"}`, ""},
+		{"ean13_compute", "400638133393", "ean13", `{"check_digit":1,"code":"4006381333931"}`, ""},
+		{"ean13_isbn", "978030640615", "ean13", `{"check_digit":7,"code":"9780306406157"}`, ""},
+		{"ean13_separators", "978-0-306-40615-7", "ean13", `{"valid":true,"check_digit":7,"code":"9780306406157"}`, ""},
+		{"upca_valid", "036000291452", "upca", `{"valid":true,"check_digit":2,"code":"036000291452"}`, ""},
+		{"upca_compute", "03600029145", "upca", `{"check_digit":2,"code":"036000291452"}`, ""},
+		{"upca_compute_sequence", "01234567890", "upca", `{"check_digit":5,"code":"012345678905"}`, ""},
+		{"too_short", "12345", "ean13", "", "invalid ean13 length: got 5 digits, expected 13, or 12 without the check digit"},
+		{"too_long", "0360002914521", "upca", "", "invalid upca length: got 13 digits"},
+		{"letters", "40063813339A", "ean13", "", "only digits are allowed"},
+		{"unicode_digits", "٤٠٠٦٣٨١٣٣٣٩٣", "ean13", "", "only digits are allowed"},
+		{"unknown_format", "12345670", "ean8", "", `unknown format "ean8"`},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := callback(t.Context(), &barcodeArgs{Code: tt.code, Format: tt.format})
+			if tt.errSubstr != "" {
+				if err == nil || !strings.Contains(err.Error(), tt.errSubstr) {
+					t.Fatalf("Expected error containing %q but got %v", tt.errSubstr, err)
+				}
+				return
+			}
+			if err != nil {
+				t.Fatalf("Unexpected error: %v", err)
+			}
+			if got != tt.expected {
+				t.Fatalf("Expected %q but got %q", tt.expected, got)
+			}
+		})
+	}
+}
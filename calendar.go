@@ -0,0 +1,94 @@
+// Copyright 2025 Marc-Antoine Ruel. All rights reserved.
+// Use of this source code is governed under the Apache License, Version 2.0
+// that can be found in the LICENSE file.
+
+package genaitools
+
+import (
+	"context"
+	"fmt"
+	"strings"
+	"time"
+
+	"github.com/maruel/genai"
+)
+
+// MonthCalendar renders a text calendar for a month, like the unix command
+// cal.
+//
+// The month and year default to the current month. The week starts on Sunday
+// unless specified otherwise.
+var MonthCalendar = genai.ToolDef{
+	Name:        "month_calendar",
+	Description: "Renders a text calendar for a month of a year, like the cal command. Defaults to the current month.",
+	Callback:    doMonthCalendar,
+}
+
+type monthCalendarArgs struct {
+	Month     int    `json:"month,omitempty" jsonschema:"description=Month between 1 and 12. Defaults to the current month,minimum=1,maximum=12"`
+	Year      int    `json:"year,omitempty" jsonschema:"description=Year with 4 digits. Defaults to the current year"`
+	WeekStart string `json:"week_start,omitempty" jsonschema:"enum=sunday,enum=monday,enum=tuesday,enum=wednesday,enum=thursday,enum=friday,enum=saturday"`
+}
+
+func doMonthCalendar(ctx context.Context, args *monthCalendarArgs) (string, error) {
+	now := time.Now()
+	year, month := args.Year, time.Month(args.Month)
+	if month == 0 {
+		month = now.Month()
+	}
+	if year == 0 {
+		year = now.Year()
+	}
+	if month < time.January || month > time.December {
+		return "", fmt.Errorf("invalid month %d; must be between 1 and 12", args.Month)
+	}
+	if year < 1 || year > 9999 {
+		return "", fmt.Errorf("invalid year %d; must be between 1 and 9999", args.Year)
+	}
+	start := time.Sunday
+	if args.WeekStart != "" {
+		found := false
+		for d := time.Sunday; d <= time.Saturday; d++ {
+			if strings.EqualFold(d.String(), args.WeekStart) {
+				start, found = d, true
+				break
+			}
+		}
+		if !found {
+			return "", fmt.Errorf("invalid week_start %q; must be a day of the week", args.WeekStart)
+		}
+	}
+	return renderMonth(year, month, start), nil
+}
+
+// renderMonth returns a 20 columns wide calendar.
+func renderMonth(year int, month time.Month, start time.Weekday) string {
+	const width = 7*3 - 1
+	var b strings.Builder
+	title := fmt.Sprintf("%s %d", month, year)
+	pad := (width - len(title)) / 2
+	b.WriteString(strings.Repeat(" ", pad) + title + "\n")
+	for i := range 7 {
+		if i != 0 {
+			b.WriteByte(' ')
+		}
+		b.WriteString(((start + time.Weekday(i)) % 7).String()[:2])
+	}
+	b.WriteByte('\n')
+	first := time.Date(year, month, 1, 0, 0, 0, 0, time.UTC)
+	// Day 0 of the next month is the last day of this month; this handles leap
+	// years.
+	days := time.Date(year, month+1, 0, 0, 0, 0, 0, time.UTC).Day()
+	week := make([]string, int(first.Weekday()-start+7)%7, 7)
+	for i := range week {
+		week[i] = "  "
+	}
+	for d := 1; d <= days; d++ {
+		week = append(week, fmt.Sprintf("%2d", d))
+		if len(week) == 7 || d == days {
+			b.WriteString(strings.Join(week, " ") + "\n")
+			week = week[:0]
+		}
+	}
+	return b.String()
+}
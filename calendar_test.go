@@ -0,0 +1,85 @@
+// Copyright 2025 Marc-Antoine Ruel. All rights reserved.
+// Use of this source code is governed under the Apache License, Version 2.0
+// that can be found in the LICENSE file.
+
+package genaitools
+
+import (
+	"context"
+	"strings"
+	"testing"
+	"time"
+)
+
+func TestMonthCalendar(t *testing.T) {
+	callback := MonthCalendar.Callback.(func(context.Context, *monthCalendarArgs) (string, error))
+	t.Run("valid", func(t *testing.T) {
+		tests := []struct {
+			name string
+			args monthCalendarArgs
+			want string
+		}{
+			{
+				"leap_february",
+				monthCalendarArgs{Month: 2, Year: 2024},
+				"   February 2024\n" +
+					"Su Mo Tu We Th Fr Sa\n" +
+					"             1  2  3\n" +
+					" 4  5  6  7  8  9 10\n" +
+					"11 12 13 14 15 16 17\n" +
+					"18 19 20 21 22 23 24\n" +
+					"25 26 27 28 29\n",
+			},
+			{
+				"monday_start",
+				monthCalendarArgs{Month: 9, Year: 2025, WeekStart: "monday"},
+				"   September 2025\n" +
+					"Mo Tu We Th Fr Sa Su\n" +
+					" 1  2  3  4  5  6  7\n" +
+					" 8  9 10 11 12 13 14\n" +
+					"15 16 17 18 19 20 21\n" +
+					"22 23 24 25 26 27 28\n" +
+					"29 30\n",
+			},
+			{
+				"non_leap_february",
+				monthCalendarArgs{Month: 2, Year: 2023, WeekStart: "Saturday"},
+				"   February 2023\n" +
+					"Sa Su Mo Tu We Th Fr\n" +
+					"             1  2  3\n" +
+					" 4  5  6  7  8  9 10\n" +
+					"11 12 13 14 15 16 17\n" +
+					"18 19 20 21 22 23 24\n" +
+					"25 26 27 28\n",
+			},
+		}
+		for _, tt := range tests {
+			t.Run(tt.name, func(t *testing.T) {
+				got, err := callback(t.Context(), &tt.args)
+				if err != nil {
+					t.Fatalf("Unexpected error: %v", err)
+				}
+				if got != tt.want {
+					t.Fatalf("unexpected output\nwant:\n%s\ngot:\n%s", tt.want, got)
+				}
+			})
+		}
+	})
+	t.Run("default", func(t *testing.T) {
+		got, err := callback(t.Context(), &monthCalendarArgs{})
+		if err != nil {
+			t.Fatalf("Unexpected error: %v", err)
+		}
+		now := time.Now()
+		if want := now.Month().String(); !strings.Contains(got, want) {
+			t.Fatalf("expected current month %q in:\n%s", want, got)
+		}
+	})
+	t.Run("errors", func(t *testing.T) {
+		for _, args := range []monthCalendarArgs{{Month: 13}, {Month: -1}, {Month: 1, Year: 2024, WeekStart: "funday"}} {
+			if _, err := callback(t.Context(), &args); err == nil {
+				t.Fatalf("expected error for %+v", args)
+			}
+		}
+	})
+}
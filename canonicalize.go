@@ -0,0 +1,371 @@
+// Copyright 2025 Marc-Antoine Ruel. All rights reserved.
+// Use of this source code is governed under the Apache License, Version 2.0
+// that can be found in the LICENSE file.
+
+package genaitools
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"regexp"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/maruel/genai"
+)
+
+// Canonicalize validates a date, a number or a phone number written as a human
+// would in a locale and returns its canonical machine form.
+//
+//   - "date" returns an ISO 8601 date, e.g. "2024-03-05".
+//   - "number" returns a plain number using '.' as the decimal separator and no
+//     grouping, e.g. "1234.5".
+//   - "phone" returns an E.164 phone number, e.g. "+15551234567".
+//
+// The locale is a BCP 47 tag like "en-US" or "fr-FR" and defaults to "en-US".
+// It determines the day/month order, the decimal separator and the country
+// calling code for national phone numbers.
+var Canonicalize = genai.ToolDef{
+	Name:        "canonicalize",
+	Description: "Validates a date, number or phone number written for a locale and returns its canonical machine form: ISO 8601 date (YYYY-MM-DD), plain number or E.164 phone number.",
+	Callback:    doCanonicalize,
+}
+
+type canonicalizeArgs struct {
+	Value  string `json:"value" jsonschema:"description=Value as written by a human"`
+	Type   string `json:"type" jsonschema:"enum=date,enum=number,enum=phone"`
+	Locale string `json:"locale,omitempty" jsonschema:"description=BCP 47 locale like en-US or fr-FR. Defaults to en-US"`
+}
+
+func doCanonicalize(ctx context.Context, args *canonicalizeArgs) (string, error) {
+	lang, region, err := parseLocale(args.Locale)
+	if err != nil {
+		return "", err
+	}
+	switch args.Type {
+	case "date":
+		d, err := parseLocaleDate(args.Value, lang, region)
+		if err != nil {
+			return "", fmt.Errorf("invalid date %q for locale %s-%s: %w", args.Value, lang, region, err)
+		}
+		return d.Format(time.DateOnly), nil
+	case "number":
+		n, err := parseLenientNumber(args.Value, usesDecimalComma(lang, region))
+		if err != nil {
+			return "", fmt.Errorf("invalid number %q for locale %s-%s: %w", args.Value, lang, region, err)
+		}
+		return n, nil
+	case "phone":
+		p, err := parsePhone(args.Value, region)
+		if err != nil {
+			return "", fmt.Errorf("invalid phone number %q for locale %s-%s: %w", args.Value, lang, region, err)
+		}
+		return p, nil
+	default:
+		return "", fmt.Errorf("unknown type %q; supported types are date, number and phone", args.Type)
+	}
+}
+
+// defaultRegions is the region assumed when the locale only specifies a
+// language.
+var defaultRegions = map[string]string{
+	"cs": "CZ",
+	"da": "DK",
+	"de": "DE",
+	"en": "US",
+	"es": "ES",
+	"fi": "FI",
+	"fr": "FR",
+	"hu": "HU",
+	"it": "IT",
+	"ja": "JP",
+	"ko": "KR",
+	"nb": "NO",
+	"nl": "NL",
+	"pl": "PL",
+	"pt": "PT",
+	"ru": "RU",
+	"sv": "SE",
+	"tr": "TR",
+	"zh": "CN",
+}
+
+var reLocale = regexp.MustCompile(`^([a-zA-Z]{2,3})(?:[-_]([a-zA-Z]{2}))?$`)
+
+// parseLocale returns the lowercase language and uppercase region of a simple
+// BCP 47 tag.
+func parseLocale(locale string) (string, string, error) {
+	if locale == "" {
+		return "en", "US", nil
+	}
+	m := reLocale.FindStringSubmatch(locale)
+	if m == nil {
+		return "", "", fmt.Errorf("invalid locale %q; use a BCP 47 tag like en-US", locale)
+	}
+	lang, region := strings.ToLower(m[1]), strings.ToUpper(m[2])
+	if region == "" {
+		if region = defaultRegions[lang]; region == "" {
+			return "", "", fmt.Errorf("locale %q requires a region, e.g. %s-XX", locale, lang)
+		}
+	}
+	return lang, region, nil
+}
+
+// Date.
+
+var reNumericDate = regexp.MustCompile(`^(\d{1,4})[./\- ](\d{1,2})[./\- ](\d{1,4})$`)
+
+// textDateLayouts are the layouts with English month names that are accepted
+// regardless of the locale.
+var textDateLayouts = []string{
+	"January 2, 2006",
+	"January 2 2006",
+	"Jan 2, 2006",
+	"Jan 2 2006",
+	"2 January 2006",
+	"2 Jan 2006",
+	"Monday, January 2, 2006",
+	"Mon, 2 Jan 2006",
+}
+
+// parseLocaleDate parses a date written using the conventions of the locale.
+func parseLocaleDate(s, lang, region string) (time.Time, error) {
+	s = strings.TrimSpace(s)
+	if s == "" {
+		return time.Time{}, errors.New("empty value")
+	}
+	if t, err := time.Parse(time.DateOnly, s); err == nil {
+		return t, nil
+	}
+	if t, err := time.Parse(time.RFC3339, s); err == nil {
+		return t, nil
+	}
+	if m := reNumericDate.FindStringSubmatch(s); m != nil {
+		a, _ := strconv.Atoi(m[1])
+		b, _ := strconv.Atoi(m[2])
+		c, _ := strconv.Atoi(m[3])
+		var y, mo, d int
+		switch {
+		case len(m[1]) == 4:
+			y, mo, d = a, b, c
+		case len(m[3]) < 3 && dateOrder(lang, region) == "ymd":
+			y, mo, d = expandYear(a), b, c
+		case dateOrder(lang, region) == "mdy":
+			y, mo, d = expandYear(c), a, b
+		default:
+			y, mo, d = expandYear(c), b, a
+		}
+		if mo < 1 || mo > 12 {
+			return time.Time{}, fmt.Errorf("month %d out of range", mo)
+		}
+		t := time.Date(y, time.Month(mo), d, 0, 0, 0, 0, time.UTC)
+		if t.Day() != d {
+			return time.Time{}, fmt.Errorf("day %d out of range for %s %d", d, time.Month(mo), y)
+		}
+		return t, nil
+	}
+	for _, l := range textDateLayouts {
+		if t, err := time.Parse(l, s); err == nil {
+			return t, nil
+		}
+	}
+	return time.Time{}, errors.New("unrecognized date format")
+}
+
+// dateOrder returns the conventional order of numeric dates in the locale.
+func dateOrder(lang, region string) string {
+	switch region {
+	case "US", "PH", "FM", "MH", "PW":
+		return "mdy"
+	case "CN", "JP", "KR", "TW", "HU", "LT", "IR":
+		return "ymd"
+	}
+	switch lang {
+	case "ja", "ko", "zh", "hu":
+		return "ymd"
+	}
+	return "dmy"
+}
+
+// expandYear converts two digit years to 1970-2069.
+func expandYear(y int) int {
+	if y >= 100 {
+		return y
+	}
+	if y < 70 {
+		return 2000 + y
+	}
+	return 1900 + y
+}
+
+// Number.
+
+// usesDecimalComma returns true if the locale uses ',' as the decimal
+// separator.
+func usesDecimalComma(lang, region string) bool {
+	switch region {
+	case "CH", "LI", "MX", "IN":
+		// Swiss German uses "'" for grouping and '.' for decimal.
+		return false
+	}
+	switch lang {
+	case "cs", "da", "de", "es", "fi", "fr", "hu", "id", "it", "nb", "nl", "pl", "pt", "ru", "sv", "tr", "uk", "vi":
+		return true
+	}
+	return false
+}
+
+// parseLenientNumber parses a number as written by a human and returns it as a
+// plain decimal string.
+//
+// It strips whitespace and currency symbols, accepts a leading sign or
+// accounting parentheses for negative values, and removes thousands
+// separators. When decimalComma is true, ',' is the decimal separator and '.'
+// is a thousands separator; otherwise it is the opposite.
+func parseLenientNumber(s string, decimalComma bool) (string, error) {
+	s = strings.TrimSpace(s)
+	s = strings.Trim(s, "$€£¥₹₩₽¤")
+	s = strings.TrimSpace(s)
+	neg := false
+	if strings.HasPrefix(s, "(") && strings.HasSuffix(s, ")") {
+		neg = true
+		s = strings.TrimSpace(s[1 : len(s)-1])
+		s = strings.TrimSpace(strings.Trim(s, "$€£¥₹₩₽¤"))
+	}
+	if s != "" && (s[0] == '-' || s[0] == '+') {
+		neg = neg != (s[0] == '-')
+		s = strings.TrimSpace(strings.TrimLeft(s[1:], "$€£¥₹₩₽¤"))
+	} else if strings.HasPrefix(s, "−") {
+		// Unicode minus sign.
+		neg = !neg
+		s = strings.TrimSpace(s[len("−"):])
+	}
+	if s == "" {
+		return "", errors.New("empty value")
+	}
+	decSep, groupSep := '.', ','
+	if decimalComma {
+		decSep, groupSep = ',', '.'
+	}
+	var intPart, fracPart strings.Builder
+	groups := []int{0}
+	seenDec := false
+	for _, r := range s {
+		switch {
+		case r >= '0' && r <= '9':
+			if seenDec {
+				fracPart.WriteRune(r)
+			} else {
+				intPart.WriteRune(r)
+				groups[len(groups)-1]++
+			}
+		case r == decSep:
+			if seenDec {
+				return "", fmt.Errorf("more than one decimal separator %q", decSep)
+			}
+			seenDec = true
+		case r == groupSep || r == ' ' || r == '\u00a0' || r == '\u202f' || r == '\'' || r == '\u2019':
+			if seenDec {
+				return "", fmt.Errorf("thousands separator %q after the decimal separator", r)
+			}
+			groups = append(groups, 0)
+		default:
+			return "", fmt.Errorf("unexpected character %q", r)
+		}
+	}
+	if intPart.Len() == 0 && fracPart.Len() == 0 {
+		return "", errors.New("no digits")
+	}
+	for i, g := range groups {
+		// Groups are 3 digits, except in India where they are 2 digits before the
+		// last group of 3.
+		if (i == 0 && g == 0 && len(groups) > 1) || (i > 0 && g != 3 && (g != 2 || i == len(groups)-1)) {
+			return "", errors.New("misplaced thousands separator")
+		}
+	}
+	out := strings.TrimLeft(intPart.String(), "0")
+	if out == "" {
+		out = "0"
+	}
+	if f := strings.TrimRight(fracPart.String(), "0"); f != "" {
+		out += "." + f
+	}
+	if neg && out != "0" {
+		out = "-" + out
+	}
+	return out, nil
+}
+
+// Phone.
+
+// callingCodes is the international calling code per region.
+var callingCodes = map[string]string{
+	"AT": "43", "AU": "61", "BE": "32", "BR": "55", "CA": "1", "CH": "41",
+	"CN": "86", "CZ": "420", "DE": "49", "DK": "45", "ES": "34", "FI": "358",
+	"FR": "33", "GB": "44", "HU": "36", "IE": "353", "IN": "91", "IT": "39",
+	"JP": "81", "KR": "82", "MX": "52", "NL": "31", "NO": "47", "NZ": "64",
+	"PL": "48", "PT": "351", "RU": "7", "SE": "46", "TR": "90", "US": "1",
+	"ZA": "27",
+}
+
+// parsePhone returns the E.164 form of a phone number.
+//
+// Numbers without an international prefix are assumed to be national numbers
+// of the region.
+func parsePhone(s, region string) (string, error) {
+	s = strings.TrimSpace(s)
+	if s == "" {
+		return "", errors.New("empty value")
+	}
+	intl := false
+	var digits strings.Builder
+	for i, r := range s {
+		switch {
+		case r >= '0' && r <= '9':
+			digits.WriteRune(r)
+		case r == '+' && i == 0:
+			intl = true
+		case r == ' ' || r == '-' || r == '.' || r == '(' || r == ')' || r == '/':
+		default:
+			return "", fmt.Errorf("unexpected character %q", r)
+		}
+	}
+	d := digits.String()
+	switch {
+	case intl:
+	case strings.HasPrefix(d, "00"):
+		d = d[2:]
+	case strings.HasPrefix(d, "011") && callingCodes[region] == "1":
+		d = d[3:]
+	default:
+		cc, ok := callingCodes[region]
+		if !ok {
+			return "", fmt.Errorf("unknown calling code for region %q; use the +<country code> form", region)
+		}
+		switch {
+		case cc == "1":
+			// North American Numbering Plan: optional trunk prefix 1, then 10 digits.
+			if len(d) == 11 && d[0] == '1' {
+				d = d[1:]
+			}
+			if len(d) != 10 {
+				return "", fmt.Errorf("expected 10 digits for a national number, got %d", len(d))
+			}
+		case region == "IT":
+			// Italy keeps the leading 0 of landlines.
+		default:
+			d = strings.TrimPrefix(d, "0")
+		}
+		d = cc + d
+	}
+	// E.164 allows up to 15 digits, including the country code.
+	if len(d) < 8 || len(d) > 15 {
+		return "", fmt.Errorf("expected between 8 and 15 digits including the country code, got %d", len(d))
+	}
+	if d[0] == '0' {
+		return "", errors.New("country code cannot start with 0")
+	}
+	return "+" + d, nil
+}
@@ -0,0 +1,79 @@
+// Copyright 2025 Marc-Antoine Ruel. All rights reserved.
+// Use of this source code is governed under the Apache License, Version 2.0
+// that can be found in the LICENSE file.
+
+package genaitools
+
+import (
+	"context"
+	"strings"
+	"testing"
+)
+
+func TestCanonicalize(t *testing.T) {
+	tests := []struct {
+		name      string
+		typ       string
+		value     string
+		locale    string
+		expected  string
+		errSubstr string
+	}{
+		// Dates.
+		{"date_iso", "date", "2024-03-05", "fr-FR", "2024-03-05", ""},
+		{"date_us", "date", "03/05/2024", "en-US", "2024-03-05", ""},
+		{"date_default_locale", "date", "3/5/24", "", "2024-03-05", ""},
+		{"date_fr", "date", "05/03/2024", "fr-FR", "2024-03-05", ""},
+		{"date_de", "date", "5.3.2024", "de", "2024-03-05", ""},
+		{"date_ja", "date", "2024/03/05", "ja-JP", "2024-03-05", ""},
+		{"date_text", "date", "March 5, 2024", "en-GB", "2024-03-05", ""},
+		{"date_leap", "date", "29/02/2024", "en-GB", "2024-02-29", ""},
+		{"date_not_leap", "date", "29/02/2023", "en-GB", "", "day 29 out of range"},
+		{"date_bad_month", "date", "13/05/2024", "en-US", "", "month 13 out of range"},
+		{"date_garbage", "date", "yesterday", "en-US", "", "unrecognized date format"},
+
+		// Numbers.
+		{"number_us", "number", "1,234.50", "en-US", "1234.5", ""},
+		{"number_de", "number", "1.234,50", "de-DE", "1234.5", ""},
+		{"number_fr", "number", "1 234,5", "fr-FR", "1234.5", ""},
+		{"number_ch", "number", "1'234.5", "de-CH", "1234.5", ""},
+		{"number_in", "number", "12,34,567", "en-IN", "1234567", ""},
+		{"number_currency", "number", "$ 42", "en-US", "42", ""},
+		{"number_accounting", "number", "(1,000)", "en-US", "-1000", ""},
+		{"number_negative", "number", "-0.25", "en-US", "-0.25", ""},
+		{"number_bad_group", "number", "1,23", "en-US", "", "misplaced thousands separator"},
+		{"number_two_decimal", "number", "1.2.3", "en-US", "", "more than one decimal separator"},
+		{"number_letters", "number", "12abc", "en-US", "", "unexpected character"},
+
+		// Phones.
+		{"phone_us", "phone", "(555) 123-4567", "en-US", "+15551234567", ""},
+		{"phone_us_trunk", "phone", "1-555-123-4567", "en-US", "+15551234567", ""},
+		{"phone_fr", "phone", "01 23 45 67 89", "fr-FR", "+33123456789", ""},
+		{"phone_intl", "phone", "+44 20 7946 0958", "en-US", "+442079460958", ""},
+		{"phone_00", "phone", "0044 20 7946 0958", "de-DE", "+442079460958", ""},
+		{"phone_us_short", "phone", "555-1234", "en-US", "", "expected 10 digits"},
+		{"phone_letters", "phone", "555-CALL-NOW", "en-US", "", "unexpected character"},
+
+		// Misc.
+		{"unknown_type", "color", "red", "en-US", "", "unknown type"},
+		{"bad_locale", "date", "2024-03-05", "not a locale", "", "invalid locale"},
+	}
+	callback := Canonicalize.Callback.(func(context.Context, *canonicalizeArgs) (string, error))
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := callback(t.Context(), &canonicalizeArgs{Value: tt.value, Type: tt.typ, Locale: tt.locale})
+			if tt.errSubstr != "" {
+				if err == nil || !strings.Contains(err.Error(), tt.errSubstr) {
+					t.Fatalf("Expected error containing %q but got %v", tt.errSubstr, err)
+				}
+				return
+			}
+			if err != nil {
+				t.Fatalf("Unexpected error: %v", err)
+			}
+			if got != tt.expected {
+				t.Fatalf("Expected %q but got %q", tt.expected, got)
+			}
+		})
+	}
+}
@@ -0,0 +1,130 @@
+// Copyright 2025 Marc-Antoine Ruel. All rights reserved.
+// Use of this source code is governed under the Apache License, Version 2.0
+// that can be found in the LICENSE file.
+
+package genaitools
+
+import (
+	"context"
+	"fmt"
+	"strings"
+	"unicode"
+	"unicode/utf8"
+
+	"github.com/maruel/genai"
+)
+
+// ChangeCase converts the case of a text.
+//
+// upper, lower and title keep the text structure. title uppercases the first
+// letter of each word and lowercases the others.
+//
+// snake, camel and kebab split the text in words at any character that is not
+// a letter or a digit, like spaces, "_", "-" and ".", and at case changes, so
+// "HTTPServer" and "http server" both become "http_server".
+var ChangeCase = genai.ToolDef{
+	Name:        "change_case",
+	Description: "Converts text to upper, lower, title, snake_case, camelCase or kebab-case. snake, camel and kebab split words at spaces, punctuation and case changes.",
+	Callback:    doChangeCase,
+}
+
+type changeCaseArgs struct {
+	Text  string `json:"text" jsonschema:"description=Text to convert"`
+	Style string `json:"style" jsonschema:"enum=upper,enum=lower,enum=title,enum=snake,enum=camel,enum=kebab,description=Target case"`
+}
+
+func doChangeCase(ctx context.Context, args *changeCaseArgs) (string, error) {
+	switch args.Style {
+	case "upper":
+		return strings.ToUpper(args.Text), nil
+	case "lower":
+		return strings.ToLower(args.Text), nil
+	case "title":
+		return titleCase(args.Text), nil
+	case "snake":
+		return strings.ToLower(strings.Join(splitWords(args.Text), "_")), nil
+	case "kebab":
+		return strings.ToLower(strings.Join(splitWords(args.Text), "-")), nil
+	case "camel":
+		words := splitWords(args.Text)
+		for i, w := range words {
+			if i == 0 {
+				words[i] = strings.ToLower(w)
+			} else {
+				words[i] = capitalize(w)
+			}
+		}
+		return strings.Join(words, ""), nil
+	default:
+		return "", fmt.Errorf("unknown style %q; use upper, lower, title, snake, camel or kebab", args.Style)
+	}
+}
+
+// titleCase uppercases the first letter of each word and lowercases the
+// others. An apostrophe inside a word does not start a new word.
+func titleCase(s string) string {
+	var b strings.Builder
+	inWord := false
+	for _, r := range s {
+		switch {
+		case unicode.IsLetter(r) || unicode.IsDigit(r):
+			if inWord {
+				b.WriteRune(unicode.ToLower(r))
+			} else {
+				b.WriteRune(unicode.ToTitle(r))
+			}
+			inWord = true
+		case inWord && (r == '\'' || r == '’'):
+			b.WriteRune(r)
+		default:
+			b.WriteRune(r)
+			inWord = false
+		}
+	}
+	return b.String()
+}
+
+// capitalize titlecases the first rune of w and lowercases the rest.
+func capitalize(w string) string {
+	r, n := utf8.DecodeRuneInString(w)
+	return string(unicode.ToTitle(r)) + strings.ToLower(w[n:])
+}
+
+// splitWords splits s at the characters that are not letters or digits and
+// at case changes: "fooBar" is split as "foo", "Bar" and "HTTPServer" as
+// "HTTP", "Server". Digits stay with the preceding word.
+func splitWords(s string) []string {
+	var words []string
+	runes := []rune(s)
+	start := -1
+	for i, r := range runes {
+		if !unicode.IsLetter(r) && !unicode.IsDigit(r) {
+			if start >= 0 {
+				words = append(words, string(runes[start:i]))
+				start = -1
+			}
+			continue
+		}
+		if start < 0 {
+			start = i
+			continue
+		}
+		prev := runes[i-1]
+		// "fooBar" or "foo2Bar": a lowercase letter or a digit followed by an
+		// uppercase letter.
+		boundary := unicode.IsUpper(r) && (unicode.IsLower(prev) || unicode.IsDigit(prev))
+		// "HTTPServer": the last uppercase letter of a run starts a new word when
+		// followed by a lowercase letter.
+		if unicode.IsUpper(r) && unicode.IsUpper(prev) && i+1 < len(runes) && unicode.IsLower(runes[i+1]) {
+			boundary = true
+		}
+		if boundary {
+			words = append(words, string(runes[start:i]))
+			start = i
+		}
+	}
+	if start >= 0 {
+		words = append(words, string(runes[start:]))
+	}
+	return words
+}
@@ -0,0 +1,61 @@
+// Copyright 2025 Marc-Antoine Ruel. All rights reserved.
+// Use of this source code is governed under the Apache License, Version 2.0
+// that can be found in the LICENSE file.
+
+package genaitools
+
+import (
+	"context"
+	"strings"
+	"testing"
+)
+
+func TestChangeCase(t *testing.T) {
+	callback := ChangeCase.Callback.(func(context.Context, *changeCaseArgs) (string, error))
+	tests := []struct {
+		name      string
+		text      string
+		style     string
+		expected  string
+		errSubstr string
+	}{
+		{"upper", "hello world", "upper", "HELLO WORLD", ""},
+		{"lower", "Hello World", "lower", "hello world", ""},
+		{"title", "hello world", "title", "Hello World", ""},
+		{"snake", "hello world", "snake", "hello_world", ""},
+		{"camel", "hello world", "camel", "helloWorld", ""},
+		{"kebab", "hello world", "kebab", "hello-world", ""},
+		{"title_mixed", "hELLO wORLD, it's 2nd-hand", "title", "Hello World, It's 2nd-Hand", ""},
+		{"snake_from_camel", "helloWorld", "snake", "hello_world", ""},
+		{"snake_acronym", "HTTPServer", "snake", "http_server", ""},
+		{"snake_delimiters", "  --Hello__big-World.txt  ", "snake", "hello_big_world_txt", ""},
+		{"snake_digits", "version2Beta3", "snake", "version2_beta3", ""},
+		{"kebab_from_snake", "user_id_value", "kebab", "user-id-value", ""},
+		{"camel_from_kebab", "user-ID-value", "camel", "userIdValue", ""},
+		{"camel_from_pascal", "ParseHTTPRequest", "camel", "parseHttpRequest", ""},
+		{"upper_multibyte", "straße ǆ éa", "upper", "STRAßE Ǆ ÉA", ""},
+		{"title_multibyte", "élan ǆungla ÖL", "title", "Élan ǅungla Öl", ""},
+		{"snake_multibyte", "Ωmega Straße", "snake", "ωmega_straße", ""},
+		{"camel_multibyte", "école élémentaire", "camel", "écoleÉlémentaire", ""},
+		{"kebab_cjk", "東京 タワー", "kebab", "東京-タワー", ""},
+		{"empty", "", "snake", "", ""},
+		{"unknown", "x", "pascal", "", `unknown style "pascal"`},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := callback(t.Context(), &changeCaseArgs{Text: tt.text, Style: tt.style})
+			if tt.errSubstr != "" {
+				if err == nil || !strings.Contains(err.Error(), tt.errSubstr) {
+					t.Fatalf("Expected error containing %q but got %v", tt.errSubstr, err)
+				}
+				return
+			}
+			if err != nil {
+				t.Fatalf("Unexpected error: %v", err)
+			}
+			if got != tt.expected {
+				t.Fatalf("Expected %q but got %q", tt.expected, got)
+			}
+		})
+	}
+}
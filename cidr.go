@@ -0,0 +1,120 @@
+// Copyright 2025 Marc-Antoine Ruel. All rights reserved.
+// Use of this source code is governed under the Apache License, Version 2.0
+// that can be found in the LICENSE file.
+
+package genaitools
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"math/big"
+	"net/netip"
+	"strings"
+
+	"github.com/maruel/genai"
+)
+
+// CIDR describes an IPv4 or IPv6 network in CIDR notation and optionally
+// checks whether an IP is inside it.
+//
+// For IPv4, the network and broadcast addresses are excluded from the usable
+// host range, except for /31 (RFC 3021) and /32. IPv6 has no broadcast
+// address so every address is usable.
+var CIDR = genai.ToolDef{
+	Name:        "cidr",
+	Description: "Describes an IPv4 or IPv6 network in CIDR notation (e.g. 192.168.1.0/24): network address, broadcast, netmask, usable host range and address count, as JSON. Optionally checks whether an IP is in the network.",
+	Callback:    doCIDR,
+}
+
+type cidrArgs struct {
+	CIDR string `json:"cidr" jsonschema:"description=Network in CIDR notation like 10.0.0.0/8 or 2001:db8::/32. A plain IP is a single address network"`
+	IP   string `json:"ip,omitempty" jsonschema:"description=IP to check for membership in the network"`
+}
+
+type cidrResult struct {
+	Prefix    string `json:"prefix"`
+	Network   string `json:"network"`
+	Broadcast string `json:"broadcast,omitempty"`
+	Netmask   string `json:"netmask,omitempty"`
+	Last      string `json:"last"`
+	FirstHost string `json:"first_host"`
+	LastHost  string `json:"last_host"`
+	// Counts are strings since IPv6 counts overflow 64 bits.
+	Addresses string `json:"addresses"`
+	Hosts     string `json:"hosts"`
+	Contains  *bool  `json:"contains,omitempty"`
+}
+
+func doCIDR(ctx context.Context, args *cidrArgs) (string, error) {
+	s := strings.TrimSpace(args.CIDR)
+	var p netip.Prefix
+	if strings.Contains(s, "/") {
+		var err error
+		if p, err = netip.ParsePrefix(s); err != nil {
+			return "", fmt.Errorf("invalid CIDR %q: %w", args.CIDR, err)
+		}
+	} else {
+		ip, err := netip.ParseAddr(s)
+		if err != nil {
+			return "", fmt.Errorf("invalid CIDR %q: %w", args.CIDR, err)
+		}
+		p = netip.PrefixFrom(ip, ip.BitLen())
+	}
+	if p.Addr().Is4In6() {
+		return "", fmt.Errorf("invalid CIDR %q: IPv4-mapped IPv6 addresses are not supported", args.CIDR)
+	}
+	p = p.Masked()
+	bits := p.Addr().BitLen()
+	hostBits := bits - p.Bits()
+	network := p.Addr()
+	last := lastAddr(p)
+	res := cidrResult{
+		Prefix:    p.String(),
+		Network:   network.String(),
+		Last:      last.String(),
+		FirstHost: network.String(),
+		LastHost:  last.String(),
+	}
+	count := new(big.Int).Lsh(big.NewInt(1), uint(hostBits))
+	hosts := new(big.Int).Set(count)
+	if network.Is4() {
+		m := ^uint32(0) << hostBits
+		res.Netmask = netip.AddrFrom4([4]byte{byte(m >> 24), byte(m >> 16), byte(m >> 8), byte(m)}).String()
+		if hostBits >= 2 {
+			res.Broadcast = last.String()
+			res.FirstHost = network.Next().String()
+			res.LastHost = last.Prev().String()
+			hosts.Sub(hosts, big.NewInt(2))
+		}
+	}
+	res.Addresses = count.String()
+	res.Hosts = hosts.String()
+	if args.IP != "" {
+		ip, err := netip.ParseAddr(strings.TrimSpace(args.IP))
+		if err != nil {
+			return "", fmt.Errorf("invalid IP %q: %w", args.IP, err)
+		}
+		contains := p.Contains(ip.Unmap())
+		res.Contains = &contains
+	}
+	b, err := json.Marshal(res)
+	return string(b), err
+}
+
+// lastAddr returns the last address of the network p, with all host bits set.
+func lastAddr(p netip.Prefix) netip.Addr {
+	a := p.Addr().As16()
+	offset := 0
+	if p.Addr().Is4() {
+		// As16 returns the IPv4-mapped form; the IPv4 address is the last 4 bytes.
+		offset = 12
+	}
+	for i := offset*8 + p.Bits(); i < 128; i++ {
+		a[i/8] |= 0x80 >> (i % 8)
+	}
+	if p.Addr().Is4() {
+		return netip.AddrFrom4([4]byte(a[12:]))
+	}
+	return netip.AddrFrom16(a)
+}
@@ -0,0 +1,78 @@
+// Copyright 2025 Marc-Antoine Ruel. All rights reserved.
+// Use of this source code is governed under the Apache License, Version 2.0
+// that can be found in the LICENSE file.
+
+package genaitools
+
+import (
+	"context"
+	"strings"
+	"testing"
+)
+
+func TestCIDR(t *testing.T) {
+	callback := CIDR.Callback.(func(context.Context, *cidrArgs) (string, error))
+	tests := []struct {
+		name      string
+		cidr      string
+		ip        string
+		expected  string
+		errSubstr string
+	}{
+		{
+			"ipv4_24", "192.168.1.77/24", "192.168.1.200",
+			`{"prefix":"192.168.1.0/24","network":"192.168.1.0","broadcast":"192.168.1.255","netmask":"255.255.255.0","last":"192.168.1.255","first_host":"192.168.1.1","last_host":"192.168.1.254","addresses":"256","hosts":"254","contains":true}`,
+			"",
+		},
+		{
+			"ipv4_outside", "10.0.0.0/8", "11.0.0.1",
+			`{"prefix":"10.0.0.0/8","network":"10.0.0.0","broadcast":"10.255.255.255","netmask":"255.0.0.0","last":"10.255.255.255","first_host":"10.0.0.1","last_host":"10.255.255.254","addresses":"16777216","hosts":"16777214","contains":false}`,
+			"",
+		},
+		{
+			"ipv4_31", "192.0.2.0/31", "",
+			`{"prefix":"192.0.2.0/31","network":"192.0.2.0","netmask":"255.255.255.254","last":"192.0.2.1","first_host":"192.0.2.0","last_host":"192.0.2.1","addresses":"2","hosts":"2"}`,
+			"",
+		},
+		{
+			"ipv4_single", "192.0.2.5", "",
+			`{"prefix":"192.0.2.5/32","network":"192.0.2.5","netmask":"255.255.255.255","last":"192.0.2.5","first_host":"192.0.2.5","last_host":"192.0.2.5","addresses":"1","hosts":"1"}`,
+			"",
+		},
+		{
+			"ipv4_0", "0.0.0.0/0", "",
+			`{"prefix":"0.0.0.0/0","network":"0.0.0.0","broadcast":"255.255.255.255","netmask":"0.0.0.0","last":"255.255.255.255","first_host":"0.0.0.1","last_host":"255.255.255.254","addresses":"4294967296","hosts":"4294967294"}`,
+			"",
+		},
+		{
+			"ipv6", "2001:db8::1/32", "2001:db8:ffff::1",
+			`{"prefix":"2001:db8::/32","network":"2001:db8::","last":"2001:db8:ffff:ffff:ffff:ffff:ffff:ffff","first_host":"2001:db8::","last_host":"2001:db8:ffff:ffff:ffff:ffff:ffff:ffff","addresses":"79228162514264337593543950336","hosts":"79228162514264337593543950336","contains":true}`,
+			"",
+		},
+		{
+			"ipv6_family_mismatch", "2001:db8::/64", "192.0.2.1",
+			`{"prefix":"2001:db8::/64","network":"2001:db8::","last":"2001:db8::ffff:ffff:ffff:ffff","first_host":"2001:db8::","last_host":"2001:db8::ffff:ffff:ffff:ffff","addresses":"18446744073709551616","hosts":"18446744073709551616","contains":false}`,
+			"",
+		},
+		{"bad_cidr", "192.168.1.0/33", "", "", "invalid CIDR"},
+		{"bad_ip", "192.168.1.0/24", "300.1.1.1", "", `invalid IP "300.1.1.1"`},
+		{"garbage", "hello", "", "", `invalid CIDR "hello"`},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := callback(t.Context(), &cidrArgs{CIDR: tt.cidr, IP: tt.ip})
+			if tt.errSubstr != "" {
+				if err == nil || !strings.Contains(err.Error(), tt.errSubstr) {
+					t.Fatalf("Expected error containing %q but got %v", tt.errSubstr, err)
+				}
+				return
+			}
+			if err != nil {
+				t.Fatalf("Unexpected error: %v", err)
+			}
+			if got != tt.expected {
+				t.Fatalf("Expected %s\ngot      %s", tt.expected, got)
+			}
+		})
+	}
+}
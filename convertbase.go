@@ -0,0 +1,84 @@
+// Copyright 2025 Marc-Antoine Ruel. All rights reserved.
+// Use of this source code is governed under the Apache License, Version 2.0
+// that can be found in the LICENSE file.
+
+package genaitools
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"math/big"
+	"strconv"
+	"strings"
+
+	"github.com/maruel/genai"
+)
+
+// ConvertBase converts an integer between bases 2 to 36.
+//
+// Values of any size are supported. The digits above 9 are the letters a to z,
+// in any case on input and lowercase on output. A leading sign and the 0b,
+// 0o and 0x prefixes matching the source base are accepted.
+var ConvertBase = genai.ToolDef{
+	Name:        "convert_base",
+	Description: "Converts an integer of any size from one base to another, between base 2 and 36, e.g. hexadecimal to binary.",
+	Callback:    doConvertBase,
+}
+
+type convertBaseArgs struct {
+	Value    string `json:"value" jsonschema:"description=Integer in the source base like ff or -101"`
+	FromBase int    `json:"from_base" jsonschema:"minimum=2,maximum=36,description=Base of value"`
+	ToBase   int    `json:"to_base" jsonschema:"minimum=2,maximum=36,description=Base of the result"`
+}
+
+var basePrefixes = map[int]string{2: "0b", 8: "0o", 16: "0x"}
+
+func doConvertBase(ctx context.Context, args *convertBaseArgs) (string, error) {
+	for _, b := range []int{args.FromBase, args.ToBase} {
+		if b < 2 || b > 36 {
+			return "", fmt.Errorf("invalid base %d; it must be between 2 and 36", b)
+		}
+	}
+	s := strings.TrimSpace(args.Value)
+	sign := ""
+	if s != "" && (s[0] == '-' || s[0] == '+') {
+		if s[0] == '-' {
+			sign = "-"
+		}
+		s = s[1:]
+	}
+	if p := basePrefixes[args.FromBase]; p != "" && len(s) > len(p) && strings.EqualFold(s[:len(p)], p) {
+		s = s[len(p):]
+	}
+	if s == "" {
+		return "", errors.New("missing value")
+	}
+	for _, c := range s {
+		if d := digitValue(c); d < 0 || d >= args.FromBase {
+			return "", fmt.Errorf("invalid digit %q for base %d in %q", c, args.FromBase, args.Value)
+		}
+	}
+	if i, err := strconv.ParseInt(sign+s, args.FromBase, 64); err == nil {
+		return strconv.FormatInt(i, args.ToBase), nil
+	}
+	// Fall back to big.Int for values overflowing 64 bits.
+	n, ok := new(big.Int).SetString(sign+s, args.FromBase)
+	if !ok {
+		return "", fmt.Errorf("invalid value %q for base %d", args.Value, args.FromBase)
+	}
+	return n.Text(args.ToBase), nil
+}
+
+// digitValue returns the value of the digit c in base 36, or -1.
+func digitValue(c rune) int {
+	switch {
+	case c >= '0' && c <= '9':
+		return int(c - '0')
+	case c >= 'a' && c <= 'z':
+		return int(c-'a') + 10
+	case c >= 'A' && c <= 'Z':
+		return int(c-'A') + 10
+	}
+	return -1
+}
@@ -0,0 +1,60 @@
+// Copyright 2025 Marc-Antoine Ruel. All rights reserved.
+// Use of this source code is governed under the Apache License, Version 2.0
+// that can be found in the LICENSE file.
+
+package genaitools
+
+import (
+	"context"
+	"strings"
+	"testing"
+)
+
+func TestConvertBase(t *testing.T) {
+	callback := ConvertBase.Callback.(func(context.Context, *convertBaseArgs) (string, error))
+	tests := []struct {
+		name      string
+		value     string
+		from, to  int
+		expected  string
+		errSubstr string
+	}{
+		{"hex_to_binary", "ff", 16, 2, "11111111", ""},
+		{"hex_upper_prefix", "0xDEADBEEF", 16, 10, "3735928559", ""},
+		{"decimal_to_base36", "1234567890", 10, 36, "kf12oi", ""},
+		{"base36_to_decimal", "KF12OI", 36, 10, "1234567890", ""},
+		{"binary_prefix", "0b1010", 2, 8, "12", ""},
+		{"octal_prefix", "0o777", 8, 16, "1ff", ""},
+		{"negative", "-255", 10, 16, "-ff", ""},
+		{"plus", " +7 ", 10, 2, "111", ""},
+		{"zero", "0", 10, 2, "0", ""},
+		{"min_int64", "-9223372036854775808", 10, 16, "-8000000000000000", ""},
+		{"big", "ffffffffffffffffffffffffffffffff", 16, 10, "340282366920938463463374607431768211455", ""},
+		{"big_negative", "-100000000000000000000000000000000000000000000000000000000000000000", 2, 16, "-20000000000000000", ""},
+		{"prefix_other_base", "0x1", 10, 2, "", `invalid digit 'x' for base 10 in "0x1"`},
+		{"invalid_digit", "12a", 10, 2, "", `invalid digit 'a' for base 10 in "12a"`},
+		{"invalid_binary", "102", 2, 10, "", `invalid digit '2' for base 2`},
+		{"invalid_symbol", "1.5", 10, 2, "", `invalid digit '.' for base 10`},
+		{"empty", " ", 10, 2, "", "missing value"},
+		{"sign_only", "-", 10, 2, "", "missing value"},
+		{"bad_from", "1", 1, 10, "", "invalid base 1; it must be between 2 and 36"},
+		{"bad_to", "1", 10, 37, "", "invalid base 37; it must be between 2 and 36"},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := callback(t.Context(), &convertBaseArgs{Value: tt.value, FromBase: tt.from, ToBase: tt.to})
+			if tt.errSubstr != "" {
+				if err == nil || !strings.Contains(err.Error(), tt.errSubstr) {
+					t.Fatalf("Expected error containing %q but got %v", tt.errSubstr, err)
+				}
+				return
+			}
+			if err != nil {
+				t.Fatalf("Unexpected error: %v", err)
+			}
+			if got != tt.expected {
+				t.Fatalf("Expected %q but got %q", tt.expected, got)
+			}
+		})
+	}
+}
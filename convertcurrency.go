@@ -0,0 +1,211 @@
+// Copyright 2025 Marc-Antoine Ruel. All rights reserved.
+// Use of this source code is governed under the Apache License, Version 2.0
+// that can be found in the LICENSE file.
+
+package genaitools
+
+import (
+	"context"
+	"encoding/json"
+	"encoding/xml"
+	"errors"
+	"fmt"
+	"maps"
+	"math"
+	"math/big"
+	"net/http"
+	"slices"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/maruel/genai"
+	"golang.org/x/text/currency"
+)
+
+// RateProvider returns currency exchange rates.
+type RateProvider interface {
+	// Rate returns the number of units of the currency to for one unit of the
+	// currency from. Both are uppercase ISO 4217 codes.
+	Rate(ctx context.Context, from, to string) (float64, error)
+}
+
+// ConvertCurrency converts an amount of money with the European Central Bank
+// reference rates.
+//
+// See NewConvertCurrency and NewECBRates for details.
+var ConvertCurrency = NewConvertCurrency(NewECBRates(nil))
+
+// NewConvertCurrency returns a tool that converts an amount of money between
+// currencies with the rates from rates.
+//
+// The converted amount is rounded half away from zero to the number of minor
+// units of the target currency, e.g. 2 for EUR and none for JPY. It defaults
+// to 2 for unknown currencies.
+func NewConvertCurrency(rates RateProvider) genai.ToolDef {
+	return genai.ToolDef{
+		Name:        "convert_currency",
+		Description: "Converts an amount of money from one currency to another with current exchange rates. Returns JSON with the converted amount and the rate used.",
+		Callback: func(ctx context.Context, args *convertCurrencyArgs) (string, error) {
+			return doConvertCurrency(ctx, rates, args)
+		},
+	}
+}
+
+type convertCurrencyArgs struct {
+	Amount json.Number `json:"amount" jsonschema:"type=number,description=Amount of money"`
+	From   string      `json:"from" jsonschema:"description=ISO 4217 code of the currency of amount like USD"`
+	To     string      `json:"to" jsonschema:"description=ISO 4217 code of the currency to convert to like EUR"`
+}
+
+type convertCurrencyResult struct {
+	Amount   string  `json:"amount"`
+	Currency string  `json:"currency"`
+	Rate     float64 `json:"rate"`
+}
+
+func doConvertCurrency(ctx context.Context, rates RateProvider, args *convertCurrencyArgs) (string, error) {
+	amount, ok := new(big.Rat).SetString(args.Amount.String())
+	if !ok {
+		return "", fmt.Errorf("invalid amount %q", args.Amount)
+	}
+	from, err := parseCurrencyCode(args.From)
+	if err != nil {
+		return "", err
+	}
+	to, err := parseCurrencyCode(args.To)
+	if err != nil {
+		return "", err
+	}
+	rate := 1.
+	if from != to {
+		if rate, err = rates.Rate(ctx, from, to); err != nil {
+			return "", err
+		}
+		if rate <= 0 || math.IsInf(rate, 0) || math.IsNaN(rate) {
+			return "", fmt.Errorf("invalid rate %v from %s to %s", rate, from, to)
+		}
+	}
+	digits := 2
+	if u, err := currency.ParseISO(to); err == nil {
+		digits, _ = currency.Standard.Rounding(u)
+	}
+	amount.Mul(amount, new(big.Rat).SetFloat64(rate))
+	res := convertCurrencyResult{Amount: amount.FloatString(digits), Currency: to, Rate: rate}
+	if strings.Trim(res.Amount, "-0.") == "" {
+		res.Amount = strings.TrimPrefix(res.Amount, "-")
+	}
+	b, err := json.Marshal(res)
+	return string(b), err
+}
+
+func parseCurrencyCode(s string) (string, error) {
+	c := strings.ToUpper(strings.TrimSpace(s))
+	if len(c) != 3 || !isASCIILetter(c[0]) || !isASCIILetter(c[1]) || !isASCIILetter(c[2]) {
+		return "", fmt.Errorf("invalid currency %q; expected an ISO 4217 code like USD", s)
+	}
+	return c, nil
+}
+
+// ecbURL is the daily euro foreign exchange reference rates of the European
+// Central Bank.
+const ecbURL = "https://www.ecb.europa.eu/stats/eurofxref/eurofxref-daily.xml"
+
+// ecbRefresh is how long the ECB rates are cached.
+const ecbRefresh = time.Hour
+
+// NewECBRates returns a RateProvider using the euro foreign exchange reference
+// rates published every working day by the European Central Bank, fetched
+// with client. client defaults to http.DefaultClient.
+//
+// It covers about 30 major currencies; rates between two non-euro currencies
+// are computed through the euro. The rates are fetched lazily and cached for
+// an hour.
+func NewECBRates(client *http.Client) RateProvider {
+	if client == nil {
+		client = http.DefaultClient
+	}
+	return &ecbRates{client: client, url: ecbURL}
+}
+
+type ecbRates struct {
+	client *http.Client
+	url    string
+
+	mu      sync.Mutex
+	fetched time.Time
+	rates   map[string]float64 // Units per euro.
+}
+
+func (e *ecbRates) Rate(ctx context.Context, from, to string) (float64, error) {
+	rates, err := e.load(ctx)
+	if err != nil {
+		return 0, err
+	}
+	f, ok := rates[from]
+	if !ok {
+		return 0, e.unsupported(from, rates)
+	}
+	t, ok := rates[to]
+	if !ok {
+		return 0, e.unsupported(to, rates)
+	}
+	return t / f, nil
+}
+
+func (e *ecbRates) unsupported(c string, rates map[string]float64) error {
+	return fmt.Errorf("unsupported currency %q; supported currencies are %s", c, strings.Join(slices.Sorted(maps.Keys(rates)), ", "))
+}
+
+// load returns the cached rates, fetching them when stale.
+func (e *ecbRates) load(ctx context.Context) (map[string]float64, error) {
+	e.mu.Lock()
+	defer e.mu.Unlock()
+	if e.rates != nil && time.Since(e.fetched) < ecbRefresh {
+		return e.rates, nil
+	}
+	if e.client.Timeout == 0 {
+		var cancel context.CancelFunc
+		ctx, cancel = context.WithTimeout(ctx, fetchTimeout)
+		defer cancel()
+	}
+	req, err := http.NewRequestWithContext(ctx, http.MethodGet, e.url, nil)
+	if err != nil {
+		return nil, err
+	}
+	req.Header.Set("User-Agent", UserAgent)
+	resp, err := e.client.Do(req)
+	if err != nil {
+		return nil, fmt.Errorf("failed to fetch the exchange rates: %w", err)
+	}
+	defer resp.Body.Close()
+	if resp.StatusCode != http.StatusOK {
+		return nil, fmt.Errorf("failed to fetch the exchange rates: HTTP %s", resp.Status)
+	}
+	var doc struct {
+		Cube struct {
+			Cube []struct {
+				Rates []struct {
+					Currency string  `xml:"currency,attr"`
+					Rate     float64 `xml:"rate,attr"`
+				} `xml:"Cube"`
+			} `xml:"Cube"`
+		} `xml:"Cube"`
+	}
+	if err = xml.NewDecoder(resp.Body).Decode(&doc); err != nil {
+		return nil, fmt.Errorf("failed to decode the exchange rates: %w", err)
+	}
+	rates := map[string]float64{"EUR": 1}
+	for _, d := range doc.Cube.Cube {
+		for _, r := range d.Rates {
+			if r.Rate > 0 {
+				rates[strings.ToUpper(r.Currency)] = r.Rate
+			}
+		}
+	}
+	if len(rates) == 1 {
+		return nil, errors.New("failed to decode the exchange rates: no rate found")
+	}
+	e.rates, e.fetched = rates, time.Now()
+	return rates, nil
+}
@@ -0,0 +1,126 @@
+// Copyright 2025 Marc-Antoine Ruel. All rights reserved.
+// Use of this source code is governed under the Apache License, Version 2.0
+// that can be found in the LICENSE file.
+
+package genaitools
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+)
+
+// fixedRates is a RateProvider with rates in units per USD.
+type fixedRates map[string]float64
+
+func (f fixedRates) Rate(ctx context.Context, from, to string) (float64, error) {
+	a, ok := f[from]
+	if !ok {
+		return 0, fmt.Errorf("unknown currency %q", from)
+	}
+	b, ok := f[to]
+	if !ok {
+		return 0, fmt.Errorf("unknown currency %q", to)
+	}
+	return b / a, nil
+}
+
+func TestConvertCurrency(t *testing.T) {
+	tool := NewConvertCurrency(fixedRates{"USD": 1, "EUR": 0.5, "JPY": 150, "KWD": 0.3, "XXX": 0})
+	callback := tool.Callback.(func(context.Context, *convertCurrencyArgs) (string, error))
+	tests := []struct {
+		name      string
+		args      convertCurrencyArgs
+		expected  string
+		errSubstr string
+	}{
+		{"usd_eur", convertCurrencyArgs{Amount: "100", From: "USD", To: "EUR"}, `{"amount":"50.00","currency":"EUR","rate":0.5}`, ""},
+		{"rounding", convertCurrencyArgs{Amount: "0.01", From: "usd", To: " eur "}, `{"amount":"0.01","currency":"EUR","rate":0.5}`, ""},
+		{"no_minor_units", convertCurrencyArgs{Amount: "12.34", From: "USD", To: "JPY"}, `{"amount":"1851","currency":"JPY","rate":150}`, ""},
+		{"three_minor_units", convertCurrencyArgs{Amount: "10", From: "EUR", To: "KWD"}, `{"amount":"6.000","currency":"KWD","rate":0.6}`, ""},
+		{"negative", convertCurrencyArgs{Amount: "-3", From: "USD", To: "EUR"}, `{"amount":"-1.50","currency":"EUR","rate":0.5}`, ""},
+		{"negative_zero", convertCurrencyArgs{Amount: "-0.001", From: "USD", To: "EUR"}, `{"amount":"0.00","currency":"EUR","rate":0.5}`, ""},
+		{"same", convertCurrencyArgs{Amount: "1.5", From: "CHF", To: "chf"}, `{"amount":"1.50","currency":"CHF","rate":1}`, ""},
+		{"unknown", convertCurrencyArgs{Amount: "1", From: "USD", To: "ABC"}, "", `unknown currency "ABC"`},
+		{"bad_rate", convertCurrencyArgs{Amount: "1", From: "USD", To: "XXX"}, "", "invalid rate 0 from USD to XXX"},
+		{"bad_code", convertCurrencyArgs{Amount: "1", From: "US$", To: "EUR"}, "", `invalid currency "US$"`},
+		{"bad_amount", convertCurrencyArgs{Amount: "1e", From: "USD", To: "EUR"}, "", `invalid amount "1e"`},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := callback(t.Context(), &tt.args)
+			if tt.errSubstr != "" {
+				if err == nil || !strings.Contains(err.Error(), tt.errSubstr) {
+					t.Fatalf("Expected error containing %q but got %v", tt.errSubstr, err)
+				}
+				return
+			}
+			if err != nil {
+				t.Fatalf("Unexpected error: %v", err)
+			}
+			if got != tt.expected {
+				t.Fatalf("Expected %q but got %q", tt.expected, got)
+			}
+		})
+	}
+}
+
+func TestECBRates(t *testing.T) {
+	const body = `<?xml version="1.0" encoding="UTF-8"?>
+<gesmes:Envelope xmlns:gesmes="http://www.gesmes.org/xml/2002-08-01" xmlns="http://www.ecb.int/vocabulary/2002-08-01/eurofxref">
+	<gesmes:subject>Reference rates</gesmes:subject>
+	<Cube>
+		<Cube time='2025-01-10'>
+			<Cube currency='USD' rate='1.25'/>
+			<Cube currency='JPY' rate='150'/>
+		</Cube>
+	</Cube>
+</gesmes:Envelope>`
+	requests := 0
+	ts := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		requests++
+		w.Header().Set("Content-Type", "text/xml")
+		_, _ = w.Write([]byte(body))
+	}))
+	defer ts.Close()
+	e := NewECBRates(ts.Client()).(*ecbRates)
+	e.url = ts.URL
+	data := []struct {
+		from, to string
+		want     float64
+	}{
+		{"EUR", "USD", 1.25},
+		{"USD", "EUR", 0.8},
+		{"USD", "JPY", 120},
+	}
+	for _, line := range data {
+		got, err := e.Rate(t.Context(), line.from, line.to)
+		if err != nil {
+			t.Fatal(err)
+		}
+		if got != line.want {
+			t.Fatalf("%s to %s: Expected %v but got %v", line.from, line.to, line.want, got)
+		}
+	}
+	if requests != 1 {
+		t.Fatalf("Expected the rates to be cached but got %d requests", requests)
+	}
+	if _, err := e.Rate(t.Context(), "USD", "XYZ"); err == nil || err.Error() != `unsupported currency "XYZ"; supported currencies are EUR, JPY, USD` {
+		t.Fatalf("Unexpected error %v", err)
+	}
+}
+
+func TestECBRatesError(t *testing.T) {
+	ts := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		http.Error(w, "down", http.StatusServiceUnavailable)
+	}))
+	defer ts.Close()
+	e := NewECBRates(ts.Client()).(*ecbRates)
+	e.url = ts.URL
+	if _, err := e.Rate(t.Context(), "USD", "EUR"); err == nil || !strings.Contains(err.Error(), "HTTP 503") {
+		t.Fatalf("Unexpected error %v", err)
+	}
+}
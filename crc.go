@@ -0,0 +1,71 @@
+// Copyright 2025 Marc-Antoine Ruel. All rights reserved.
+// Use of this source code is governed under the Apache License, Version 2.0
+// that can be found in the LICENSE file.
+
+package genaitools
+
+import (
+	"context"
+	"encoding/base64"
+	"fmt"
+	"hash/crc32"
+	"hash/crc64"
+
+	"github.com/maruel/genai"
+)
+
+// CRC computes a CRC32 or CRC64 checksum and returns it as lowercase
+// hexadecimal.
+//
+// The supported variants are "crc32-ieee", "crc32-castagnoli",
+// "crc32-koopman", "crc64-iso" and "crc64-ecma". Binary data can be passed
+// encoded as base64.
+var CRC = genai.ToolDef{
+	Name:        "crc",
+	Description: "Computes the CRC32 or CRC64 checksum of data and returns it as hexadecimal. Useful to validate non-cryptographic checksums.",
+	Callback:    doCRC,
+}
+
+type crcArgs struct {
+	Variant  string `json:"variant" jsonschema:"enum=crc32-ieee,enum=crc32-castagnoli,enum=crc32-koopman,enum=crc64-iso,enum=crc64-ecma"`
+	Data     string `json:"data" jsonschema:"description=Data to checksum"`
+	Encoding string `json:"encoding,omitempty" jsonschema:"description=Encoding of data. Use base64 for binary data. Defaults to text,enum=text,enum=base64"`
+}
+
+func doCRC(ctx context.Context, args *crcArgs) (string, error) {
+	b, err := decodeData(args.Data, args.Encoding)
+	if err != nil {
+		return "", err
+	}
+	switch args.Variant {
+	case "crc32-ieee":
+		return fmt.Sprintf("%08x", crc32.ChecksumIEEE(b)), nil
+	case "crc32-castagnoli":
+		return fmt.Sprintf("%08x", crc32.Checksum(b, crc32.MakeTable(crc32.Castagnoli))), nil
+	case "crc32-koopman":
+		return fmt.Sprintf("%08x", crc32.Checksum(b, crc32.MakeTable(crc32.Koopman))), nil
+	case "crc64-iso":
+		return fmt.Sprintf("%016x", crc64.Checksum(b, crc64.MakeTable(crc64.ISO))), nil
+	case "crc64-ecma":
+		return fmt.Sprintf("%016x", crc64.Checksum(b, crc64.MakeTable(crc64.ECMA))), nil
+	default:
+		return "", fmt.Errorf("unknown variant %q; supported variants are crc32-ieee, crc32-castagnoli, crc32-koopman, crc64-iso and crc64-ecma", args.Variant)
+	}
+}
+
+// decodeData decodes data passed by the LLM as either "text" (the default) or
+// "base64".
+func decodeData(data, encoding string) ([]byte, error) {
+	switch encoding {
+	case "", "text":
+		return []byte(data), nil
+	case "base64":
+		b, err := base64.StdEncoding.DecodeString(data)
+		if err != nil {
+			return nil, fmt.Errorf("invalid base64 data: %w", err)
+		}
+		return b, nil
+	default:
+		return nil, fmt.Errorf("unknown encoding %q; supported encodings are text and base64", encoding)
+	}
+}
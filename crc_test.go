@@ -0,0 +1,49 @@
+// Copyright 2025 Marc-Antoine Ruel. All rights reserved.
+// Use of this source code is governed under the Apache License, Version 2.0
+// that can be found in the LICENSE file.
+
+package genaitools
+
+import (
+	"context"
+	"strings"
+	"testing"
+)
+
+func TestCRC(t *testing.T) {
+	callback := CRC.Callback.(func(context.Context, *crcArgs) (string, error))
+	tests := []struct {
+		name      string
+		args      crcArgs
+		expected  string
+		errSubstr string
+	}{
+		// Check values from the CRC catalogue, computed over "123456789".
+		{"crc32_ieee", crcArgs{Variant: "crc32-ieee", Data: "123456789"}, "cbf43926", ""},
+		{"crc32_castagnoli", crcArgs{Variant: "crc32-castagnoli", Data: "123456789"}, "e3069283", ""},
+		{"crc64_ecma", crcArgs{Variant: "crc64-ecma", Data: "123456789"}, "995dc9bbdf1939fa", ""},
+		{"crc64_iso", crcArgs{Variant: "crc64-iso", Data: "123456789"}, "b90956c775a41001", ""},
+		{"base64", crcArgs{Variant: "crc32-ieee", Data: "MTIzNDU2Nzg5", Encoding: "base64"}, "cbf43926", ""},
+		{"empty", crcArgs{Variant: "crc32-ieee"}, "00000000", ""},
+		{"bad_variant", crcArgs{Variant: "crc16", Data: "a"}, "", "unknown variant"},
+		{"bad_base64", crcArgs{Variant: "crc32-ieee", Data: "!!!", Encoding: "base64"}, "", "invalid base64"},
+		{"bad_encoding", crcArgs{Variant: "crc32-ieee", Data: "a", Encoding: "hex"}, "", "unknown encoding"},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := callback(t.Context(), &tt.args)
+			if tt.errSubstr != "" {
+				if err == nil || !strings.Contains(err.Error(), tt.errSubstr) {
+					t.Fatalf("Expected error containing %q but got %v", tt.errSubstr, err)
+				}
+				return
+			}
+			if err != nil {
+				t.Fatalf("Unexpected error: %v", err)
+			}
+			if got != tt.expected {
+				t.Fatalf("Expected %q but got %q", tt.expected, got)
+			}
+		})
+	}
+}
@@ -0,0 +1,138 @@
+// Copyright 2025 Marc-Antoine Ruel. All rights reserved.
+// Use of this source code is governed under the Apache License, Version 2.0
+// that can be found in the LICENSE file.
+
+package genaitools
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/maruel/genai"
+)
+
+// DateRange resolves a natural language date range relative to now into
+// concrete start and end timestamps.
+//
+// Supported phrases, case insensitive:
+//   - "today", "yesterday", "tomorrow"
+//   - "this", "last", "previous" or "next" followed by "week", "month",
+//     "quarter" or "year": calendar periods, weeks start on Monday
+//   - "last N days", "last N weeks", "last N months", "last N years" (or
+//     "past N ..."): rolling periods ending with and including today
+//   - "week to date", "month to date", "quarter to date", "year to date" (or
+//     "wtd", "mtd", "qtd", "ytd")
+//
+// The start is inclusive and the end is exclusive; both are at midnight so the
+// range covers whole days.
+var DateRange = genai.ToolDef{
+	Name:        "date_range",
+	Description: "Resolves a date range phrase like \"last 7 days\", \"this month\", \"year to date\" or \"previous quarter\" relative to now into a start (inclusive) and end (exclusive) in RFC3339 format. Weeks start on Monday.",
+	Callback: func(ctx context.Context, args *dateRangeArgs) (string, error) {
+		return doDateRange(args, time.Now())
+	},
+}
+
+type dateRangeArgs struct {
+	Phrase   string `json:"phrase" jsonschema:"description=Phrase like today or last 7 days or previous quarter or year to date"`
+	Timezone string `json:"timezone,omitempty" jsonschema:"description=IANA time zone like America/New_York. Defaults to the local time zone"`
+}
+
+type dateRangeResult struct {
+	Start string `json:"start"`
+	End   string `json:"end"`
+}
+
+func doDateRange(args *dateRangeArgs, now time.Time) (string, error) {
+	if args.Timezone != "" {
+		loc, err := time.LoadLocation(args.Timezone)
+		if err != nil {
+			return "", fmt.Errorf("invalid timezone %q: %w", args.Timezone, err)
+		}
+		now = now.In(loc)
+	}
+	start, end, err := resolveDateRange(args.Phrase, now)
+	if err != nil {
+		return "", err
+	}
+	b, err := json.Marshal(dateRangeResult{Start: start.Format(time.RFC3339), End: end.Format(time.RFC3339)})
+	return string(b), err
+}
+
+func resolveDateRange(phrase string, now time.Time) (time.Time, time.Time, error) {
+	y, m, d := now.Date()
+	loc := now.Location()
+	day := func(y int, m time.Month, d int) time.Time {
+		return time.Date(y, m, d, 0, 0, 0, 0, loc)
+	}
+	today := day(y, m, d)
+	tomorrow := day(y, m, d+1)
+	// Monday of the current week.
+	weekStart := day(y, m, d-(int(now.Weekday())+6)%7)
+	quarterStart := day(y, m-(m-1)%3, 1)
+	fields := strings.Fields(strings.ToLower(phrase))
+	switch strings.Join(fields, " ") {
+	case "today":
+		return today, tomorrow, nil
+	case "yesterday":
+		return day(y, m, d-1), today, nil
+	case "tomorrow":
+		return tomorrow, day(y, m, d+2), nil
+	case "week to date", "wtd":
+		return weekStart, tomorrow, nil
+	case "month to date", "mtd":
+		return day(y, m, 1), tomorrow, nil
+	case "quarter to date", "qtd":
+		return quarterStart, tomorrow, nil
+	case "year to date", "ytd":
+		return day(y, time.January, 1), tomorrow, nil
+	}
+	if len(fields) == 2 {
+		// Calendar period; offset is relative to the current one.
+		offset := 0
+		switch fields[0] {
+		case "this", "current":
+		case "last", "previous", "prior":
+			offset = -1
+		case "next":
+			offset = 1
+		default:
+			return time.Time{}, time.Time{}, fmt.Errorf("unsupported date range %q", phrase)
+		}
+		switch fields[1] {
+		case "week":
+			s := weekStart.AddDate(0, 0, 7*offset)
+			return s, s.AddDate(0, 0, 7), nil
+		case "month":
+			s := day(y, m+time.Month(offset), 1)
+			return s, s.AddDate(0, 1, 0), nil
+		case "quarter":
+			s := quarterStart.AddDate(0, 3*offset, 0)
+			return s, s.AddDate(0, 3, 0), nil
+		case "year":
+			s := day(y+offset, time.January, 1)
+			return s, s.AddDate(1, 0, 0), nil
+		}
+	}
+	if len(fields) == 3 && (fields[0] == "last" || fields[0] == "past") {
+		n, err := strconv.Atoi(fields[1])
+		if err != nil || n < 1 {
+			return time.Time{}, time.Time{}, fmt.Errorf("unsupported date range %q; expected a positive number of days, weeks, months or years", phrase)
+		}
+		switch strings.TrimSuffix(fields[2], "s") {
+		case "day":
+			return day(y, m, d-n+1), tomorrow, nil
+		case "week":
+			return day(y, m, d-7*n+1), tomorrow, nil
+		case "month":
+			return tomorrow.AddDate(0, -n, 0), tomorrow, nil
+		case "year":
+			return tomorrow.AddDate(-n, 0, 0), tomorrow, nil
+		}
+	}
+	return time.Time{}, time.Time{}, fmt.Errorf("unsupported date range %q", phrase)
+}
@@ -0,0 +1,61 @@
+// Copyright 2025 Marc-Antoine Ruel. All rights reserved.
+// Use of this source code is governed under the Apache License, Version 2.0
+// that can be found in the LICENSE file.
+
+package genaitools
+
+import (
+	"strings"
+	"testing"
+	"time"
+)
+
+func TestDateRange(t *testing.T) {
+	// Wednesday.
+	now := time.Date(2024, 5, 15, 14, 30, 0, 0, time.UTC)
+	tests := []struct {
+		phrase    string
+		tz        string
+		expected  string
+		errSubstr string
+	}{
+		{"today", "", `{"start":"2024-05-15T00:00:00Z","end":"2024-05-16T00:00:00Z"}`, ""},
+		{"Yesterday", "", `{"start":"2024-05-14T00:00:00Z","end":"2024-05-15T00:00:00Z"}`, ""},
+		{"last 7 days", "", `{"start":"2024-05-09T00:00:00Z","end":"2024-05-16T00:00:00Z"}`, ""},
+		{"past 1 day", "", `{"start":"2024-05-15T00:00:00Z","end":"2024-05-16T00:00:00Z"}`, ""},
+		{"last 2 weeks", "", `{"start":"2024-05-02T00:00:00Z","end":"2024-05-16T00:00:00Z"}`, ""},
+		{"last 3 months", "", `{"start":"2024-02-16T00:00:00Z","end":"2024-05-16T00:00:00Z"}`, ""},
+		{"this week", "", `{"start":"2024-05-13T00:00:00Z","end":"2024-05-20T00:00:00Z"}`, ""},
+		{"last week", "", `{"start":"2024-05-06T00:00:00Z","end":"2024-05-13T00:00:00Z"}`, ""},
+		{"this month", "", `{"start":"2024-05-01T00:00:00Z","end":"2024-06-01T00:00:00Z"}`, ""},
+		{"previous month", "", `{"start":"2024-04-01T00:00:00Z","end":"2024-05-01T00:00:00Z"}`, ""},
+		{"next month", "", `{"start":"2024-06-01T00:00:00Z","end":"2024-07-01T00:00:00Z"}`, ""},
+		{"this quarter", "", `{"start":"2024-04-01T00:00:00Z","end":"2024-07-01T00:00:00Z"}`, ""},
+		{"previous quarter", "", `{"start":"2024-01-01T00:00:00Z","end":"2024-04-01T00:00:00Z"}`, ""},
+		{"last year", "", `{"start":"2023-01-01T00:00:00Z","end":"2024-01-01T00:00:00Z"}`, ""},
+		{"year to date", "", `{"start":"2024-01-01T00:00:00Z","end":"2024-05-16T00:00:00Z"}`, ""},
+		{"QTD", "", `{"start":"2024-04-01T00:00:00Z","end":"2024-05-16T00:00:00Z"}`, ""},
+		{"week to date", "", `{"start":"2024-05-13T00:00:00Z","end":"2024-05-16T00:00:00Z"}`, ""},
+		{"today", "Asia/Tokyo", `{"start":"2024-05-15T00:00:00+09:00","end":"2024-05-16T00:00:00+09:00"}`, ""},
+		{"last fortnight", "", "", "unsupported date range"},
+		{"last -3 days", "", "", "positive number"},
+		{"today", "Mars/Olympus", "", "invalid timezone"},
+	}
+	for _, tt := range tests {
+		t.Run(tt.phrase, func(t *testing.T) {
+			got, err := doDateRange(&dateRangeArgs{Phrase: tt.phrase, Timezone: tt.tz}, now)
+			if tt.errSubstr != "" {
+				if err == nil || !strings.Contains(err.Error(), tt.errSubstr) {
+					t.Fatalf("Expected error containing %q but got %v", tt.errSubstr, err)
+				}
+				return
+			}
+			if err != nil {
+				t.Fatalf("Unexpected error: %v", err)
+			}
+			if got != tt.expected {
+				t.Fatalf("Expected %q but got %q", tt.expected, got)
+			}
+		})
+	}
+}
@@ -0,0 +1,82 @@
+// Copyright 2025 Marc-Antoine Ruel. All rights reserved.
+// Use of this source code is governed under the Apache License, Version 2.0
+// that can be found in the LICENSE file.
+
+package genaitools
+
+import (
+	"bytes"
+	"context"
+	"encoding/base64"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"strings"
+
+	"github.com/maruel/genai"
+)
+
+// DecodeJWT decodes the header and the payload of a JSON Web Token.
+//
+// The signature is NOT verified and no key is needed. The output is labeled
+// as such so the decoded claims are not mistaken as trusted.
+var DecodeJWT = genai.ToolDef{
+	Name:        "decode_jwt",
+	Description: "Decodes the header and payload of a JWT (JSON Web Token) as indented JSON for debugging. The signature is NOT verified; never trust the decoded claims.",
+	Callback:    doDecodeJWT,
+}
+
+type decodeJWTArgs struct {
+	Token string `json:"token" jsonschema:"description=JWT like xxxxx.yyyyy.zzzzz; a Bearer prefix is ignored"`
+}
+
+func doDecodeJWT(ctx context.Context, args *decodeJWTArgs) (string, error) {
+	tok := strings.TrimSpace(args.Token)
+	if p, ok := strings.CutPrefix(tok, "Bearer "); ok {
+		tok = strings.TrimSpace(p)
+	}
+	parts := strings.Split(tok, ".")
+	if len(parts) != 3 {
+		if len(parts) == 5 {
+			return "", errors.New("invalid JWT: it has 5 segments; encrypted JWE tokens cannot be decoded")
+		}
+		return "", fmt.Errorf("invalid JWT: expected 3 segments separated by '.' but got %d", len(parts))
+	}
+	header, err := decodeJWTSegment("header", parts[0])
+	if err != nil {
+		return "", err
+	}
+	payload, err := decodeJWTSegment("payload", parts[1])
+	if err != nil {
+		return "", err
+	}
+	sig, err := base64.RawURLEncoding.DecodeString(strings.TrimRight(parts[2], "="))
+	if err != nil {
+		return "", fmt.Errorf("invalid JWT signature: not base64url: %w", err)
+	}
+	var b strings.Builder
+	b.WriteString("UNVERIFIED: the signature was not checked; do not trust these claims.\n\nHeader:\n")
+	b.WriteString(header)
+	b.WriteString("\n\nPayload:\n")
+	b.WriteString(payload)
+	fmt.Fprintf(&b, "\n\nSignature: %d bytes, not verified", len(sig))
+	return b.String(), nil
+}
+
+// decodeJWTSegment decodes a base64url encoded JSON object and returns it
+// indented.
+func decodeJWTSegment(name, s string) (string, error) {
+	raw, err := base64.RawURLEncoding.DecodeString(strings.TrimRight(s, "="))
+	if err != nil {
+		return "", fmt.Errorf("invalid JWT %s: not base64url: %w", name, err)
+	}
+	raw = bytes.TrimSpace(raw)
+	if len(raw) == 0 || raw[0] != '{' || !json.Valid(raw) {
+		return "", fmt.Errorf("invalid JWT %s: not a JSON object", name)
+	}
+	var buf bytes.Buffer
+	if err = json.Indent(&buf, raw, "", "  "); err != nil {
+		return "", err
+	}
+	return buf.String(), nil
+}
@@ -0,0 +1,276 @@
+// Copyright 2025 Marc-Antoine Ruel. All rights reserved.
+// Use of this source code is governed under the Apache License, Version 2.0
+// that can be found in the LICENSE file.
+
+package genaitools
+
+import (
+	"context"
+	"fmt"
+	"strings"
+
+	"github.com/maruel/genai"
+)
+
+const (
+	// maxDiffInput is the maximum size of each text Diff compares.
+	maxDiffInput = 1 << 20
+	// maxDiffEdits bounds the memory used to find the shortest edit script.
+	// Past this number of changed lines, the remaining lines are reported as
+	// replaced, which is correct but not minimal.
+	maxDiffEdits = 1000
+)
+
+// Diff compares two texts line by line and returns a unified diff, like
+// "diff -u".
+//
+// It finds the longest common subsequence of lines with Myers' algorithm.
+// CRLF line endings are normalized to LF before comparing, so texts differing
+// only by their line endings are reported as such instead of every line
+// changing. A missing newline at the end is reported with the usual "\ No
+// newline at end of file" marker.
+var Diff = genai.ToolDef{
+	Name:        "diff",
+	Description: "Compares two texts line by line and returns the differences as a unified diff like diff -u, with configurable context lines.",
+	Callback:    doDiff,
+}
+
+type diffArgs struct {
+	A       string `json:"a" jsonschema:"description=Original text"`
+	B       string `json:"b" jsonschema:"description=New text"`
+	Context *int   `json:"context,omitempty" jsonschema:"description=Number of unchanged lines shown around each change. Defaults to 3"`
+}
+
+// diffOp is an operation of an edit script: ' ' keeps a[ai] (equal to b[bi]),
+// '-' deletes a[ai] and '+' inserts b[bi].
+type diffOp struct {
+	kind   byte
+	ai, bi int
+}
+
+func doDiff(ctx context.Context, args *diffArgs) (string, error) {
+	for _, s := range []struct{ name, text string }{{"a", args.A}, {"b", args.B}} {
+		if len(s.text) > maxDiffInput {
+			return "", fmt.Errorf("%s is %d bytes; the maximum is %d bytes", s.name, len(s.text), maxDiffInput)
+		}
+	}
+	lines := 3
+	if args.Context != nil {
+		if lines = *args.Context; lines < 0 {
+			return "", fmt.Errorf("invalid context %d; it must not be negative", lines)
+		}
+	}
+	a := strings.ReplaceAll(args.A, "\r\n", "\n")
+	b := strings.ReplaceAll(args.B, "\r\n", "\n")
+	if a == b {
+		if args.A != args.B {
+			return fmt.Sprintf("no differences except line endings: a uses %s and b uses %s", lineEnding(args.A), lineEnding(args.B)), nil
+		}
+		return "no differences", nil
+	}
+	al, bl := splitLinesKeepEnds(a), splitLinesKeepEnds(b)
+	return formatUnified(al, bl, diffLines(al, bl), lines), nil
+}
+
+func lineEnding(s string) string {
+	crlf := strings.Count(s, "\r\n")
+	switch lf := strings.Count(s, "\n"); {
+	case crlf == 0:
+		return "LF"
+	case crlf == lf:
+		return "CRLF"
+	default:
+		return "mixed CRLF and LF"
+	}
+}
+
+// splitLinesKeepEnds splits s after each "\n". The last line lacks it when s
+// doesn't end with a newline.
+func splitLinesKeepEnds(s string) []string {
+	if s == "" {
+		return nil
+	}
+	lines := strings.SplitAfter(s, "\n")
+	if lines[len(lines)-1] == "" {
+		lines = lines[:len(lines)-1]
+	}
+	return lines
+}
+
+// diffLines returns the edit script turning a into b.
+func diffLines(a, b []string) []diffOp {
+	// Trim the common prefix and suffix, which is cheap and frequent.
+	pre := 0
+	for pre < len(a) && pre < len(b) && a[pre] == b[pre] {
+		pre++
+	}
+	suf := 0
+	for suf < len(a)-pre && suf < len(b)-pre && a[len(a)-1-suf] == b[len(b)-1-suf] {
+		suf++
+	}
+	var ops []diffOp
+	for i := range pre {
+		ops = append(ops, diffOp{' ', i, i})
+	}
+	for _, op := range myers(a[pre:len(a)-suf], b[pre:len(b)-suf]) {
+		ops = append(ops, diffOp{op.kind, op.ai + pre, op.bi + pre})
+	}
+	for i := range suf {
+		ops = append(ops, diffOp{' ', len(a) - suf + i, len(b) - suf + i})
+	}
+	return ops
+}
+
+// myers returns the shortest edit script turning a into b, as described in
+// "An O(ND) Difference Algorithm and Its Variations" by Eugene W. Myers.
+func myers(a, b []string) []diffOp {
+	n, m := len(a), len(b)
+	offset := n + m + 1
+	v := make([]int, 2*offset+1)
+	// trace[d] is v[-d-1:d+2] before step d.
+	var trace [][]int
+	for d := 0; d <= n+m; d++ {
+		if d > maxDiffEdits {
+			return replaceAll(n, m)
+		}
+		trace = append(trace, append([]int(nil), v[offset-d-1:offset+d+2]...))
+		for k := -d; k <= d; k += 2 {
+			var x int
+			if k == -d || (k != d && v[offset+k-1] < v[offset+k+1]) {
+				x = v[offset+k+1]
+			} else {
+				x = v[offset+k-1] + 1
+			}
+			y := x - k
+			for x < n && y < m && a[x] == b[y] {
+				x++
+				y++
+			}
+			v[offset+k] = x
+			if x >= n && y >= m {
+				return backtrack(trace, n, m)
+			}
+		}
+	}
+	return nil
+}
+
+// backtrack walks trace backward from (n, m) to recover the edit script.
+func backtrack(trace [][]int, n, m int) []diffOp {
+	var ops []diffOp
+	x, y := n, m
+	for d := len(trace) - 1; d >= 0; d-- {
+		v := trace[d]
+		at := func(k int) int { return v[k+d+1] }
+		k := x - y
+		prevK := k - 1
+		if k == -d || (k != d && at(k-1) < at(k+1)) {
+			prevK = k + 1
+		}
+		prevX := at(prevK)
+		prevY := prevX - prevK
+		for x > prevX && y > prevY {
+			x--
+			y--
+			ops = append(ops, diffOp{' ', x, y})
+		}
+		if d > 0 {
+			if x == prevX {
+				y--
+				ops = append(ops, diffOp{'+', x, y})
+			} else {
+				x--
+				ops = append(ops, diffOp{'-', x, y})
+			}
+		}
+	}
+	for i, j := 0, len(ops)-1; i < j; i, j = i+1, j-1 {
+		ops[i], ops[j] = ops[j], ops[i]
+	}
+	return ops
+}
+
+// replaceAll returns an edit script deleting all of a then inserting all of
+// b.
+func replaceAll(n, m int) []diffOp {
+	ops := make([]diffOp, 0, n+m)
+	for i := range n {
+		ops = append(ops, diffOp{'-', i, 0})
+	}
+	for i := range m {
+		ops = append(ops, diffOp{'+', n, i})
+	}
+	return ops
+}
+
+// formatUnified formats the edit script as hunks with context lines around
+// the changes.
+func formatUnified(a, b []string, ops []diffOp, context int) string {
+	var out strings.Builder
+	out.WriteString("--- a\n+++ b\n")
+	for i := 0; i < len(ops); {
+		if ops[i].kind == ' ' {
+			i++
+			continue
+		}
+		// Extend the hunk until the next change is more than 2*context equal
+		// lines away.
+		start := max(i-context, 0)
+		end := i
+		for end < len(ops) {
+			if ops[end].kind != ' ' {
+				end++
+				continue
+			}
+			run := end
+			for run < len(ops) && ops[run].kind == ' ' {
+				run++
+			}
+			if run == len(ops) || run-end > 2*context {
+				end = min(end+context, len(ops))
+				break
+			}
+			end = run
+		}
+		aLen, bLen := 0, 0
+		for _, op := range ops[start:end] {
+			if op.kind != '+' {
+				aLen++
+			}
+			if op.kind != '-' {
+				bLen++
+			}
+		}
+		fmt.Fprintf(&out, "@@ -%s +%s @@\n", hunkRange(ops[start].ai, aLen), hunkRange(ops[start].bi, bLen))
+		for _, op := range ops[start:end] {
+			line := ""
+			switch op.kind {
+			case ' ', '-':
+				line = a[op.ai]
+			default:
+				line = b[op.bi]
+			}
+			out.WriteByte(op.kind)
+			out.WriteString(line)
+			if !strings.HasSuffix(line, "\n") {
+				out.WriteString("\n\\ No newline at end of file\n")
+			}
+		}
+		i = end
+	}
+	return out.String()
+}
+
+// hunkRange formats a range of a hunk header. start is the 0-based index of
+// the first line.
+func hunkRange(start, n int) string {
+	switch n {
+	case 0:
+		// An empty range refers to the line before.
+		return fmt.Sprintf("%d,0", start)
+	case 1:
+		return fmt.Sprintf("%d", start+1)
+	default:
+		return fmt.Sprintf("%d,%d", start+1, n)
+	}
+}
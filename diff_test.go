@@ -0,0 +1,96 @@
+// Copyright 2025 Marc-Antoine Ruel. All rights reserved.
+// Use of this source code is governed under the Apache License, Version 2.0
+// that can be found in the LICENSE file.
+
+package genaitools
+
+import (
+	"context"
+	"strings"
+	"testing"
+)
+
+func TestDiff(t *testing.T) {
+	callback := Diff.Callback.(func(context.Context, *diffArgs) (string, error))
+	zero, one, negative := 0, 1, -1
+	const twelve = "1\n2\n3\n4\n5\n6\n7\n8\n9\n10\n11\n12\n"
+	const twelveChanged = "1\n2\nX\n4\n5\n6\n7\n8\n9\n10\nY\n12\n"
+	tests := []struct {
+		name      string
+		args      diffArgs
+		expected  string
+		errSubstr string
+	}{
+		{"replace", diffArgs{A: "a\nb\nc\n", B: "a\nB\nc\n"}, "--- a\n+++ b\n@@ -1,3 +1,3 @@\n a\n-b\n+B\n c\n", ""},
+		{"delete", diffArgs{A: "a\nb\nc\n", B: "a\nc\n"}, "--- a\n+++ b\n@@ -1,3 +1,2 @@\n a\n-b\n c\n", ""},
+		{"insert", diffArgs{A: "a\nc\n", B: "a\nb\nc\n"}, "--- a\n+++ b\n@@ -1,2 +1,3 @@\n a\n+b\n c\n", ""},
+		{
+			"two_hunks",
+			diffArgs{A: twelve, B: twelveChanged, Context: &one},
+			"--- a\n+++ b\n@@ -2,3 +2,3 @@\n 2\n-3\n+X\n 4\n@@ -10,3 +10,3 @@\n 10\n-11\n+Y\n 12\n",
+			"",
+		},
+		{
+			"split_hunks",
+			diffArgs{A: twelve, B: twelveChanged},
+			"--- a\n+++ b\n@@ -1,6 +1,6 @@\n 1\n 2\n-3\n+X\n 4\n 5\n 6\n@@ -8,5 +8,5 @@\n 8\n 9\n 10\n-11\n+Y\n 12\n",
+			"",
+		},
+		{
+			"merged_hunks",
+			diffArgs{A: "1\n2\n3\n4\n5\n", B: "1\nX\n3\nY\n5\n", Context: &one},
+			"--- a\n+++ b\n@@ -1,5 +1,5 @@\n 1\n-2\n+X\n 3\n-4\n+Y\n 5\n",
+			"",
+		},
+		{"no_newline", diffArgs{A: "a\nb", B: "a\nb\n"}, "--- a\n+++ b\n@@ -1,2 +1,2 @@\n a\n-b\n\\ No newline at end of file\n+b\n", ""},
+		{"from_empty", diffArgs{A: "", B: "a\n"}, "--- a\n+++ b\n@@ -0,0 +1 @@\n+a\n", ""},
+		{"to_empty", diffArgs{A: "a\nb\n", B: "b\n", Context: &zero}, "--- a\n+++ b\n@@ -1 +0,0 @@\n-a\n", ""},
+		{"crlf_normalized", diffArgs{A: "a\r\nb\r\n", B: "a\nc\n"}, "--- a\n+++ b\n@@ -1,2 +1,2 @@\n a\n-b\n+c\n", ""},
+		{"line_endings_only", diffArgs{A: "a\r\nb\r\n", B: "a\nb\n"}, "no differences except line endings: a uses CRLF and b uses LF", ""},
+		{"mixed_line_endings", diffArgs{A: "a\nb\n", B: "a\r\nb\n"}, "no differences except line endings: a uses LF and b uses mixed CRLF and LF", ""},
+		{"equal", diffArgs{A: "a\n", B: "a\n"}, "no differences", ""},
+		{"bad_context", diffArgs{A: "a", B: "b", Context: &negative}, "", "invalid context -1"},
+		{"too_large", diffArgs{A: strings.Repeat("a", maxDiffInput+1)}, "", "a is 1048577 bytes"},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := callback(t.Context(), &tt.args)
+			if tt.errSubstr != "" {
+				if err == nil || !strings.Contains(err.Error(), tt.errSubstr) {
+					t.Fatalf("Expected error containing %q but got %v", tt.errSubstr, err)
+				}
+				return
+			}
+			if err != nil {
+				t.Fatalf("Unexpected error: %v", err)
+			}
+			if got != tt.expected {
+				t.Fatalf("Expected %q but got %q", tt.expected, got)
+			}
+		})
+	}
+}
+
+func TestDiffLinesMaxEdits(t *testing.T) {
+	// Past maxDiffEdits, the middle is replaced wholesale; the script must still
+	// turn a into b.
+	var a, b []string
+	for i := range maxDiffEdits {
+		a = append(a, "a"+strings.Repeat("x", i%7)+"\n")
+		b = append(b, "b"+strings.Repeat("x", i%5)+"\n")
+	}
+	a = append([]string{"same\n"}, a...)
+	b = append([]string{"same\n"}, b...)
+	var got []string
+	for _, op := range diffLines(a, b) {
+		switch op.kind {
+		case ' ':
+			got = append(got, a[op.ai])
+		case '+':
+			got = append(got, b[op.bi])
+		}
+	}
+	if strings.Join(got, "") != strings.Join(b, "") {
+		t.Fatal("The edit script does not produce b")
+	}
+}
@@ -0,0 +1,144 @@
+// Copyright 2025 Marc-Antoine Ruel. All rights reserved.
+// Use of this source code is governed under the Apache License, Version 2.0
+// that can be found in the LICENSE file.
+
+package genaitools
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"io/fs"
+	"maps"
+	"os"
+	"slices"
+	"time"
+
+	"github.com/maruel/genai"
+)
+
+// maxDirDiffEntries is the maximum number of entries walked in each tree
+// compared by the dir diff tool.
+const maxDirDiffEntries = 100000
+
+// NewDirDiff returns a tool that compares two directory trees inside root.
+//
+// It reports the files added, removed and modified (by size or modification
+// time) in the second tree compared to the first one, as JSON. Paths passed by
+// the LLM are relative to root and cannot escape it. Each tree is limited to
+// 100000 entries.
+func NewDirDiff(root string) genai.ToolDef {
+	return genai.ToolDef{
+		Name:        "dir_diff",
+		Description: "Compares two directory trees and reports the files added, removed and modified (by size or modification time) in the second one compared to the first one, as JSON.",
+		Callback: func(ctx context.Context, args *dirDiffArgs) (string, error) {
+			return doDirDiff(ctx, root, args)
+		},
+	}
+}
+
+type dirDiffArgs struct {
+	Before     string `json:"before" jsonschema:"description=Relative path of the original directory"`
+	After      string `json:"after" jsonschema:"description=Relative path of the directory to compare against the original one"`
+	MaxChanges int    `json:"max_changes,omitempty" jsonschema:"description=Maximum number of changes to report. Defaults to 100"`
+}
+
+type dirDiffFile struct {
+	Path    string    `json:"path"`
+	Size    int64     `json:"size"`
+	ModTime time.Time `json:"mod_time"`
+}
+
+type dirDiffChange struct {
+	Path          string    `json:"path"`
+	SizeBefore    int64     `json:"size_before"`
+	SizeAfter     int64     `json:"size_after"`
+	ModTimeBefore time.Time `json:"mod_time_before"`
+	ModTimeAfter  time.Time `json:"mod_time_after"`
+}
+
+type dirDiffResult struct {
+	Added     []dirDiffFile   `json:"added"`
+	Removed   []dirDiffFile   `json:"removed"`
+	Modified  []dirDiffChange `json:"modified"`
+	Truncated bool            `json:"truncated,omitempty"`
+}
+
+func doDirDiff(ctx context.Context, root string, args *dirDiffArgs) (string, error) {
+	maxChanges := args.MaxChanges
+	if maxChanges <= 0 {
+		maxChanges = 100
+	}
+	before, err := listTree(ctx, root, args.Before, maxDirDiffEntries)
+	if err != nil {
+		return "", err
+	}
+	after, err := listTree(ctx, root, args.After, maxDirDiffEntries)
+	if err != nil {
+		return "", err
+	}
+	res := dirDiffResult{Added: []dirDiffFile{}, Removed: []dirDiffFile{}, Modified: []dirDiffChange{}}
+	n := 0
+	add := func() bool {
+		if n++; n > maxChanges {
+			res.Truncated = true
+			return false
+		}
+		return true
+	}
+	for _, p := range slices.Sorted(maps.Keys(before)) {
+		b := before[p]
+		if a, ok := after[p]; !ok {
+			if add() {
+				res.Removed = append(res.Removed, b)
+			}
+		} else if a.Size != b.Size || !a.ModTime.Equal(b.ModTime) {
+			if add() {
+				res.Modified = append(res.Modified, dirDiffChange{Path: p, SizeBefore: b.Size, SizeAfter: a.Size, ModTimeBefore: b.ModTime, ModTimeAfter: a.ModTime})
+			}
+		}
+	}
+	for _, p := range slices.Sorted(maps.Keys(after)) {
+		if _, ok := before[p]; !ok && add() {
+			res.Added = append(res.Added, after[p])
+		}
+	}
+	b, err := json.Marshal(&res)
+	return string(b), err
+}
+
+// listTree returns all the non-directory entries in the tree, keyed by their
+// slash separated relative path. It fails if the tree has more than
+// maxEntries entries.
+func listTree(ctx context.Context, root, p string, maxEntries int) (map[string]dirDiffFile, error) {
+	dir, fi, err := statInRoot(root, p)
+	if err != nil {
+		return nil, err
+	}
+	if !fi.IsDir() {
+		return nil, fmt.Errorf("path %q is not a directory", p)
+	}
+	out := map[string]dirDiffFile{}
+	entries := 0
+	err = fs.WalkDir(os.DirFS(dir), ".", func(name string, d fs.DirEntry, err error) error {
+		if err != nil {
+			return err
+		}
+		if err = ctx.Err(); err != nil {
+			return err
+		}
+		if entries++; entries > maxEntries {
+			return fmt.Errorf("directory %q has more than %d entries", p, maxEntries)
+		}
+		if d.IsDir() {
+			return nil
+		}
+		info, err := d.Info()
+		if err != nil {
+			return err
+		}
+		out[name] = dirDiffFile{Path: name, Size: info.Size(), ModTime: info.ModTime().UTC()}
+		return nil
+	})
+	return out, err
+}
@@ -0,0 +1,117 @@
+// Copyright 2025 Marc-Antoine Ruel. All rights reserved.
+// Use of this source code is governed under the Apache License, Version 2.0
+// that can be found in the LICENSE file.
+
+package genaitools
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+	"time"
+)
+
+func TestDirDiff(t *testing.T) {
+	root := t.TempDir()
+	ts := time.Date(2025, 1, 2, 3, 4, 5, 0, time.UTC)
+	write := func(p, content string, mod time.Time) {
+		p = filepath.Join(root, p)
+		if err := os.MkdirAll(filepath.Dir(p), 0o755); err != nil {
+			t.Fatal(err)
+		}
+		if err := os.WriteFile(p, []byte(content), 0o644); err != nil {
+			t.Fatal(err)
+		}
+		if err := os.Chtimes(p, mod, mod); err != nil {
+			t.Fatal(err)
+		}
+	}
+	write("before/same.txt", "same", ts)
+	write("before/removed.txt", "gone", ts)
+	write("before/sub/size.txt", "short", ts)
+	write("before/touched.txt", "touched", ts)
+	write("after/same.txt", "same", ts)
+	write("after/sub/size.txt", "much longer", ts)
+	write("after/touched.txt", "touched", ts.Add(time.Hour))
+	write("after/sub/added.txt", "new", ts)
+	write("before/subsecond.txt", "same size", ts)
+	write("after/subsecond.txt", "same size", ts.Add(500*time.Millisecond))
+
+	tool := NewDirDiff(root)
+	callback := tool.Callback.(func(context.Context, *dirDiffArgs) (string, error))
+	t.Run("diff", func(t *testing.T) {
+		got, err := callback(t.Context(), &dirDiffArgs{Before: "before", After: "after"})
+		if err != nil {
+			t.Fatal(err)
+		}
+		res := dirDiffResult{}
+		if err = json.Unmarshal([]byte(got), &res); err != nil {
+			t.Fatal(err)
+		}
+		if len(res.Added) != 1 || res.Added[0].Path != "sub/added.txt" || res.Added[0].Size != 3 {
+			t.Fatalf("unexpected added: %+v", res.Added)
+		}
+		if len(res.Removed) != 1 || res.Removed[0].Path != "removed.txt" {
+			t.Fatalf("unexpected removed: %+v", res.Removed)
+		}
+		if len(res.Modified) != 3 || res.Modified[0].Path != "sub/size.txt" || res.Modified[0].SizeAfter != 11 || res.Modified[1].Path != "subsecond.txt" || res.Modified[2].Path != "touched.txt" {
+			t.Fatalf("unexpected modified: %+v", res.Modified)
+		}
+		if res.Truncated {
+			t.Fatal("unexpected truncation")
+		}
+	})
+	t.Run("cap", func(t *testing.T) {
+		got, err := callback(t.Context(), &dirDiffArgs{Before: "before", After: "after", MaxChanges: 2})
+		if err != nil {
+			t.Fatal(err)
+		}
+		res := dirDiffResult{}
+		if err = json.Unmarshal([]byte(got), &res); err != nil {
+			t.Fatal(err)
+		}
+		if n := len(res.Added) + len(res.Removed) + len(res.Modified); n != 2 || !res.Truncated {
+			t.Fatalf("expected 2 changes and truncation, got %s", got)
+		}
+	})
+	t.Run("max_entries", func(t *testing.T) {
+		// before has 5 files, 1 directory and itself.
+		if _, err := listTree(t.Context(), root, "before", 7); err != nil {
+			t.Fatalf("Unexpected error: %v", err)
+		}
+		if _, err := listTree(t.Context(), root, "before", 6); err == nil || err.Error() != `directory "before" has more than 6 entries` {
+			t.Fatalf("expected entries error, got %v", err)
+		}
+	})
+	t.Run("canceled", func(t *testing.T) {
+		ctx, cancel := context.WithCancel(t.Context())
+		cancel()
+		if _, err := callback(ctx, &dirDiffArgs{Before: "before", After: "after"}); !errors.Is(err, context.Canceled) {
+			t.Fatalf("expected context.Canceled, got %v", err)
+		}
+	})
+	t.Run("errors", func(t *testing.T) {
+		if err := os.Symlink(t.TempDir(), filepath.Join(root, "outside")); err != nil {
+			t.Log(err)
+		} else if _, err := callback(t.Context(), &dirDiffArgs{Before: "before", After: "outside"}); err == nil || !strings.Contains(err.Error(), "via a symlink") {
+			t.Fatalf("expected symlink escape error, got %v", err)
+		}
+		for _, tt := range []struct {
+			before, after string
+			errSubstr     string
+		}{
+			{"before", "../..", "escapes the root directory"},
+			{"/etc", "after", "must be relative"},
+			{"before", "missing", `path "missing"`},
+			{"before/same.txt", "after", "not a directory"},
+		} {
+			if _, err := callback(t.Context(), &dirDiffArgs{Before: tt.before, After: tt.after}); err == nil || !strings.Contains(err.Error(), tt.errSubstr) {
+				t.Fatalf("%s vs %s: expected error containing %q, got %v", tt.before, tt.after, tt.errSubstr, err)
+			}
+		}
+	})
+}
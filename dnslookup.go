@@ -0,0 +1,107 @@
+// Copyright 2025 Marc-Antoine Ruel. All rights reserved.
+// Use of this source code is governed under the Apache License, Version 2.0
+// that can be found in the LICENSE file.
+
+package genaitools
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"net"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/maruel/genai"
+)
+
+// dnsTimeout is the timeout of a DNS lookup.
+const dnsTimeout = 10 * time.Second
+
+// NewDNSLookup returns a tool that looks up the DNS records of a host name
+// with resolver. resolver defaults to net.DefaultResolver.
+//
+// The supported record types are A, AAAA, MX, TXT and CNAME. The records are
+// returned one per line. MX records are formatted as "preference host". The
+// lookup times out after 10 seconds.
+func NewDNSLookup(resolver *net.Resolver) genai.ToolDef {
+	if resolver == nil {
+		resolver = net.DefaultResolver
+	}
+	return genai.ToolDef{
+		Name:        "dns_lookup",
+		Description: "Looks up the DNS records of a host name. Returns the records one per line.",
+		Callback: func(ctx context.Context, args *dnsLookupArgs) (string, error) {
+			return doDNSLookup(ctx, resolver, args)
+		},
+	}
+}
+
+type dnsLookupArgs struct {
+	Host string `json:"host" jsonschema:"description=Host name like example.com"`
+	Type string `json:"type,omitempty" jsonschema:"enum=A,enum=AAAA,enum=MX,enum=TXT,enum=CNAME,description=Record type; defaults to A"`
+}
+
+func doDNSLookup(ctx context.Context, r *net.Resolver, args *dnsLookupArgs) (string, error) {
+	host := strings.TrimSpace(args.Host)
+	if host == "" {
+		return "", errors.New("missing host")
+	}
+	if net.ParseIP(host) != nil {
+		return "", fmt.Errorf("%q is an IP address, not a host name", host)
+	}
+	typ := strings.ToUpper(strings.TrimSpace(args.Type))
+	if typ == "" {
+		typ = "A"
+	}
+	ctx, cancel := context.WithTimeout(ctx, dnsTimeout)
+	defer cancel()
+	var records []string
+	var err error
+	switch typ {
+	case "A", "AAAA":
+		network := "ip4"
+		if typ == "AAAA" {
+			network = "ip6"
+		}
+		var ips []net.IP
+		if ips, err = r.LookupIP(ctx, network, host); err == nil {
+			for _, ip := range ips {
+				records = append(records, ip.String())
+			}
+		}
+	case "MX":
+		var mxs []*net.MX
+		if mxs, err = r.LookupMX(ctx, host); err == nil {
+			for _, mx := range mxs {
+				records = append(records, strconv.Itoa(int(mx.Pref))+" "+mx.Host)
+			}
+		}
+	case "TXT":
+		records, err = r.LookupTXT(ctx, host)
+	case "CNAME":
+		var cname string
+		if cname, err = r.LookupCNAME(ctx, host); err == nil {
+			records = append(records, cname)
+		}
+	default:
+		return "", fmt.Errorf("unsupported record type %q; use A, AAAA, MX, TXT or CNAME", args.Type)
+	}
+	if err != nil {
+		var dnsErr *net.DNSError
+		if errors.As(err, &dnsErr) {
+			if dnsErr.IsNotFound {
+				return "", fmt.Errorf("no %s records for %q: the domain does not exist (NXDOMAIN) or has no such record", typ, host)
+			}
+			if dnsErr.IsTimeout {
+				return "", fmt.Errorf("DNS lookup of %q timed out", host)
+			}
+		}
+		return "", fmt.Errorf("DNS lookup of %q failed: %w", host, err)
+	}
+	if len(records) == 0 {
+		return "", fmt.Errorf("no %s records for %q", typ, host)
+	}
+	return strings.Join(records, "\n"), nil
+}
@@ -0,0 +1,189 @@
+// Copyright 2025 Marc-Antoine Ruel. All rights reserved.
+// Use of this source code is governed under the Apache License, Version 2.0
+// that can be found in the LICENSE file.
+
+package genaitools
+
+import (
+	"context"
+	"encoding/binary"
+	"net"
+	"strings"
+	"testing"
+)
+
+func TestDNSLookup(t *testing.T) {
+	callback := NewDNSLookup(newStubResolver(t)).Callback.(func(context.Context, *dnsLookupArgs) (string, error))
+	tests := []struct {
+		name      string
+		host      string
+		typ       string
+		expected  string
+		errSubstr string
+	}{
+		{"a", "a.example.test", "", "192.0.2.1\n192.0.2.2", ""},
+		{"a_lower", "a.example.test", "a", "192.0.2.1\n192.0.2.2", ""},
+		{"aaaa", "a.example.test", "AAAA", "2001:db8::1", ""},
+		{"mx", "a.example.test", "MX", "10 mx1.example.test.\n20 mx2.example.test.", ""},
+		{"txt", "a.example.test", "TXT", "v=spf1 -all\nhello world", ""},
+		{"cname", "www.example.test", "CNAME", "a.example.test.", ""},
+		{"a_via_cname", "www.example.test", "A", "192.0.2.1", ""},
+		{"nxdomain", "missing.example.test", "A", "", `no A records for "missing.example.test": the domain does not exist (NXDOMAIN)`},
+		{"canonical", "a.example.test", "CNAME", "a.example.test.", ""},
+		{"no_records", "empty.example.test", "MX", "", `no MX records for "empty.example.test"`},
+		{"empty_host", " ", "A", "", "missing host"},
+		{"ip", "192.0.2.1", "A", "", "is an IP address"},
+		{"bad_type", "a.example.test", "SRV", "", `unsupported record type "SRV"`},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := callback(t.Context(), &dnsLookupArgs{Host: tt.host, Type: tt.typ})
+			if tt.errSubstr != "" {
+				if err == nil || !strings.Contains(err.Error(), tt.errSubstr) {
+					t.Fatalf("Expected error containing %q but got %v", tt.errSubstr, err)
+				}
+				return
+			}
+			if err != nil {
+				t.Fatalf("Unexpected error: %v", err)
+			}
+			if got != tt.expected {
+				t.Fatalf("Expected %q but got %q", tt.expected, got)
+			}
+		})
+	}
+}
+
+// DNS record types used by the stub server.
+const (
+	dnsTypeA     = 1
+	dnsTypeCNAME = 5
+	dnsTypeMX    = 15
+	dnsTypeTXT   = 16
+	dnsTypeAAAA  = 28
+)
+
+type dnsRecord struct {
+	name  string
+	typ   uint16
+	rdata []byte
+}
+
+// newStubResolver returns a resolver that queries a local UDP DNS server
+// serving a fixed zone.
+func newStubResolver(t *testing.T) *net.Resolver {
+	pc, err := net.ListenPacket("udp", "127.0.0.1:0")
+	if err != nil {
+		t.Fatal(err)
+	}
+	t.Cleanup(func() { _ = pc.Close() })
+	txt := func(s ...string) []byte {
+		var b []byte
+		for _, x := range s {
+			b = append(b, byte(len(x)))
+			b = append(b, x...)
+		}
+		return b
+	}
+	mx := func(pref uint16, host string) []byte {
+		return append(binary.BigEndian.AppendUint16(nil, pref), dnsName(host)...)
+	}
+	cname := dnsRecord{"www.example.test.", dnsTypeCNAME, dnsName("a.example.test.")}
+	zone := map[string][]dnsRecord{
+		"a.example.test.": {
+			{"a.example.test.", dnsTypeA, net.ParseIP("192.0.2.1").To4()},
+			{"a.example.test.", dnsTypeA, net.ParseIP("192.0.2.2").To4()},
+			{"a.example.test.", dnsTypeAAAA, net.ParseIP("2001:db8::1")},
+			{"a.example.test.", dnsTypeMX, mx(10, "mx1.example.test.")},
+			{"a.example.test.", dnsTypeMX, mx(20, "mx2.example.test.")},
+			{"a.example.test.", dnsTypeTXT, txt("v=spf1 -all")},
+			{"a.example.test.", dnsTypeTXT, txt("hello", " world")},
+		},
+		"www.example.test.": {
+			cname,
+			{"a.example.test.", dnsTypeA, net.ParseIP("192.0.2.1").To4()},
+		},
+		"empty.example.test.": {},
+	}
+	go func() {
+		buf := make([]byte, 512)
+		for {
+			n, addr, err := pc.ReadFrom(buf)
+			if err != nil {
+				return
+			}
+			if resp := dnsStubAnswer(zone, buf[:n]); resp != nil {
+				_, _ = pc.WriteTo(resp, addr)
+			}
+		}
+	}()
+	return &net.Resolver{
+		PreferGo: true,
+		Dial: func(ctx context.Context, network, address string) (net.Conn, error) {
+			var d net.Dialer
+			return d.DialContext(ctx, "udp", pc.LocalAddr().String())
+		},
+	}
+}
+
+// dnsStubAnswer returns the response to the query q.
+func dnsStubAnswer(zone map[string][]dnsRecord, q []byte) []byte {
+	if len(q) < 12 {
+		return nil
+	}
+	// Parse the question name.
+	var labels []string
+	i := 12
+	for i < len(q) && q[i] != 0 {
+		l := int(q[i])
+		if i+1+l > len(q) {
+			return nil
+		}
+		labels = append(labels, string(q[i+1:i+1+l]))
+		i += 1 + l
+	}
+	if i+5 > len(q) {
+		return nil
+	}
+	qend := i + 5
+	qtype := binary.BigEndian.Uint16(q[i+1:])
+	name := strings.ToLower(strings.Join(labels, ".")) + "."
+	records, ok := zone[name]
+	var answers []dnsRecord
+	for _, r := range records {
+		// An alias answers all the queries with the CNAME and the target records.
+		if r.typ == qtype || records[0].typ == dnsTypeCNAME {
+			answers = append(answers, r)
+		}
+	}
+	// Header: same ID, QR, AA, RD, RA and NXDOMAIN when not found.
+	resp := append([]byte{}, q[:2]...)
+	flags := uint16(0x8580)
+	if !ok {
+		flags |= 3
+	}
+	resp = binary.BigEndian.AppendUint16(resp, flags)
+	resp = binary.BigEndian.AppendUint16(resp, 1)
+	resp = binary.BigEndian.AppendUint16(resp, uint16(len(answers)))
+	resp = append(resp, 0, 0, 0, 0)
+	resp = append(resp, q[12:qend]...)
+	for _, r := range answers {
+		resp = append(resp, dnsName(r.name)...)
+		resp = binary.BigEndian.AppendUint16(resp, r.typ)
+		resp = binary.BigEndian.AppendUint16(resp, 1)
+		resp = binary.BigEndian.AppendUint32(resp, 60)
+		resp = binary.BigEndian.AppendUint16(resp, uint16(len(r.rdata)))
+		resp = append(resp, r.rdata...)
+	}
+	return resp
+}
+
+// dnsName encodes a fully qualified name without compression.
+func dnsName(s string) []byte {
+	var b []byte
+	for l := range strings.SplitSeq(strings.TrimSuffix(s, "."), ".") {
+		b = append(b, byte(len(l)))
+		b = append(b, l...)
+	}
+	return append(b, 0)
+}
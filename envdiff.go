@@ -0,0 +1,94 @@
+// Copyright 2025 Marc-Antoine Ruel. All rights reserved.
+// Use of this source code is governed under the Apache License, Version 2.0
+// that can be found in the LICENSE file.
+
+package genaitools
+
+import (
+	"context"
+	"encoding/json"
+	"maps"
+	"regexp"
+
+	"github.com/maruel/genai"
+)
+
+// EnvDiff compares two sets of environment variables and optionally merges
+// them.
+//
+// Values of variables whose name or value looks like a secret (password,
+// token, API key, private key, ...) are replaced with "[REDACTED]" in the
+// output.
+var EnvDiff = genai.ToolDef{
+	Name:        "env_diff",
+	Description: "Compares two sets of environment variables and returns the added, removed and changed variables as JSON, optionally with the merged set where the second one wins. Secret-looking values are redacted.",
+	Callback:    doEnvDiff,
+}
+
+type envDiffArgs struct {
+	Before map[string]string `json:"before" jsonschema:"description=Original environment variables"`
+	After  map[string]string `json:"after" jsonschema:"description=New environment variables"`
+	Merge  bool              `json:"merge,omitempty" jsonschema:"description=Also return the merged variables where after overrides before"`
+}
+
+type envChange struct {
+	Before string `json:"before"`
+	After  string `json:"after"`
+}
+
+type envDiffResult struct {
+	Added   map[string]string    `json:"added"`
+	Removed map[string]string    `json:"removed"`
+	Changed map[string]envChange `json:"changed"`
+	Merged  map[string]string    `json:"merged,omitempty"`
+}
+
+const redacted = "[REDACTED]"
+
+var (
+	// reSecretName matches variable names that usually hold secrets.
+	reSecretName = regexp.MustCompile(`(?i)(pass(wd|word)?|secret|token|api_?key|private_?key|credential|auth|session|cookie|signature|salt)`)
+	// reSecretValue matches values that look like well known credentials or
+	// contain embedded credentials.
+	reSecretValue = regexp.MustCompile(`^(sk-[A-Za-z0-9_-]{16,}|gh[pousr]_[A-Za-z0-9]{20,}|github_pat_[A-Za-z0-9_]{20,}|xox[abprs]-[A-Za-z0-9-]{10,}|AKIA[0-9A-Z]{16}|AIza[0-9A-Za-z_-]{35}|eyJ[A-Za-z0-9_-]+\.[A-Za-z0-9_-]+\.[A-Za-z0-9_-]+)$|-----BEGIN [A-Z ]*PRIVATE KEY-----|://[^/\s:@]+:[^/\s@]+@`)
+)
+
+func redactEnv(k, v string) string {
+	if v != "" && (reSecretName.MatchString(k) || reSecretValue.MatchString(v)) {
+		return redacted
+	}
+	return v
+}
+
+func doEnvDiff(ctx context.Context, args *envDiffArgs) (string, error) {
+	res := envDiffResult{Added: map[string]string{}, Removed: map[string]string{}, Changed: map[string]envChange{}}
+	for k, a := range args.After {
+		if b, ok := args.Before[k]; !ok {
+			res.Added[k] = redactEnv(k, a)
+		} else if a != b {
+			rb, ra := redactEnv(k, b), redactEnv(k, a)
+			if rb == redacted || ra == redacted {
+				// Do not leak one side when the other is a secret.
+				rb, ra = redacted, redacted
+			}
+			res.Changed[k] = envChange{Before: rb, After: ra}
+		}
+	}
+	for k, b := range args.Before {
+		if _, ok := args.After[k]; !ok {
+			res.Removed[k] = redactEnv(k, b)
+		}
+	}
+	if args.Merge {
+		res.Merged = maps.Clone(args.Before)
+		if res.Merged == nil {
+			res.Merged = map[string]string{}
+		}
+		maps.Copy(res.Merged, args.After)
+		for k, v := range res.Merged {
+			res.Merged[k] = redactEnv(k, v)
+		}
+	}
+	b, err := json.Marshal(res)
+	return string(b), err
+}
@@ -0,0 +1,63 @@
+// Copyright 2025 Marc-Antoine Ruel. All rights reserved.
+// Use of this source code is governed under the Apache License, Version 2.0
+// that can be found in the LICENSE file.
+
+package genaitools
+
+import (
+	"context"
+	"testing"
+)
+
+func TestEnvDiff(t *testing.T) {
+	callback := EnvDiff.Callback.(func(context.Context, *envDiffArgs) (string, error))
+	before := map[string]string{
+		"HOME":         "/home/user",
+		"LOG_LEVEL":    "info",
+		"DB_PASSWORD":  "hunter2",
+		"DATABASE_URL": "postgres://app:s3cr3t@db/app",
+		"OLD":          "gone",
+	}
+	after := map[string]string{
+		"HOME":         "/home/user",
+		"LOG_LEVEL":    "debug",
+		"DB_PASSWORD":  "hunter3",
+		"DATABASE_URL": "postgres://db/app",
+		"OPENAI":       "sk-abcdefghijklmnopqrstuvwxyz",
+		"NEW":          "1",
+	}
+	tests := []struct {
+		name     string
+		merge    bool
+		expected string
+	}{
+		{
+			"diff",
+			false,
+			`{"added":{"NEW":"1","OPENAI":"[REDACTED]"},"removed":{"OLD":"gone"},"changed":{"DATABASE_URL":{"before":"[REDACTED]","after":"[REDACTED]"},"DB_PASSWORD":{"before":"[REDACTED]","after":"[REDACTED]"},"LOG_LEVEL":{"before":"info","after":"debug"}}}`,
+		},
+		{
+			"merge",
+			true,
+			`{"added":{"NEW":"1","OPENAI":"[REDACTED]"},"removed":{"OLD":"gone"},"changed":{"DATABASE_URL":{"before":"[REDACTED]","after":"[REDACTED]"},"DB_PASSWORD":{"before":"[REDACTED]","after":"[REDACTED]"},"LOG_LEVEL":{"before":"info","after":"debug"}},"merged":{"DATABASE_URL":"postgres://db/app","DB_PASSWORD":"[REDACTED]","HOME":"/home/user","LOG_LEVEL":"debug","NEW":"1","OLD":"gone","OPENAI":"[REDACTED]"}}`,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := callback(t.Context(), &envDiffArgs{Before: before, After: after, Merge: tt.merge})
+			if err != nil {
+				t.Fatalf("Unexpected error: %v", err)
+			}
+			if got != tt.expected {
+				t.Fatalf("Expected %s\ngot      %s", tt.expected, got)
+			}
+		})
+	}
+	got, err := callback(t.Context(), &envDiffArgs{Merge: true})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if want := `{"added":{},"removed":{},"changed":{}}`; got != want {
+		t.Fatalf("Expected %s but got %s", want, got)
+	}
+}
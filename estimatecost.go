@@ -0,0 +1,167 @@
+// Copyright 2025 Marc-Antoine Ruel. All rights reserved.
+// Use of this source code is governed under the Apache License, Version 2.0
+// that can be found in the LICENSE file.
+
+package genaitools
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"maps"
+	"math"
+	"slices"
+	"strings"
+	"unicode"
+
+	"github.com/maruel/genai"
+)
+
+// ModelPrice is the price of a model in USD per million tokens.
+type ModelPrice struct {
+	Input  float64
+	Output float64
+}
+
+// DefaultModelPrices are approximate list prices of popular models, keyed by
+// "provider/model". Prices change often; use NewEstimateCost with an updated
+// table for accurate estimates.
+var DefaultModelPrices = map[string]ModelPrice{
+	"anthropic/claude-3-5-haiku": {Input: 0.80, Output: 4},
+	"anthropic/claude-opus-4":    {Input: 15, Output: 75},
+	"anthropic/claude-sonnet-4":  {Input: 3, Output: 15},
+	"gemini/gemini-2.5-flash":    {Input: 0.30, Output: 2.50},
+	"gemini/gemini-2.5-pro":      {Input: 1.25, Output: 10},
+	"openai/gpt-4.1":             {Input: 2, Output: 8},
+	"openai/gpt-4.1-mini":        {Input: 0.40, Output: 1.60},
+	"openai/gpt-4o":              {Input: 2.50, Output: 10},
+	"openai/gpt-4o-mini":         {Input: 0.15, Output: 0.60},
+	"openai/gpt-5":               {Input: 1.25, Output: 10},
+	"openai/gpt-5-mini":          {Input: 0.25, Output: 2},
+	"openai/o3":                  {Input: 2, Output: 8},
+}
+
+// EstimateCost estimates the number of tokens of a text and the cost of a
+// request with DefaultModelPrices.
+//
+// See NewEstimateCost for details.
+var EstimateCost = NewEstimateCost(DefaultModelPrices)
+
+// NewEstimateCost returns a tool that estimates the number of tokens of a text
+// and the cost of a request given the prices keyed by "provider/model".
+//
+// The token count is a heuristic that does not depend on the model's
+// tokenizer; expect an error of about 20%. A model matches the longest key
+// that is a prefix of it, so "gpt-4o-2024-08-06" uses the price of "gpt-4o".
+// The provider is optional when the model name is unambiguous.
+func NewEstimateCost(prices map[string]ModelPrice) genai.ToolDef {
+	p := make(map[string]ModelPrice, len(prices))
+	for k, v := range prices {
+		p[strings.ToLower(k)] = v
+	}
+	return genai.ToolDef{
+		Name:        "estimate_cost",
+		Description: "Estimates the number of tokens of a text and the cost in USD of sending it to a model, optionally with the expected number of output tokens. Returns JSON.",
+		Callback: func(ctx context.Context, args *estimateCostArgs) (string, error) {
+			return doEstimateCost(p, args)
+		},
+	}
+}
+
+type estimateCostArgs struct {
+	Text         string `json:"text" jsonschema:"description=Input text sent to the model"`
+	Provider     string `json:"provider,omitempty" jsonschema:"description=Provider like openai\\, anthropic or gemini"`
+	Model        string `json:"model" jsonschema:"description=Model name like gpt-4o"`
+	OutputTokens int64  `json:"output_tokens,omitempty" jsonschema:"description=Expected number of output tokens"`
+}
+
+type estimateCostResult struct {
+	Model         string   `json:"model,omitempty"`
+	InputTokens   int64    `json:"input_tokens"`
+	OutputTokens  int64    `json:"output_tokens"`
+	InputCostUSD  *float64 `json:"input_cost_usd,omitempty"`
+	OutputCostUSD *float64 `json:"output_cost_usd,omitempty"`
+	TotalCostUSD  *float64 `json:"total_cost_usd,omitempty"`
+	Note          string   `json:"note,omitempty"`
+}
+
+func doEstimateCost(prices map[string]ModelPrice, args *estimateCostArgs) (string, error) {
+	if args.OutputTokens < 0 {
+		return "", fmt.Errorf("invalid output_tokens %d; it must not be negative", args.OutputTokens)
+	}
+	res := estimateCostResult{InputTokens: estimateTokens(args.Text), OutputTokens: args.OutputTokens}
+	key, price, err := lookupPrice(prices, args.Provider, args.Model)
+	if err != nil {
+		res.Note = err.Error()
+	} else {
+		res.Model = key
+		in := roundCost(float64(res.InputTokens) * price.Input / 1e6)
+		out := roundCost(float64(res.OutputTokens) * price.Output / 1e6)
+		total := roundCost(in + out)
+		res.InputCostUSD, res.OutputCostUSD, res.TotalCostUSD = &in, &out, &total
+	}
+	b, _ := json.Marshal(res)
+	return string(b), nil
+}
+
+// lookupPrice returns the price of the longest key matching the provider and
+// model.
+func lookupPrice(prices map[string]ModelPrice, provider, model string) (string, ModelPrice, error) {
+	provider, model = strings.ToLower(strings.TrimSpace(provider)), strings.ToLower(strings.TrimSpace(model))
+	if p, m, ok := strings.Cut(model, "/"); ok && provider == "" {
+		provider, model = p, m
+	}
+	var matches []string
+	best := 0
+	for k := range prices {
+		p, m, _ := strings.Cut(k, "/")
+		if (provider != "" && p != provider) || !strings.HasPrefix(model, m) || m == "" {
+			continue
+		}
+		if len(m) > best {
+			matches, best = []string{k}, len(m)
+		} else if len(m) == best {
+			matches = append(matches, k)
+		}
+	}
+	switch len(matches) {
+	case 0:
+		known := slices.Sorted(maps.Keys(prices))
+		return "", ModelPrice{}, fmt.Errorf("unknown model %q; the cost cannot be estimated. Known models: %s", model, strings.Join(known, ", "))
+	case 1:
+		return matches[0], prices[matches[0]], nil
+	default:
+		slices.Sort(matches)
+		return "", ModelPrice{}, fmt.Errorf("ambiguous model %q; specify the provider among %s", model, strings.Join(matches, ", "))
+	}
+}
+
+// estimateTokens approximates the number of tokens of s as counted by common
+// BPE tokenizers: common words are a single token and longer ones are split
+// every 7 characters or so, while punctuation marks and non-ASCII characters
+// like accented letters or CJK are about one token each.
+func estimateTokens(s string) int64 {
+	var n, word int64
+	flush := func() {
+		n += (word + 6) / 7
+		word = 0
+	}
+	for _, r := range s {
+		switch {
+		case r < 0x80 && (unicode.IsLetter(r) || unicode.IsDigit(r)):
+			word++
+		case unicode.IsSpace(r):
+			flush()
+		default:
+			flush()
+			n++
+		}
+	}
+	flush()
+	return n
+}
+
+// roundCost rounds to a millionth of a dollar.
+func roundCost(v float64) float64 {
+	return math.Round(v*1e6) / 1e6
+}
@@ -0,0 +1,108 @@
+// Copyright 2025 Marc-Antoine Ruel. All rights reserved.
+// Use of this source code is governed under the Apache License, Version 2.0
+// that can be found in the LICENSE file.
+
+package genaitools
+
+import (
+	"context"
+	"strings"
+	"testing"
+)
+
+func TestEstimateCost(t *testing.T) {
+	callback := EstimateCost.Callback.(func(context.Context, *estimateCostArgs) (string, error))
+	tests := []struct {
+		name      string
+		args      estimateCostArgs
+		expected  string
+		errSubstr string
+	}{
+		{
+			"dated_model",
+			estimateCostArgs{Text: "The quick brown fox jumps over the lazy dog.", Model: "gpt-4o-2024-08-06", OutputTokens: 1000},
+			`{"model":"openai/gpt-4o","input_tokens":10,"output_tokens":1000,"input_cost_usd":0.000025,"output_cost_usd":0.01,"total_cost_usd":0.010025}`,
+			"",
+		},
+		{
+			"provider",
+			estimateCostArgs{Text: strings.Repeat("word ", 1000), Provider: "Anthropic", Model: "claude-sonnet-4-20250514"},
+			`{"model":"anthropic/claude-sonnet-4","input_tokens":1000,"output_tokens":0,"input_cost_usd":0.003,"output_cost_usd":0,"total_cost_usd":0.003}`,
+			"",
+		},
+		{
+			"longest_prefix",
+			estimateCostArgs{Text: "hi", Model: "openai/gpt-4o-mini", OutputTokens: 1000000},
+			`{"model":"openai/gpt-4o-mini","input_tokens":1,"output_tokens":1000000,"input_cost_usd":0,"output_cost_usd":0.6,"total_cost_usd":0.6}`,
+			"",
+		},
+		{
+			"unknown",
+			estimateCostArgs{Text: "hi", Model: "llama-3"},
+			`{"input_tokens":1,"output_tokens":0,"note":"unknown model \"llama-3\"; the cost cannot be estimated. Known models: anthropic/claude-3-5-haiku, anthropic/claude-opus-4, anthropic/claude-sonnet-4, gemini/gemini-2.5-flash, gemini/gemini-2.5-pro, openai/gpt-4.1, openai/gpt-4.1-mini, openai/gpt-4o, openai/gpt-4o-mini, openai/gpt-5, openai/gpt-5-mini, openai/o3"}`,
+			"",
+		},
+		{
+			"wrong_provider",
+			estimateCostArgs{Text: "", Provider: "gemini", Model: "gpt-4o"},
+			`{"input_tokens":0,"output_tokens":0,"note":"unknown model \"gpt-4o\"; the cost cannot be estimated. Known models: anthropic/claude-3-5-haiku, anthropic/claude-opus-4, anthropic/claude-sonnet-4, gemini/gemini-2.5-flash, gemini/gemini-2.5-pro, openai/gpt-4.1, openai/gpt-4.1-mini, openai/gpt-4o, openai/gpt-4o-mini, openai/gpt-5, openai/gpt-5-mini, openai/o3"}`,
+			"",
+		},
+		{"negative", estimateCostArgs{Text: "hi", Model: "gpt-4o", OutputTokens: -1}, "", "invalid output_tokens -1"},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := callback(t.Context(), &tt.args)
+			if tt.errSubstr != "" {
+				if err == nil || !strings.Contains(err.Error(), tt.errSubstr) {
+					t.Fatalf("Expected error containing %q but got %v", tt.errSubstr, err)
+				}
+				return
+			}
+			if err != nil {
+				t.Fatalf("Unexpected error: %v", err)
+			}
+			if got != tt.expected {
+				t.Fatalf("Expected %q but got %q", tt.expected, got)
+			}
+		})
+	}
+}
+
+func TestNewEstimateCost(t *testing.T) {
+	prices := map[string]ModelPrice{"Local/Llama": {Input: 1, Output: 2}, "other/llama": {Input: 3, Output: 4}}
+	callback := NewEstimateCost(prices).Callback.(func(context.Context, *estimateCostArgs) (string, error))
+	got, err := callback(t.Context(), &estimateCostArgs{Text: "hello world", Provider: "local", Model: "llama-3.1", OutputTokens: 500000})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if want := `{"model":"local/llama","input_tokens":2,"output_tokens":500000,"input_cost_usd":0.000002,"output_cost_usd":1,"total_cost_usd":1.000002}`; got != want {
+		t.Fatalf("Expected %q but got %q", want, got)
+	}
+	got, err = callback(t.Context(), &estimateCostArgs{Text: "hi", Model: "llama"})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if want := `{"input_tokens":1,"output_tokens":0,"note":"ambiguous model \"llama\"; specify the provider among local/llama, other/llama"}`; got != want {
+		t.Fatalf("Expected %q but got %q", want, got)
+	}
+}
+
+func TestEstimateTokens(t *testing.T) {
+	tests := []struct {
+		text     string
+		expected int64
+	}{
+		{"", 0},
+		{"Hello, world!", 4},
+		{"internationalization", 3},
+		{"こんにちは世界", 7},
+		{"café déjà vu", 7},
+		{"x := 42 // answer", 7},
+	}
+	for _, tt := range tests {
+		if got := estimateTokens(tt.text); got != tt.expected {
+			t.Errorf("estimateTokens(%q): Expected %d but got %d", tt.text, tt.expected, got)
+		}
+	}
+}
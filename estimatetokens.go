@@ -0,0 +1,81 @@
+// Copyright 2025 Marc-Antoine Ruel. All rights reserved.
+// Use of this source code is governed under the Apache License, Version 2.0
+// that can be found in the LICENSE file.
+
+package genaitools
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"strings"
+	"unicode/utf8"
+
+	"github.com/maruel/genai"
+)
+
+// Tokenizer counts the tokens of a text, e.g. with a model's BPE vocabulary.
+type Tokenizer interface {
+	// CountTokens returns the number of tokens of text for the model. model
+	// may be empty when the caller did not specify one.
+	CountTokens(ctx context.Context, model, text string) (int64, error)
+}
+
+// EstimateTokens approximates the number of tokens of a text with a heuristic.
+//
+// See NewEstimateTokens for details.
+var EstimateTokens = NewEstimateTokens(nil)
+
+// NewEstimateTokens returns a tool that counts the tokens of a text with
+// tokenizer.
+//
+// When tokenizer is nil, the count is approximated with the same heuristic as
+// EstimateCost, that does not depend on the model: common words are one token,
+// longer words are split every 7 characters or so, and punctuation marks and
+// non-ASCII characters are about one token each. Expect an error of about 20%.
+// The result states whether the count is approximate.
+func NewEstimateTokens(tokenizer Tokenizer) genai.ToolDef {
+	return genai.ToolDef{
+		Name:        "estimate_tokens",
+		Description: "Counts or estimates the number of LLM tokens of a text, e.g. to check it fits in a context window. Returns JSON stating whether the count is approximate.",
+		Callback: func(ctx context.Context, args *estimateTokensArgs) (string, error) {
+			return doEstimateTokens(ctx, tokenizer, args)
+		},
+	}
+}
+
+type estimateTokensArgs struct {
+	Text  string `json:"text" jsonschema:"description=Text to count"`
+	Model string `json:"model,omitempty" jsonschema:"description=Model name like gpt-4o"`
+}
+
+type estimateTokensResult struct {
+	Tokens      int64  `json:"tokens"`
+	Characters  int    `json:"characters"`
+	Model       string `json:"model,omitempty"`
+	Approximate bool   `json:"approximate"`
+	Method      string `json:"method"`
+	Note        string `json:"note,omitempty"`
+}
+
+func doEstimateTokens(ctx context.Context, tokenizer Tokenizer, args *estimateTokensArgs) (string, error) {
+	res := estimateTokensResult{
+		Characters: utf8.RuneCountInString(args.Text),
+		Model:      strings.TrimSpace(args.Model),
+	}
+	if tokenizer != nil {
+		n, err := tokenizer.CountTokens(ctx, res.Model, args.Text)
+		if err != nil {
+			return "", fmt.Errorf("failed to count tokens: %w", err)
+		}
+		res.Tokens = n
+		res.Method = "tokenizer"
+	} else {
+		res.Tokens = estimateTokens(args.Text)
+		res.Approximate = true
+		res.Method = "heuristic"
+		res.Note = "approximation not based on the model's tokenizer; expect an error of about 20%"
+	}
+	b, err := json.Marshal(res)
+	return string(b), err
+}
@@ -0,0 +1,78 @@
+// Copyright 2025 Marc-Antoine Ruel. All rights reserved.
+// Use of this source code is governed under the Apache License, Version 2.0
+// that can be found in the LICENSE file.
+
+package genaitools
+
+import (
+	"context"
+	"errors"
+	"strings"
+	"testing"
+)
+
+type fakeTokenizer struct{}
+
+func (fakeTokenizer) CountTokens(ctx context.Context, model, text string) (int64, error) {
+	if model == "unknown" {
+		return 0, errors.New("unsupported model")
+	}
+	return int64(len(strings.Fields(text))), nil
+}
+
+func TestEstimateTokensTool(t *testing.T) {
+	callback := EstimateTokens.Callback.(func(context.Context, *estimateTokensArgs) (string, error))
+	tests := []struct {
+		name     string
+		args     estimateTokensArgs
+		expected string
+	}{
+		{"empty", estimateTokensArgs{}, `{"tokens":0,"characters":0,"approximate":true,"method":"heuristic","note":"approximation not based on the model's tokenizer; expect an error of about 20%"}`},
+		{"sentence", estimateTokensArgs{Text: "Hello, world!", Model: " gpt-4o "}, `{"tokens":4,"characters":13,"model":"gpt-4o","approximate":true,"method":"heuristic","note":"approximation not based on the model's tokenizer; expect an error of about 20%"}`},
+		{"cjk", estimateTokensArgs{Text: "東京タワー"}, `{"tokens":5,"characters":5,"approximate":true,"method":"heuristic","note":"approximation not based on the model's tokenizer; expect an error of about 20%"}`},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := callback(t.Context(), &tt.args)
+			if err != nil {
+				t.Fatalf("Unexpected error: %v", err)
+			}
+			if got != tt.expected {
+				t.Fatalf("Expected %q but got %q", tt.expected, got)
+			}
+		})
+	}
+
+	t.Run("monotonic", func(t *testing.T) {
+		text := strings.Repeat("The quick brown fox jumps over the lazy dog. Ünïcödé 東京! ", 20)
+		prev := int64(0)
+		for i := range text {
+			n := estimateTokens(text[:i])
+			if n < prev {
+				t.Fatalf("estimateTokens(%q) = %d decreased from %d", text[:i], n, prev)
+			}
+			prev = n
+		}
+		for i := 1; i < 20; i++ {
+			a := estimateTokens(strings.Repeat("hello world ", i))
+			b := estimateTokens(strings.Repeat("hello world ", i+1))
+			if b <= a {
+				t.Fatalf("Expected more tokens for %d repetitions than %d but got %d <= %d", i+1, i, b, a)
+			}
+		}
+	})
+
+	t.Run("tokenizer", func(t *testing.T) {
+		cb := NewEstimateTokens(fakeTokenizer{}).Callback.(func(context.Context, *estimateTokensArgs) (string, error))
+		got, err := cb(t.Context(), &estimateTokensArgs{Text: "one two three", Model: "gpt-4o"})
+		if err != nil {
+			t.Fatalf("Unexpected error: %v", err)
+		}
+		if expected := `{"tokens":3,"characters":13,"model":"gpt-4o","approximate":false,"method":"tokenizer"}`; got != expected {
+			t.Fatalf("Expected %q but got %q", expected, got)
+		}
+		if _, err = cb(t.Context(), &estimateTokensArgs{Text: "x", Model: "unknown"}); err == nil || err.Error() != "failed to count tokens: unsupported model" {
+			t.Fatalf("Expected error but got %v", err)
+		}
+	})
+}
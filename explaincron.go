@@ -0,0 +1,399 @@
+// Copyright 2025 Marc-Antoine Ruel. All rights reserved.
+// Use of this source code is governed under the Apache License, Version 2.0
+// that can be found in the LICENSE file.
+
+package genaitools
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/maruel/genai"
+)
+
+// ExplainCron describes a cron expression in English and computes its next
+// fire times.
+//
+// The expression has the 5 standard fields: minute, hour, day of month, month
+// and day of week. Each field accepts "*", "?", values, ranges "a-b", lists
+// "a,b" and steps "*/n", "a-b/n" or "a/n". Months and days of week accept
+// three letter names like JAN or MON, and both 0 and 7 are Sunday. The
+// descriptors @yearly, @annually, @monthly, @weekly, @daily, @midnight and
+// @hourly are supported.
+//
+// Like cron, when both the day of month and the day of week are restricted,
+// a day matches if either matches.
+var ExplainCron = genai.ToolDef{
+	Name:        "explain_cron",
+	Description: "Explains a cron expression like \"0 */4 * * *\" in plain English and lists its next fire times in RFC3339 format, as JSON.",
+	Callback: func(ctx context.Context, args *explainCronArgs) (string, error) {
+		return doExplainCron(args, time.Now())
+	},
+}
+
+type explainCronArgs struct {
+	Expression string `json:"expression" jsonschema:"description=Cron expression with 5 fields: minute hour day-of-month month day-of-week\\, like 30 9 * * MON-FRI"`
+	Count      int    `json:"count,omitempty" jsonschema:"description=Number of next fire times to return. Defaults to 5,minimum=1,maximum=100"`
+	Timezone   string `json:"timezone,omitempty" jsonschema:"description=IANA time zone like America/New_York. Defaults to the local time zone"`
+}
+
+type explainCronResult struct {
+	Description string   `json:"description"`
+	Next        []string `json:"next"`
+}
+
+// cronField describes one of the 5 fields of a cron expression.
+type cronField struct {
+	name     string
+	min, max int
+	names    []string
+}
+
+var cronFields = [5]cronField{
+	{"minute", 0, 59, nil},
+	{"hour", 0, 23, nil},
+	{"day-of-month", 1, 31, nil},
+	{"month", 1, 12, []string{"", "January", "February", "March", "April", "May", "June", "July", "August", "September", "October", "November", "December"}},
+	// 7 is an alias for Sunday.
+	{"day-of-week", 0, 7, []string{"Sunday", "Monday", "Tuesday", "Wednesday", "Thursday", "Friday", "Saturday", "Sunday"}},
+}
+
+var cronDescriptors = map[string]string{
+	"@yearly":   "0 0 1 1 *",
+	"@annually": "0 0 1 1 *",
+	"@monthly":  "0 0 1 * *",
+	"@weekly":   "0 0 * * 0",
+	"@daily":    "0 0 * * *",
+	"@midnight": "0 0 * * *",
+	"@hourly":   "0 * * * *",
+}
+
+// cronPart is an element of a comma separated list.
+type cronPart struct {
+	star   bool
+	lo, hi int
+	step   int
+	isLone bool // A single value without range nor step.
+}
+
+// cronSchedule is a parsed cron expression.
+type cronSchedule struct {
+	parts [5][]cronPart
+	bits  [5]uint64
+	// domStar and dowStar are set when the day fields are unrestricted.
+	domStar, dowStar bool
+}
+
+// cronMaxYears bounds the search of the next fire time so an expression that
+// never matches, like "0 0 30 2 *", terminates.
+const cronMaxYears = 5
+
+func doExplainCron(args *explainCronArgs, now time.Time) (string, error) {
+	n := args.Count
+	if n == 0 {
+		n = 5
+	}
+	if n < 1 || n > 100 {
+		return "", fmt.Errorf("invalid count %d; must be between 1 and 100", args.Count)
+	}
+	if args.Timezone != "" {
+		loc, err := time.LoadLocation(args.Timezone)
+		if err != nil {
+			return "", fmt.Errorf("invalid timezone %q: %w", args.Timezone, err)
+		}
+		now = now.In(loc)
+	}
+	s, err := parseCron(args.Expression)
+	if err != nil {
+		return "", err
+	}
+	res := explainCronResult{Description: s.describe(), Next: []string{}}
+	t := now
+	for range n {
+		var ok bool
+		if t, ok = s.next(t); !ok {
+			break
+		}
+		res.Next = append(res.Next, t.Format(time.RFC3339))
+	}
+	if len(res.Next) == 0 {
+		return "", fmt.Errorf("cron expression %q never fires", args.Expression)
+	}
+	b, err := json.Marshal(res)
+	return string(b), err
+}
+
+func parseCron(expr string) (*cronSchedule, error) {
+	e := strings.TrimSpace(expr)
+	if strings.HasPrefix(e, "@") {
+		d, ok := cronDescriptors[strings.ToLower(e)]
+		if !ok {
+			return nil, fmt.Errorf("invalid cron expression %q: unknown descriptor; use @yearly, @monthly, @weekly, @daily or @hourly", expr)
+		}
+		e = d
+	}
+	fields := strings.Fields(e)
+	if len(fields) != 5 {
+		return nil, fmt.Errorf("invalid cron expression %q: expected 5 fields (minute hour day-of-month month day-of-week) but got %d", expr, len(fields))
+	}
+	s := &cronSchedule{}
+	for i, f := range fields {
+		parts, bits, err := parseCronField(f, &cronFields[i])
+		if err != nil {
+			return nil, fmt.Errorf("invalid cron expression %q: %w", expr, err)
+		}
+		s.parts[i], s.bits[i] = parts, bits
+	}
+	s.domStar = fields[2][0] == '*' || fields[2][0] == '?'
+	s.dowStar = fields[4][0] == '*' || fields[4][0] == '?'
+	return s, nil
+}
+
+func parseCronField(f string, cf *cronField) ([]cronPart, uint64, error) {
+	var parts []cronPart
+	var bits uint64
+	for p := range strings.SplitSeq(f, ",") {
+		part := cronPart{step: 1}
+		expr, step, hasStep := strings.Cut(p, "/")
+		if hasStep {
+			n, err := strconv.Atoi(step)
+			if err != nil || n < 1 {
+				return nil, 0, fmt.Errorf("invalid step %q in %s field %q", step, cf.name, f)
+			}
+			part.step = n
+		}
+		switch {
+		case expr == "*" || expr == "?":
+			part.star, part.lo, part.hi = true, cf.min, cf.max
+		case strings.Contains(expr, "-"):
+			a, b, _ := strings.Cut(expr, "-")
+			var err error
+			if part.lo, err = parseCronValue(a, cf); err != nil {
+				return nil, 0, err
+			}
+			if part.hi, err = parseCronValue(b, cf); err != nil {
+				return nil, 0, err
+			}
+			if part.lo > part.hi {
+				return nil, 0, fmt.Errorf("invalid range %q in %s field: %d is after %d", expr, cf.name, part.lo, part.hi)
+			}
+		default:
+			v, err := parseCronValue(expr, cf)
+			if err != nil {
+				return nil, 0, err
+			}
+			part.lo, part.hi = v, v
+			if hasStep {
+				// "a/n" means from a to the maximum every n.
+				part.hi = cf.max
+			} else {
+				part.isLone = true
+			}
+		}
+		for v := part.lo; v <= part.hi; v += part.step {
+			bits |= 1 << v
+		}
+		parts = append(parts, part)
+	}
+	// Sunday is both 0 and 7.
+	if cf.name == "day-of-week" && bits&(1<<7) != 0 {
+		bits = bits&^(1<<7) | 1
+	}
+	return parts, bits, nil
+}
+
+func parseCronValue(s string, cf *cronField) (int, error) {
+	if s == "" {
+		return 0, fmt.Errorf("missing value in %s field", cf.name)
+	}
+	for i, name := range cf.names {
+		if name != "" && strings.EqualFold(s, name[:3]) {
+			return i, nil
+		}
+	}
+	v, err := strconv.Atoi(s)
+	if err != nil {
+		return 0, fmt.Errorf("invalid value %q in %s field", s, cf.name)
+	}
+	if v < cf.min || v > cf.max {
+		return 0, fmt.Errorf("value %d out of range %d-%d in %s field", v, cf.min, cf.max, cf.name)
+	}
+	return v, nil
+}
+
+func (s *cronSchedule) has(field, v int) bool {
+	return s.bits[field]&(1<<v) != 0
+}
+
+func (s *cronSchedule) dayMatches(t time.Time) bool {
+	dom := s.has(2, t.Day())
+	dow := s.has(4, int(t.Weekday()))
+	if s.domStar || s.dowStar {
+		return dom && dow
+	}
+	return dom || dow
+}
+
+// next returns the first fire time strictly after t.
+func (s *cronSchedule) next(t time.Time) (time.Time, bool) {
+	loc := t.Location()
+	t = time.Date(t.Year(), t.Month(), t.Day(), t.Hour(), t.Minute(), 0, 0, loc).Add(time.Minute)
+	limit := t.AddDate(cronMaxYears, 0, 0)
+	for t.Before(limit) {
+		switch {
+		case !s.has(3, int(t.Month())):
+			t = advanceTo(t, time.Date(t.Year(), t.Month()+1, 1, 0, 0, 0, 0, loc))
+		case !s.dayMatches(t):
+			t = advanceTo(t, time.Date(t.Year(), t.Month(), t.Day()+1, 0, 0, 0, 0, loc))
+		case !s.has(1, t.Hour()):
+			t = advanceTo(t, time.Date(t.Year(), t.Month(), t.Day(), t.Hour()+1, 0, 0, 0, loc))
+		case !s.has(0, t.Minute()):
+			t = t.Add(time.Minute)
+		default:
+			return t, true
+		}
+	}
+	return time.Time{}, false
+}
+
+// advanceTo returns n if it is after t. Otherwise n is in a daylight saving
+// time gap that time.Date resolved backward, so it returns the next hour.
+func advanceTo(t, n time.Time) time.Time {
+	if n.After(t) {
+		return n
+	}
+	return t.Add(time.Duration(60-t.Minute()) * time.Minute)
+}
+
+// describe returns an English description in the style of crontab.guru, like
+// "At minute 0 past every 4th hour."
+func (s *cronSchedule) describe() string {
+	var out []string
+	if times := s.clockTimes(); times != nil {
+		out = append(out, "At "+joinEnglish(times))
+	} else {
+		out = append(out, "At "+describeCronField(s.parts[0], &cronFields[0]))
+		if !isCronStar(s.parts[1]) {
+			out = append(out, "past "+describeCronField(s.parts[1], &cronFields[1]))
+		}
+	}
+	var days []string
+	if !isCronStar(s.parts[2]) {
+		days = append(days, "on "+describeCronField(s.parts[2], &cronFields[2]))
+	}
+	if !isCronStar(s.parts[4]) {
+		days = append(days, "on "+describeCronField(s.parts[4], &cronFields[4]))
+	}
+	if !s.domStar && !s.dowStar {
+		out = append(out, strings.Join(days, " or "))
+	} else if len(days) != 0 {
+		out = append(out, strings.Join(days, " and "))
+	}
+	if !isCronStar(s.parts[3]) {
+		out = append(out, "in "+describeCronField(s.parts[3], &cronFields[3]))
+	}
+	return strings.Join(out, " ") + "."
+}
+
+// clockTimes returns the times like "09:30" when the minute and hour fields
+// are a few single values.
+func (s *cronSchedule) clockTimes() []string {
+	for _, f := range s.parts[:2] {
+		for _, p := range f {
+			if !p.isLone {
+				return nil
+			}
+		}
+	}
+	if len(s.parts[0])*len(s.parts[1]) > 4 {
+		return nil
+	}
+	var times []string
+	for _, h := range s.parts[1] {
+		for _, m := range s.parts[0] {
+			times = append(times, fmt.Sprintf("%02d:%02d", h.lo, m.lo))
+		}
+	}
+	return times
+}
+
+func isCronStar(parts []cronPart) bool {
+	return len(parts) == 1 && parts[0].star && parts[0].step == 1
+}
+
+func describeCronField(parts []cronPart, cf *cronField) string {
+	value := func(v int) string {
+		if cf.names != nil {
+			return cf.names[v]
+		}
+		return strconv.Itoa(v)
+	}
+	// A list of single values: "minute 0, 15, and 30" or "Monday and Friday".
+	lone := true
+	for _, p := range parts {
+		lone = lone && p.isLone
+	}
+	if lone {
+		values := make([]string, len(parts))
+		for i, p := range parts {
+			values[i] = value(p.lo)
+		}
+		if cf.names != nil {
+			return joinEnglish(values)
+		}
+		return cf.name + " " + joinEnglish(values)
+	}
+	phrases := make([]string, len(parts))
+	for i, p := range parts {
+		every := "every " + cf.name
+		if p.step != 1 {
+			every = "every " + ordinal(p.step) + " " + cf.name
+		}
+		switch {
+		case p.isLone:
+			phrases[i] = cf.name + " " + value(p.lo)
+			if cf.names != nil {
+				phrases[i] = value(p.lo)
+			}
+		case p.star:
+			phrases[i] = every
+		default:
+			phrases[i] = every + " from " + value(p.lo) + " through " + value(p.hi)
+		}
+	}
+	return joinEnglish(phrases)
+}
+
+// joinEnglish joins items as "a", "a and b" or "a, b, and c".
+func joinEnglish(items []string) string {
+	switch len(items) {
+	case 0:
+		return ""
+	case 1:
+		return items[0]
+	case 2:
+		return items[0] + " and " + items[1]
+	default:
+		return strings.Join(items[:len(items)-1], ", ") + ", and " + items[len(items)-1]
+	}
+}
+
+// ordinal returns "1st", "2nd", "3rd", "4th", "11th", etc.
+func ordinal(n int) string {
+	suffix := "th"
+	if n%100 < 11 || n%100 > 13 {
+		switch n % 10 {
+		case 1:
+			suffix = "st"
+		case 2:
+			suffix = "nd"
+		case 3:
+			suffix = "rd"
+		}
+	}
+	return strconv.Itoa(n) + suffix
+}
@@ -0,0 +1,164 @@
+// Copyright 2025 Marc-Antoine Ruel. All rights reserved.
+// Use of this source code is governed under the Apache License, Version 2.0
+// that can be found in the LICENSE file.
+
+package genaitools
+
+import (
+	"encoding/json"
+	"strings"
+	"testing"
+	"time"
+)
+
+func TestExplainCron(t *testing.T) {
+	// A Friday.
+	now := time.Date(2025, 3, 7, 10, 17, 30, 0, time.UTC)
+	tests := []struct {
+		name        string
+		args        explainCronArgs
+		description string
+		next        []string
+		errSubstr   string
+	}{
+		{
+			"every_4_hours",
+			explainCronArgs{Expression: "0 */4 * * *"},
+			"At minute 0 past every 4th hour.",
+			[]string{"2025-03-07T12:00:00Z", "2025-03-07T16:00:00Z", "2025-03-07T20:00:00Z", "2025-03-08T00:00:00Z", "2025-03-08T04:00:00Z"},
+			"",
+		},
+		{
+			"every_minute",
+			explainCronArgs{Expression: "* * * * *", Count: 2},
+			"At every minute.",
+			[]string{"2025-03-07T10:18:00Z", "2025-03-07T10:19:00Z"},
+			"",
+		},
+		{
+			"every_15_minutes",
+			explainCronArgs{Expression: "*/15 * * * *", Count: 3},
+			"At every 15th minute.",
+			[]string{"2025-03-07T10:30:00Z", "2025-03-07T10:45:00Z", "2025-03-07T11:00:00Z"},
+			"",
+		},
+		{
+			"weekdays",
+			explainCronArgs{Expression: "30 9 * * 1-5", Count: 3},
+			"At 09:30 on every day-of-week from Monday through Friday.",
+			[]string{"2025-03-10T09:30:00Z", "2025-03-11T09:30:00Z", "2025-03-12T09:30:00Z"},
+			"",
+		},
+		{
+			"names",
+			explainCronArgs{Expression: "0 9,17 * * mon,FRI", Count: 3},
+			"At 09:00 and 17:00 on Monday and Friday.",
+			[]string{"2025-03-07T17:00:00Z", "2025-03-10T09:00:00Z", "2025-03-10T17:00:00Z"},
+			"",
+		},
+		{
+			"monthly",
+			explainCronArgs{Expression: "@monthly", Count: 2},
+			"At 00:00 on day-of-month 1.",
+			[]string{"2025-04-01T00:00:00Z", "2025-05-01T00:00:00Z"},
+			"",
+		},
+		{
+			"day_or_weekday",
+			explainCronArgs{Expression: "0 0 13 * 5", Count: 3},
+			"At 00:00 on day-of-month 13 or on Friday.",
+			[]string{"2025-03-13T00:00:00Z", "2025-03-14T00:00:00Z", "2025-03-21T00:00:00Z"},
+			"",
+		},
+		{
+			"months",
+			explainCronArgs{Expression: "0 12 * JAN,jul *", Count: 2},
+			"At 12:00 in January and July.",
+			[]string{"2025-07-01T12:00:00Z", "2025-07-02T12:00:00Z"},
+			"",
+		},
+		{
+			"ranges_and_steps",
+			explainCronArgs{Expression: "5/20 8-18/2 */2 1-3 *", Count: 4},
+			"At every 20th minute from 5 through 59 past every 2nd hour from 8 through 18 on every 2nd day-of-month in every month from January through March.",
+			[]string{"2025-03-07T10:25:00Z", "2025-03-07T10:45:00Z", "2025-03-07T12:05:00Z", "2025-03-07T12:25:00Z"},
+			"",
+		},
+		{
+			"sunday_7",
+			explainCronArgs{Expression: "0 0 * * 7", Count: 1},
+			"At 00:00 on Sunday.",
+			[]string{"2025-03-09T00:00:00Z"},
+			"",
+		},
+		{
+			"sunday_range",
+			explainCronArgs{Expression: "0 0 * * 6-7", Count: 2},
+			"At 00:00 on every day-of-week from Saturday through Sunday.",
+			[]string{"2025-03-08T00:00:00Z", "2025-03-09T00:00:00Z"},
+			"",
+		},
+		{
+			"leap_day",
+			explainCronArgs{Expression: "0 0 29 2 *", Count: 1},
+			"At 00:00 on day-of-month 29 in February.",
+			[]string{"2028-02-29T00:00:00Z"},
+			"",
+		},
+		{
+			"timezone_dst",
+			explainCronArgs{Expression: "0 9 * * *", Count: 3, Timezone: "America/New_York"},
+			"At 09:00.",
+			[]string{"2025-03-07T09:00:00-05:00", "2025-03-08T09:00:00-05:00", "2025-03-09T09:00:00-04:00"},
+			"",
+		},
+		{
+			"timezone_dst_gap",
+			explainCronArgs{Expression: "30 2 * * *", Count: 2, Timezone: "America/New_York"},
+			"At 02:30.",
+			[]string{"2025-03-08T02:30:00-05:00", "2025-03-10T02:30:00-04:00"},
+			"",
+		},
+		{
+			"hourly",
+			explainCronArgs{Expression: "@hourly", Count: 3, Timezone: "America/New_York"},
+			"At minute 0.",
+			[]string{"2025-03-07T06:00:00-05:00", "2025-03-07T07:00:00-05:00", "2025-03-07T08:00:00-05:00"},
+			"",
+		},
+		{"never", explainCronArgs{Expression: "0 0 30 2 *"}, "", nil, `cron expression "0 0 30 2 *" never fires`},
+		{"six_fields", explainCronArgs{Expression: "0 0 * * * *"}, "", nil, "expected 5 fields (minute hour day-of-month month day-of-week) but got 6"},
+		{"empty", explainCronArgs{Expression: ""}, "", nil, "expected 5 fields"},
+		{"out_of_range", explainCronArgs{Expression: "60 * * * *"}, "", nil, "value 60 out of range 0-59 in minute field"},
+		{"zero_step", explainCronArgs{Expression: "*/0 * * * *"}, "", nil, `invalid step "0" in minute field "*/0"`},
+		{"reversed_range", explainCronArgs{Expression: "* 5-1 * * *"}, "", nil, `invalid range "5-1" in hour field: 5 is after 1`},
+		{"bad_name", explainCronArgs{Expression: "MON * * * *"}, "", nil, `invalid value "MON" in minute field`},
+		{"descriptor", explainCronArgs{Expression: "@reboot"}, "", nil, "unknown descriptor"},
+		{"count", explainCronArgs{Expression: "* * * * *", Count: 101}, "", nil, "invalid count 101"},
+		{"timezone", explainCronArgs{Expression: "* * * * *", Timezone: "Mars/Olympus"}, "", nil, `invalid timezone "Mars/Olympus"`},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := doExplainCron(&tt.args, now)
+			if tt.errSubstr != "" {
+				if err == nil || !strings.Contains(err.Error(), tt.errSubstr) {
+					t.Fatalf("Expected error containing %q but got %v", tt.errSubstr, err)
+				}
+				return
+			}
+			if err != nil {
+				t.Fatalf("Unexpected error: %v", err)
+			}
+			var res explainCronResult
+			if err = json.Unmarshal([]byte(got), &res); err != nil {
+				t.Fatal(err)
+			}
+			if res.Description != tt.description {
+				t.Fatalf("Expected %q but got %q", tt.description, res.Description)
+			}
+			if strings.Join(res.Next, " ") != strings.Join(tt.next, " ") {
+				t.Fatalf("Expected %q but got %q", tt.next, res.Next)
+			}
+		})
+	}
+}
@@ -0,0 +1,388 @@
+// Copyright 2025 Marc-Antoine Ruel. All rights reserved.
+// Use of this source code is governed under the Apache License, Version 2.0
+// that can be found in the LICENSE file.
+
+package genaitools
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"math"
+	"strconv"
+	"strings"
+
+	"github.com/maruel/genai"
+)
+
+// Expression evaluates an infix arithmetic expression like "(3 + 4) * 5 - 2".
+//
+// It supports + - * / % ^ (power), parentheses and unary minus, with the usual
+// precedence. The result is formatted like Arithmetic does.
+var Expression = genai.ToolDef{
+	Name:        "expression",
+	Description: "Evaluates an arithmetic expression with numbers, + - * / % ^ (power), parentheses and unary minus, e.g. (3 + 4) * 5 - 2, and returns the result.",
+	Callback:    doExpression,
+}
+
+type expressionArgs struct {
+	Expression string `json:"expression" jsonschema:"description=Arithmetic expression like (3 + 4) * 5 - 2"`
+}
+
+func doExpression(ctx context.Context, args *expressionArgs) (string, error) {
+	v, err := evalExpression(args.Expression, nil)
+	if err != nil {
+		return "", err
+	}
+	if math.IsNaN(v) || math.IsInf(v, 0) {
+		return "", fmt.Errorf("the result of %q is not a finite number", args.Expression)
+	}
+	return formatFloat(v), nil
+}
+
+var errDivideByZero = errors.New("cannot divide by zero")
+
+// exprEnv is the environment an expression is evaluated in.
+//
+// A zero value rejects all identifiers.
+type exprEnv struct {
+	// vars are the named values that can be referenced.
+	vars map[string]float64
+	// funcs enables the SUM, AVG, MIN and MAX functions.
+	funcs bool
+}
+
+// evalExpression evaluates an infix expression.
+//
+// It supports + - * / % ^, parentheses, unary minus and the comparisons < <=
+// > >= = == != <>. Comparisons evaluate to 1 when true and 0 otherwise.
+func evalExpression(expr string, env *exprEnv) (float64, error) {
+	toks, err := tokenize(expr)
+	if err != nil {
+		return 0, err
+	}
+	if env == nil {
+		env = &exprEnv{}
+	}
+	p := exprParser{toks: toks, env: env}
+	v, err := p.comparison()
+	if err != nil {
+		return 0, err
+	}
+	if t := p.peek(); t.kind != tokEOF {
+		if t.kind == tokOp && t.s == ")" {
+			return 0, fmt.Errorf("unbalanced parentheses: unexpected %q at position %d", t.s, t.pos)
+		}
+		return 0, fmt.Errorf("unexpected %q at position %d", t.s, t.pos)
+	}
+	return v, nil
+}
+
+type tokKind int
+
+const (
+	tokEOF tokKind = iota
+	tokNum
+	tokIdent
+	tokOp
+)
+
+type token struct {
+	kind tokKind
+	s    string
+	num  float64
+	pos  int
+}
+
+func tokenize(s string) ([]token, error) {
+	var out []token
+	for i := 0; i < len(s); {
+		c := s[i]
+		switch {
+		case c == ' ' || c == '\t' || c == '\n' || c == '\r':
+			i++
+		case c >= '0' && c <= '9' || c == '.':
+			j := i
+			for j < len(s) && (s[j] >= '0' && s[j] <= '9' || s[j] == '.') {
+				j++
+			}
+			// Exponent, e.g. 1e-3.
+			if j < len(s) && (s[j] == 'e' || s[j] == 'E') {
+				k := j + 1
+				if k < len(s) && (s[k] == '+' || s[k] == '-') {
+					k++
+				}
+				if k < len(s) && s[k] >= '0' && s[k] <= '9' {
+					for k < len(s) && s[k] >= '0' && s[k] <= '9' {
+						k++
+					}
+					j = k
+				}
+			}
+			n, err := strconv.ParseFloat(s[i:j], 64)
+			if err != nil {
+				return nil, fmt.Errorf("invalid number %q at position %d", s[i:j], i)
+			}
+			out = append(out, token{kind: tokNum, s: s[i:j], num: n, pos: i})
+			i = j
+		case isIdentChar(c):
+			j := i
+			for j < len(s) && (isIdentChar(s[j]) || s[j] >= '0' && s[j] <= '9') {
+				j++
+			}
+			out = append(out, token{kind: tokIdent, s: s[i:j], pos: i})
+			i = j
+		default:
+			op := ""
+			if i+1 < len(s) {
+				switch two := s[i : i+2]; two {
+				case "<=", ">=", "==", "!=", "<>":
+					op = two
+				}
+			}
+			if op == "" {
+				if !strings.ContainsRune("+-*/%^(),<>=", rune(c)) {
+					return nil, fmt.Errorf("unexpected character %q at position %d", c, i)
+				}
+				op = s[i : i+1]
+			}
+			out = append(out, token{kind: tokOp, s: op, pos: i})
+			i += len(op)
+		}
+	}
+	return append(out, token{kind: tokEOF, pos: len(s)}), nil
+}
+
+func isIdentChar(c byte) bool {
+	return c == '_' || c >= 'a' && c <= 'z' || c >= 'A' && c <= 'Z'
+}
+
+// exprParser is a recursive descent parser that evaluates while parsing.
+type exprParser struct {
+	toks []token
+	i    int
+	env  *exprEnv
+}
+
+func (p *exprParser) peek() token {
+	return p.toks[p.i]
+}
+
+func (p *exprParser) next() token {
+	t := p.toks[p.i]
+	if t.kind != tokEOF {
+		p.i++
+	}
+	return t
+}
+
+func (p *exprParser) acceptOp(ops ...string) (string, bool) {
+	if t := p.peek(); t.kind == tokOp {
+		for _, op := range ops {
+			if t.s == op {
+				p.i++
+				return op, true
+			}
+		}
+	}
+	return "", false
+}
+
+func (p *exprParser) comparison() (float64, error) {
+	l, err := p.additive()
+	if err != nil {
+		return 0, err
+	}
+	op, ok := p.acceptOp("<", "<=", ">", ">=", "=", "==", "!=", "<>")
+	if !ok {
+		return l, nil
+	}
+	r, err := p.additive()
+	if err != nil {
+		return 0, err
+	}
+	b := false
+	switch op {
+	case "<":
+		b = l < r
+	case "<=":
+		b = l <= r
+	case ">":
+		b = l > r
+	case ">=":
+		b = l >= r
+	case "=", "==":
+		b = l == r
+	case "!=", "<>":
+		b = l != r
+	}
+	if b {
+		return 1, nil
+	}
+	return 0, nil
+}
+
+func (p *exprParser) additive() (float64, error) {
+	l, err := p.term()
+	if err != nil {
+		return 0, err
+	}
+	for {
+		op, ok := p.acceptOp("+", "-")
+		if !ok {
+			return l, nil
+		}
+		r, err := p.term()
+		if err != nil {
+			return 0, err
+		}
+		if op == "+" {
+			l += r
+		} else {
+			l -= r
+		}
+	}
+}
+
+func (p *exprParser) term() (float64, error) {
+	l, err := p.unary()
+	if err != nil {
+		return 0, err
+	}
+	for {
+		op, ok := p.acceptOp("*", "/", "%")
+		if !ok {
+			return l, nil
+		}
+		r, err := p.unary()
+		if err != nil {
+			return 0, err
+		}
+		switch op {
+		case "*":
+			l *= r
+		case "/":
+			if r == 0 {
+				return 0, errDivideByZero
+			}
+			l /= r
+		case "%":
+			if r == 0 {
+				return 0, errDivideByZero
+			}
+			l = math.Mod(l, r)
+		}
+	}
+}
+
+func (p *exprParser) unary() (float64, error) {
+	if op, ok := p.acceptOp("-", "+"); ok {
+		v, err := p.unary()
+		if op == "-" {
+			v = -v
+		}
+		return v, err
+	}
+	return p.power()
+}
+
+func (p *exprParser) power() (float64, error) {
+	base, err := p.primary()
+	if err != nil {
+		return 0, err
+	}
+	if _, ok := p.acceptOp("^"); !ok {
+		return base, nil
+	}
+	// Right associative: 2^3^2 is 2^(3^2).
+	exp, err := p.unary()
+	if err != nil {
+		return 0, err
+	}
+	return math.Pow(base, exp), nil
+}
+
+func (p *exprParser) primary() (float64, error) {
+	t := p.next()
+	switch t.kind {
+	case tokNum:
+		return t.num, nil
+	case tokIdent:
+		if _, ok := p.acceptOp("("); ok {
+			return p.call(t)
+		}
+		if v, ok := p.env.vars[t.s]; ok {
+			return v, nil
+		}
+		if p.env.vars == nil {
+			return 0, fmt.Errorf("unknown identifier %q at position %d", t.s, t.pos)
+		}
+		return 0, fmt.Errorf("undefined variable %q at position %d", t.s, t.pos)
+	case tokOp:
+		if t.s == "(" {
+			v, err := p.comparison()
+			if err != nil {
+				return 0, err
+			}
+			if _, ok := p.acceptOp(")"); !ok {
+				return 0, fmt.Errorf("unbalanced parentheses: missing \")\" for \"(\" at position %d", t.pos)
+			}
+			return v, nil
+		}
+		return 0, fmt.Errorf("unexpected %q at position %d", t.s, t.pos)
+	case tokEOF:
+		return 0, errors.New("unexpected end of expression")
+	}
+	return 0, fmt.Errorf("unexpected %q at position %d", t.s, t.pos)
+}
+
+func (p *exprParser) call(name token) (float64, error) {
+	fn := strings.ToUpper(name.s)
+	switch fn {
+	case "SUM", "AVG", "MIN", "MAX":
+		if !p.env.funcs {
+			return 0, fmt.Errorf("unknown identifier %q at position %d", name.s, name.pos)
+		}
+	default:
+		return 0, fmt.Errorf("unknown function %q at position %d", name.s, name.pos)
+	}
+	var args []float64
+	if _, ok := p.acceptOp(")"); !ok {
+		for {
+			v, err := p.comparison()
+			if err != nil {
+				return 0, err
+			}
+			args = append(args, v)
+			if _, ok := p.acceptOp(","); ok {
+				continue
+			}
+			if _, ok := p.acceptOp(")"); ok {
+				break
+			}
+			return 0, fmt.Errorf("unbalanced parentheses: missing \")\" for %s at position %d", fn, name.pos)
+		}
+	}
+	if len(args) == 0 {
+		return 0, fmt.Errorf("%s requires at least one argument", fn)
+	}
+	r := args[0]
+	switch fn {
+	case "SUM", "AVG":
+		for _, v := range args[1:] {
+			r += v
+		}
+		if fn == "AVG" {
+			r /= float64(len(args))
+		}
+	case "MIN":
+		for _, v := range args[1:] {
+			r = min(r, v)
+		}
+	case "MAX":
+		for _, v := range args[1:] {
+			r = max(r, v)
+		}
+	}
+	return r, nil
+}
@@ -0,0 +1,55 @@
+// Copyright 2025 Marc-Antoine Ruel. All rights reserved.
+// Use of this source code is governed under the Apache License, Version 2.0
+// that can be found in the LICENSE file.
+
+package genaitools
+
+import (
+	"context"
+	"strings"
+	"testing"
+)
+
+func TestExpression(t *testing.T) {
+	callback := Expression.Callback.(func(context.Context, *expressionArgs) (string, error))
+	tests := []struct {
+		name      string
+		expr      string
+		expected  string
+		errSubstr string
+	}{
+		{"example", "(3 + 4) * 5 - 2", "33", ""},
+		{"precedence", "2 + 3 * 4", "14", ""},
+		{"precedence_division", "10 - 6 / 2", "7", ""},
+		{"modulo", "17 % 5 * 2", "4", ""},
+		{"power_precedence", "2 * 3 ^ 2", "18", ""},
+		{"power_right_assoc", "2 ^ 3 ^ 2", "512", ""},
+		{"unary_minus", "-3 + 5", "2", ""},
+		{"unary_minus_power", "-2 ^ 2", "-4", ""},
+		{"nested_parentheses", "((1 + 2) * (3 + (4 - 1))) / 4", "4.500000", ""},
+		{"decimal", "0.1 + 0.2", "0.300000", ""},
+		{"unknown_identifier", "2 * x", "", `unknown identifier "x"`},
+		{"unbalanced_open", "(1 + 2", "", "unbalanced parentheses"},
+		{"unbalanced_close", "1 + 2)", "", "unbalanced parentheses"},
+		{"divide_by_zero", "1 / (2 - 2)", "", "cannot divide by zero"},
+		{"empty", "", "", "unexpected end of expression"},
+		{"not_finite", "(0 - 8) ^ 0.5", "", "not a finite number"},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := callback(t.Context(), &expressionArgs{Expression: tt.expr})
+			if tt.errSubstr != "" {
+				if err == nil || !strings.Contains(err.Error(), tt.errSubstr) {
+					t.Fatalf("Expected error containing %q but got %v", tt.errSubstr, err)
+				}
+				return
+			}
+			if err != nil {
+				t.Fatalf("Unexpected error: %v", err)
+			}
+			if got != tt.expected {
+				t.Fatalf("Expected %q but got %q", tt.expected, got)
+			}
+		})
+	}
+}
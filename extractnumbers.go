@@ -0,0 +1,84 @@
+// Copyright 2025 Marc-Antoine Ruel. All rights reserved.
+// Use of this source code is governed under the Apache License, Version 2.0
+// that can be found in the LICENSE file.
+
+package genaitools
+
+import (
+	"context"
+	"encoding/json"
+	"regexp"
+	"unicode"
+	"unicode/utf8"
+
+	"github.com/maruel/genai"
+)
+
+// ExtractNumbers extracts all the numbers from a text, like the output of a
+// command or a web page.
+//
+// Thousands separators and currency symbols are understood; the locale
+// selects whether the decimal separator is a dot or a comma. Each number is
+// returned parsed along with the original substring.
+var ExtractNumbers = genai.ToolDef{
+	Name:        "extract_numbers",
+	Description: "Extracts all the numbers (integers, decimals, with thousands separators or currency symbols) from a text and returns them as a JSON array of objects with the parsed value and the original text.",
+	Callback:    doExtractNumbers,
+}
+
+type extractNumbersArgs struct {
+	Text   string `json:"text" jsonschema:"description=Text to extract numbers from"`
+	Locale string `json:"locale,omitempty" jsonschema:"description=BCP 47 locale of the text like en-US or de-DE. It selects the decimal separator. Defaults to en-US"`
+}
+
+type extractedNumber struct {
+	Value json.Number `json:"value"`
+	Text  string      `json:"text"`
+}
+
+// reNumberCandidate matches a number with optional sign, currency symbol and
+// separators. Plain spaces are not accepted as separators since they usually
+// separate distinct numbers.
+var reNumberCandidate = regexp.MustCompile(`[-+−]?[$€£¥₹₩₽¤]?\d(?:[\d,.'’\x{00a0}\x{202f}]*\d)?(?:[$€£¥₹₩₽¤])?`)
+
+// reNumberFallbackDot and reNumberFallbackComma match simple numbers once a
+// candidate failed to parse, e.g. in a list like "1,2,3".
+var (
+	reNumberFallbackDot   = regexp.MustCompile(`\d+(?:\.\d+)?`)
+	reNumberFallbackComma = regexp.MustCompile(`\d+(?:,\d+)?`)
+)
+
+func doExtractNumbers(ctx context.Context, args *extractNumbersArgs) (string, error) {
+	lang, region, err := parseLocale(args.Locale)
+	if err != nil {
+		return "", err
+	}
+	decimalComma := usesDecimalComma(lang, region)
+	fallback := reNumberFallbackDot
+	if decimalComma {
+		fallback = reNumberFallbackComma
+	}
+	out := []extractedNumber{}
+	for _, loc := range reNumberCandidate.FindAllStringIndex(args.Text, -1) {
+		start, end := loc[0], loc[1]
+		if r, size := utf8.DecodeRuneInString(args.Text[start:]); r == '-' || r == '+' || r == '−' {
+			// A sign right after a word or a digit is a dash, like in "10-20" or
+			// "COVID-19".
+			if p, _ := utf8.DecodeLastRuneInString(args.Text[:start]); start > 0 && (unicode.IsLetter(p) || unicode.IsDigit(p)) {
+				start += size
+			}
+		}
+		s := args.Text[start:end]
+		if v, err := parseLenientNumber(s, decimalComma); err == nil {
+			out = append(out, extractedNumber{Value: json.Number(v), Text: s})
+			continue
+		}
+		for _, m := range fallback.FindAllString(s, -1) {
+			if v, err := parseLenientNumber(m, decimalComma); err == nil {
+				out = append(out, extractedNumber{Value: json.Number(v), Text: m})
+			}
+		}
+	}
+	b, err := json.Marshal(out)
+	return string(b), err
+}
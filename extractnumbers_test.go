@@ -0,0 +1,70 @@
+// Copyright 2025 Marc-Antoine Ruel. All rights reserved.
+// Use of this source code is governed under the Apache License, Version 2.0
+// that can be found in the LICENSE file.
+
+package genaitools
+
+import (
+	"context"
+	"strings"
+	"testing"
+)
+
+func TestExtractNumbers(t *testing.T) {
+	callback := ExtractNumbers.Callback.(func(context.Context, *extractNumbersArgs) (string, error))
+	tests := []struct {
+		name      string
+		text      string
+		locale    string
+		expected  string
+		errSubstr string
+	}{
+		{
+			"mixed",
+			"Total: $1,234.50 for 3 items, discount -0.25 and 12% tax.",
+			"",
+			`[{"value":1234.5,"text":"$1,234.50"},{"value":3,"text":"3"},{"value":-0.25,"text":"-0.25"},{"value":12,"text":"12"}]`,
+			"",
+		},
+		{
+			"dashes",
+			"pages 10-20, COVID-19",
+			"",
+			`[{"value":10,"text":"10"},{"value":20,"text":"20"},{"value":19,"text":"19"}]`,
+			"",
+		},
+		{
+			"list",
+			"values 1,2,3",
+			"",
+			`[{"value":1,"text":"1"},{"value":2,"text":"2"},{"value":3,"text":"3"}]`,
+			"",
+		},
+		{
+			"german",
+			"Preis: 1.234,56€ und 7,5 kg",
+			"de-DE",
+			`[{"value":1234.56,"text":"1.234,56€"},{"value":7.5,"text":"7,5"}]`,
+			"",
+		},
+		{"none", "no digits here", "", `[]`, ""},
+		{"bad_locale", "1", "not a locale", "", "invalid locale"},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := callback(t.Context(), &extractNumbersArgs{Text: tt.text, Locale: tt.locale})
+			if tt.errSubstr != "" {
+				if err == nil || !strings.Contains(err.Error(), tt.errSubstr) {
+					t.Fatalf("Expected error containing %q but got %v", tt.errSubstr, err)
+				}
+				return
+			}
+			if err != nil {
+				t.Fatalf("Unexpected error: %v", err)
+			}
+			if got != tt.expected {
+				t.Fatalf("Expected %s but got %s", tt.expected, got)
+			}
+		})
+	}
+}
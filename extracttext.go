@@ -0,0 +1,172 @@
+// Copyright 2025 Marc-Antoine Ruel. All rights reserved.
+// Use of this source code is governed under the Apache License, Version 2.0
+// that can be found in the LICENSE file.
+
+package genaitools
+
+import (
+	"context"
+	"strings"
+	"unicode"
+
+	"github.com/maruel/genai"
+	"golang.org/x/net/html"
+)
+
+// ExtractText converts HTML to readable plain text.
+//
+// Scripts, styles and other non-visible elements are removed, tags are
+// stripped, entities are decoded and whitespace is collapsed. Block elements
+// start new lines and list items are prefixed with "- ". Link targets are kept
+// inline as "text (url)".
+//
+// The document is tokenized with golang.org/x/net/html, which handles malformed
+// markup the way browsers do.
+var ExtractText = genai.ToolDef{
+	Name:        "extract_text",
+	Description: "Converts an HTML document to readable plain text, removing scripts, styles and tags, collapsing whitespace and keeping link targets inline as text (url).",
+	Callback: func(ctx context.Context, args *extractTextArgs) (string, error) {
+		return extractText(args.HTML), nil
+	},
+}
+
+type extractTextArgs struct {
+	HTML string `json:"html" jsonschema:"description=HTML document or fragment"`
+}
+
+// skippedElements are the elements whose content is not visible text.
+var skippedElements = map[string]bool{
+	"iframe": true, "noscript": true, "object": true, "script": true, "style": true, "svg": true, "template": true,
+}
+
+// paragraphElements are separated from the surrounding text by a blank line.
+var paragraphElements = map[string]bool{
+	"blockquote": true, "h1": true, "h2": true, "h3": true, "h4": true, "h5": true, "h6": true, "hr": true,
+	"ol": true, "p": true, "pre": true, "table": true, "title": true, "ul": true,
+}
+
+// lineElements start on a new line.
+var lineElements = map[string]bool{
+	"address": true, "article": true, "aside": true, "br": true, "dd": true, "div": true, "dl": true, "dt": true,
+	"figcaption": true, "figure": true, "footer": true, "form": true, "header": true, "li": true, "main": true,
+	"nav": true, "section": true, "tr": true,
+}
+
+func extractText(s string) string {
+	var w textWriter
+	href, linkStart := "", -1
+	// skip is the depth of nested skipped elements.
+	skip := 0
+	z := html.NewTokenizer(strings.NewReader(s))
+	for {
+		tt := z.Next()
+		if tt == html.ErrorToken {
+			// io.EOF; the reader cannot fail otherwise.
+			break
+		}
+		tok := z.Token()
+		name := tok.Data
+		switch tt {
+		case html.TextToken:
+			if skip == 0 {
+				w.text(tok.Data)
+			}
+			continue
+		case html.StartTagToken:
+			if skippedElements[name] {
+				skip++
+				continue
+			}
+		case html.EndTagToken:
+			if skippedElements[name] {
+				if skip > 0 {
+					skip--
+				}
+				continue
+			}
+		case html.SelfClosingTagToken:
+		default:
+			// Comments and doctype.
+			continue
+		}
+		if skip != 0 {
+			continue
+		}
+		closing := tt == html.EndTagToken
+		switch {
+		case paragraphElements[name]:
+			w.newline(2)
+		case lineElements[name]:
+			w.newline(1)
+		case name == "td" || name == "th":
+			w.space = true
+		}
+		switch {
+		case name == "li" && !closing:
+			w.prefix("- ")
+		case name == "img" && htmlAttr(tok, "alt") != "":
+			w.text("[" + htmlAttr(tok, "alt") + "]")
+		case name == "a" && !closing:
+			href, linkStart = htmlAttr(tok, "href"), w.b.Len()
+		case name == "a" && closing && linkStart >= 0:
+			text := strings.TrimSpace(w.b.String()[linkStart:])
+			if text != "" && href != "" && href != text && !strings.HasPrefix(href, "#") && !strings.HasPrefix(strings.ToLower(href), "javascript:") {
+				w.text(" (" + href + ")")
+			}
+			href, linkStart = "", -1
+		}
+	}
+	return strings.TrimSpace(w.b.String())
+}
+
+// htmlAttr returns the value of the first attribute key of the tag.
+func htmlAttr(tok html.Token, key string) string {
+	for _, a := range tok.Attr {
+		if a.Key == key {
+			return a.Val
+		}
+	}
+	return ""
+}
+
+// textWriter accumulates text, collapsing whitespace.
+type textWriter struct {
+	b strings.Builder
+	// space is true when whitespace is pending.
+	space bool
+	// lineStart is true when no space must be added before the next text.
+	lineStart bool
+	// newlines is the number of trailing newlines.
+	newlines int
+}
+
+func (w *textWriter) text(s string) {
+	for _, r := range s {
+		if unicode.IsSpace(r) {
+			w.space = true
+			continue
+		}
+		if w.space && !w.lineStart && w.b.Len() != 0 {
+			w.b.WriteByte(' ')
+		}
+		w.space, w.lineStart, w.newlines = false, false, 0
+		w.b.WriteRune(r)
+	}
+}
+
+// prefix starts the line with s, like a list bullet.
+func (w *textWriter) prefix(s string) {
+	w.b.WriteString(s)
+	w.space, w.lineStart, w.newlines = false, true, 0
+}
+
+// newline ends the current line, leaving n-1 blank lines.
+func (w *textWriter) newline(n int) {
+	w.space, w.lineStart = false, true
+	if w.b.Len() == 0 {
+		return
+	}
+	for ; w.newlines < n; w.newlines++ {
+		w.b.WriteByte('\n')
+	}
+}
@@ -0,0 +1,54 @@
+// Copyright 2025 Marc-Antoine Ruel. All rights reserved.
+// Use of this source code is governed under the Apache License, Version 2.0
+// that can be found in the LICENSE file.
+
+package genaitools
+
+import (
+	"context"
+	"testing"
+)
+
+func TestExtractText(t *testing.T) {
+	callback := ExtractText.Callback.(func(context.Context, *extractTextArgs) (string, error))
+	tests := []struct {
+		name     string
+		html     string
+		expected string
+	}{
+		{
+			"document",
+			"<!DOCTYPE html>\n<html><head><title>Hi</title><style>p { color: red; }</style>\n" +
+				"<script>if (a < b) { document.write('</p>'); }</script></head>\n" +
+				"<body><h1>Title</h1><p>Hello,   <b>big</b>\n<i>world</i>!</p><!-- comment <p>x</p> --><p>Bye</p></body></html>",
+			"Hi\n\nTitle\n\nHello, big world!\n\nBye",
+		},
+		{"nested", "<div><div><span>a<em>b</em></span> c</div><div>d</div></div>", "ab c\nd"},
+		{"entities", "<p>Tom &amp; Jerry &lt;3 &quot;x&quot; &#233;&#x27;&nbsp;&copy;</p>", "Tom & Jerry <3 \"x\" é' ©"},
+		{"script_case", "a<SCRIPT type=\"text/javascript\">alert(1)</SCRIPT >b", "ab"},
+		{"script_unterminated", "a<script>alert(1)", "a"},
+		{"svg", "a<svg><g><text>hidden</text></g></svg>b", "ab"},
+		{"nested_skipped", "<object><object>x</object>y</object>z", "z"},
+		{"links", `<p>See <a href="https://example.com/?a=1&amp;b=2">the docs</a>, <a href="#top">top</a> or <a href='https://x.org'>https://x.org</a>.</p>`, "See the docs (https://example.com/?a=1&b=2), top or https://x.org."},
+		{"empty_link", `<a href="/x"><img src="i.png"></a>text`, "text"},
+		{"list", "<ul><li>one</li><li> two <b>2</b></li></ul><p>after</p>", "- one\n- two 2\n\nafter"},
+		{"table", "<table><tr><th>a</th><th>b</th></tr><tr><td>1</td><td>2</td></tr></table>", "a b\n1 2"},
+		{"br", "line1<br>line2<br/>line3", "line1\nline2\nline3"},
+		{"img_alt", `<p>Logo: <img alt="ACME &amp; Co" src="x.png"/></p>`, "Logo: [ACME & Co]"},
+		{"malformed", "<p>a < b and c<d <p>e</p", "a < b and ce"},
+		{"not_a_tag", "1 <2> 3 <=4", "1 <2> 3 <=4"},
+		{"unclosed_comment", "a<!-- b", "a"},
+		{"text", "  plain\n\ttext  ", "plain text"},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := callback(t.Context(), &extractTextArgs{HTML: tt.html})
+			if err != nil {
+				t.Fatalf("Unexpected error: %v", err)
+			}
+			if got != tt.expected {
+				t.Fatalf("Expected %q but got %q", tt.expected, got)
+			}
+		})
+	}
+}
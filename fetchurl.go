@@ -0,0 +1,137 @@
+// Copyright 2025 Marc-Antoine Ruel. All rights reserved.
+// Use of this source code is governed under the Apache License, Version 2.0
+// that can be found in the LICENSE file.
+
+package genaitools
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"io"
+	"net/http"
+	"net/url"
+	"strings"
+	"time"
+	"unicode/utf8"
+
+	"github.com/maruel/genai"
+)
+
+// UserAgent is the User-Agent header sent by the tools doing HTTP requests.
+var UserAgent = "genaitools (+https://github.com/maruel/genaitools)"
+
+const (
+	// fetchTimeout is the timeout of a fetch when the client has none.
+	fetchTimeout = 30 * time.Second
+	// maxFetchRedirects is the maximum number of redirects followed.
+	maxFetchRedirects = 5
+	// maxFetchBody is the maximum number of bytes of the body returned.
+	maxFetchBody = 256 << 10
+)
+
+// FetchURL retrieves the content of an http or https URL with
+// http.DefaultClient.
+//
+// See NewFetchURL for details.
+var FetchURL = NewFetchURL(nil)
+
+// NewFetchURL returns a tool that retrieves the content of an http or https
+// URL with client. client defaults to http.DefaultClient.
+//
+// At most 5 redirects are followed. When client has no timeout, the request
+// times out after 30 seconds. The body is truncated after 256KiB. A status
+// other than 200 is returned on the first line of the result.
+func NewFetchURL(client *http.Client) genai.ToolDef {
+	c := http.Client{}
+	if client != nil {
+		c = *client
+	}
+	if c.CheckRedirect == nil {
+		c.CheckRedirect = checkFetchRedirect
+	}
+	return genai.ToolDef{
+		Name:        "fetch_url",
+		Description: "Retrieves the content of an http or https URL with a GET request and returns the response body as text.",
+		Callback: func(ctx context.Context, args *fetchURLArgs) (string, error) {
+			return doFetchURL(ctx, &c, args)
+		},
+	}
+}
+
+type fetchURLArgs struct {
+	URL string `json:"url" jsonschema:"description=Absolute http or https URL"`
+}
+
+func checkFetchRedirect(req *http.Request, via []*http.Request) error {
+	if len(via) >= maxFetchRedirects {
+		return fmt.Errorf("stopped after %d redirects", maxFetchRedirects)
+	}
+	return checkFetchScheme(req.URL)
+}
+
+func checkFetchScheme(u *url.URL) error {
+	if u.Scheme != "http" && u.Scheme != "https" {
+		return fmt.Errorf("unsupported URL scheme %q; only http and https are supported", u.Scheme)
+	}
+	if u.Host == "" {
+		return errors.New("missing host in URL")
+	}
+	return nil
+}
+
+func doFetchURL(ctx context.Context, c *http.Client, args *fetchURLArgs) (string, error) {
+	u, err := url.Parse(args.URL)
+	if err != nil {
+		return "", fmt.Errorf("invalid URL: %w", err)
+	}
+	if err = checkFetchScheme(u); err != nil {
+		return "", err
+	}
+	if c.Timeout == 0 {
+		var cancel context.CancelFunc
+		ctx, cancel = context.WithTimeout(ctx, fetchTimeout)
+		defer cancel()
+	}
+	req, err := http.NewRequestWithContext(ctx, http.MethodGet, u.String(), nil)
+	if err != nil {
+		return "", err
+	}
+	req.Header.Set("User-Agent", UserAgent)
+	resp, err := c.Do(req)
+	if err != nil {
+		return "", err
+	}
+	defer resp.Body.Close()
+	b, err := io.ReadAll(io.LimitReader(resp.Body, maxFetchBody+1))
+	if err != nil {
+		return "", fmt.Errorf("failed to read the response: %w", err)
+	}
+	truncated := len(b) > maxFetchBody
+	if truncated {
+		b = trimPartialRune(b[:maxFetchBody])
+	}
+	if !utf8.Valid(b) {
+		return "", fmt.Errorf("the response is binary (Content-Type %q)", resp.Header.Get("Content-Type"))
+	}
+	var out strings.Builder
+	if resp.StatusCode != http.StatusOK {
+		out.WriteString("HTTP " + resp.Status + "\n")
+	}
+	out.Write(b)
+	if truncated {
+		fmt.Fprintf(&out, "\n... [truncated after %d bytes]", maxFetchBody)
+	}
+	return out.String(), nil
+}
+
+// trimPartialRune removes a rune cut in half at the end of b.
+func trimPartialRune(b []byte) []byte {
+	for i := 1; i < utf8.UTFMax && len(b) > 0; i++ {
+		if r, _ := utf8.DecodeLastRune(b); r != utf8.RuneError {
+			break
+		}
+		b = b[:len(b)-1]
+	}
+	return b
+}
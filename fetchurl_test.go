@@ -0,0 +1,80 @@
+// Copyright 2025 Marc-Antoine Ruel. All rights reserved.
+// Use of this source code is governed under the Apache License, Version 2.0
+// that can be found in the LICENSE file.
+
+package genaitools
+
+import (
+	"context"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+)
+
+func TestFetchURL(t *testing.T) {
+	mux := http.NewServeMux()
+	mux.HandleFunc("/text", func(w http.ResponseWriter, r *http.Request) {
+		w.Header().Set("Content-Type", "text/plain")
+		_, _ = w.Write([]byte("hello " + r.UserAgent()))
+	})
+	mux.HandleFunc("/redirect", func(w http.ResponseWriter, r *http.Request) {
+		http.Redirect(w, r, "/text", http.StatusFound)
+	})
+	mux.HandleFunc("/loop", func(w http.ResponseWriter, r *http.Request) {
+		http.Redirect(w, r, "/loop", http.StatusFound)
+	})
+	mux.HandleFunc("/ftp", func(w http.ResponseWriter, r *http.Request) {
+		http.Redirect(w, r, "ftp://example.com/file", http.StatusFound)
+	})
+	mux.HandleFunc("/missing", func(w http.ResponseWriter, r *http.Request) {
+		http.Error(w, "nope", http.StatusNotFound)
+	})
+	mux.HandleFunc("/big", func(w http.ResponseWriter, r *http.Request) {
+		// The 3 bytes rune straddles the limit.
+		_, _ = w.Write([]byte(strings.Repeat("a", maxFetchBody-1) + "€" + "tail"))
+	})
+	mux.HandleFunc("/binary", func(w http.ResponseWriter, r *http.Request) {
+		w.Header().Set("Content-Type", "image/png")
+		_, _ = w.Write([]byte{0x89, 'P', 'N', 'G', 0xff, 0xfe})
+	})
+	srv := httptest.NewServer(mux)
+	defer srv.Close()
+
+	callback := NewFetchURL(srv.Client()).Callback.(func(context.Context, *fetchURLArgs) (string, error))
+	tests := []struct {
+		name      string
+		url       string
+		expected  string
+		errSubstr string
+	}{
+		{"text", srv.URL + "/text", "hello " + UserAgent, ""},
+		{"redirect", srv.URL + "/redirect", "hello " + UserAgent, ""},
+		{"status", srv.URL + "/missing", "HTTP 404 Not Found\nnope\n", ""},
+		{"truncated", srv.URL + "/big", strings.Repeat("a", maxFetchBody-1) + "\n... [truncated after 262144 bytes]", ""},
+		{"binary", srv.URL + "/binary", "", `the response is binary (Content-Type "image/png")`},
+		{"redirect_loop", srv.URL + "/loop", "", "stopped after 5 redirects"},
+		{"redirect_scheme", srv.URL + "/ftp", "", `unsupported URL scheme "ftp"`},
+		{"scheme", "file:///etc/passwd", "", `unsupported URL scheme "file"`},
+		{"relative", "/text", "", `unsupported URL scheme ""`},
+		{"no_host", "http:///text", "", "missing host in URL"},
+		{"invalid", "http://a b", "", "invalid URL"},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := callback(t.Context(), &fetchURLArgs{URL: tt.url})
+			if tt.errSubstr != "" {
+				if err == nil || !strings.Contains(err.Error(), tt.errSubstr) {
+					t.Fatalf("Expected error containing %q but got %v", tt.errSubstr, err)
+				}
+				return
+			}
+			if err != nil {
+				t.Fatalf("Unexpected error: %v", err)
+			}
+			if got != tt.expected {
+				t.Fatalf("Expected %q but got %q", tt.expected, got)
+			}
+		})
+	}
+}
@@ -0,0 +1,131 @@
+// Copyright 2025 Marc-Antoine Ruel. All rights reserved.
+// Use of this source code is governed under the Apache License, Version 2.0
+// that can be found in the LICENSE file.
+
+package genaitools
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"math/big"
+	"strings"
+
+	"github.com/maruel/genai"
+	"golang.org/x/text/currency"
+	"golang.org/x/text/language"
+	"golang.org/x/text/message"
+	"golang.org/x/text/number"
+)
+
+// FormatCurrency formats an amount of money for a currency and a locale.
+//
+// The locale determines the symbol, the grouping and decimal separators and
+// where the symbol goes, e.g. "$1,234.56" in en-US and "1.234,56 €" in de-DE.
+// The amount is rounded half away from zero to the number of minor units of
+// the currency, e.g. none for JPY and three for KWD.
+//
+// The currencies, symbols and separators come from golang.org/x/text. It
+// doesn't expose the CLDR currency patterns, so the position of the symbol is
+// built in for the most common languages and defaults to before the number.
+var FormatCurrency = genai.ToolDef{
+	Name:        "format_currency",
+	Description: "Formats an amount of money for an ISO 4217 currency code and a locale, with the correct symbol, separators and number of decimals.",
+	Callback:    doFormatCurrency,
+}
+
+type formatCurrencyArgs struct {
+	Amount   json.Number `json:"amount" jsonschema:"type=number,description=Amount of money"`
+	Currency string      `json:"currency" jsonschema:"description=ISO 4217 currency code\\, e.g. USD\\, EUR or JPY"`
+	Locale   string      `json:"locale,omitempty" jsonschema:"description=BCP 47 locale like en-US or fr-FR. Defaults to en-US"`
+}
+
+// maxCurrencyAmount is the largest amount formatted exactly; the number
+// formatting of golang.org/x/text goes through a float64.
+const maxCurrencyAmount = 1e15
+
+// currencyPattern is the position of the symbol relative to the number.
+type currencyPattern struct {
+	// suffix puts the symbol after the number.
+	suffix bool
+	// space separates the symbol from the number.
+	space bool
+}
+
+// currencyPatterns is keyed by language, or language-region for regional
+// variations. The other languages put the symbol first without a space.
+var currencyPatterns = map[string]currencyPattern{
+	"cs":    {suffix: true, space: true},
+	"da":    {suffix: true, space: true},
+	"de":    {suffix: true, space: true},
+	"de-AT": {space: true},
+	"de-CH": {space: true},
+	"es":    {suffix: true, space: true},
+	"es-MX": {},
+	"es-US": {},
+	"fi":    {suffix: true, space: true},
+	"fr":    {suffix: true, space: true},
+	"hu":    {suffix: true, space: true},
+	"it":    {suffix: true, space: true},
+	"it-CH": {space: true},
+	"nb":    {suffix: true, space: true},
+	"nl":    {space: true},
+	"pl":    {suffix: true, space: true},
+	"pt":    {suffix: true, space: true},
+	"pt-BR": {space: true},
+	"ru":    {suffix: true, space: true},
+	"sv":    {suffix: true, space: true},
+	"uk":    {suffix: true, space: true},
+	"vi":    {suffix: true, space: true},
+}
+
+func doFormatCurrency(ctx context.Context, args *formatCurrencyArgs) (string, error) {
+	code := strings.ToUpper(strings.TrimSpace(args.Currency))
+	unit, err := currency.ParseISO(code)
+	if err != nil {
+		return "", fmt.Errorf("unknown currency code %q; use an ISO 4217 code like USD or EUR", args.Currency)
+	}
+	locale := args.Locale
+	if locale == "" {
+		locale = "en-US"
+	}
+	tag, err := language.Parse(locale)
+	if err != nil {
+		return "", fmt.Errorf("invalid locale %q; use a BCP 47 tag like en-US: %w", args.Locale, err)
+	}
+	amount, ok := new(big.Rat).SetString(args.Amount.String())
+	if !ok {
+		return "", fmt.Errorf("invalid amount %q", args.Amount)
+	}
+	digits, _ := currency.Standard.Rounding(unit)
+	// Round half away from zero exactly before formatting.
+	s := amount.FloatString(digits)
+	sign := ""
+	if s[0] == '-' {
+		s = s[1:]
+		if strings.Trim(s, "0.") != "" {
+			sign = "-"
+		}
+	}
+	var v float64
+	if _, err := fmt.Sscan(s, &v); err != nil || v >= maxCurrencyAmount {
+		return "", fmt.Errorf("amount %q is too large", args.Amount)
+	}
+	p := message.NewPrinter(tag)
+	n := p.Sprint(number.Decimal(v, number.Scale(digits)))
+	symbol := p.Sprint(currency.Symbol(unit))
+	base, _ := tag.Base()
+	region, _ := tag.Region()
+	pat, ok := currencyPatterns[base.String()+"-"+region.String()]
+	if !ok {
+		pat = currencyPatterns[base.String()]
+	}
+	sep := ""
+	if pat.space {
+		sep = "\u00a0"
+	}
+	if pat.suffix {
+		return sign + n + sep + symbol, nil
+	}
+	return sign + symbol + sep + n, nil
+}
@@ -0,0 +1,65 @@
+// Copyright 2025 Marc-Antoine Ruel. All rights reserved.
+// Use of this source code is governed under the Apache License, Version 2.0
+// that can be found in the LICENSE file.
+
+package genaitools
+
+import (
+	"context"
+	"encoding/json"
+	"strings"
+	"testing"
+)
+
+func TestFormatCurrency(t *testing.T) {
+	callback := FormatCurrency.Callback.(func(context.Context, *formatCurrencyArgs) (string, error))
+	tests := []struct {
+		name      string
+		amount    json.Number
+		currency  string
+		locale    string
+		expected  string
+		errSubstr string
+	}{
+		{"usd_en_us", "1234.5", "USD", "", "$1,234.50", ""},
+		{"usd_negative", "-1234.567", "usd", "en-US", "-$1,234.57", ""},
+		{"usd_big", "1234567890.1", "USD", "en-US", "$1,234,567,890.10", ""},
+		{"eur_de", "1234.5", "EUR", "de-DE", "1.234,50 €", ""},
+		{"eur_fr", "1234567.891", "EUR", "fr-FR", "1\u00a0234\u00a0567,89\u00a0€", ""},
+		{"eur_nl", "1234.5", "EUR", "nl", "€ 1.234,50", ""},
+		{"eur_en", "1234.5", "EUR", "en-GB", "€1,234.50", ""},
+		{"jpy_ja", "1234.5", "JPY", "ja-JP", "￥1,235", ""},
+		{"jpy_de", "1234", "JPY", "de-DE", "1.234 ¥", ""},
+		{"kwd", "1.2345", "KWD", "en-US", "KWD1.235", ""},
+		{"inr_lakh", "12345678.9", "INR", "en-IN", "₹1,23,45,678.90", ""},
+		{"chf_ch", "1234.5", "CHF", "de-CH", "CHF 1’234.50", ""},
+		{"brl", "1234.5", "BRL", "pt-BR", "R$ 1.234,50", ""},
+		{"cad_home", "10", "CAD", "en-CA", "$10.00", ""},
+		{"cad_abroad", "10", "CAD", "en-US", "CA$10.00", ""},
+		{"negative_zero", "-0.001", "USD", "en-US", "$0.00", ""},
+		{"exact_rounding", "1.005", "USD", "en-US", "$1.01", ""},
+		{"unknown_currency", "1", "XYZ", "en-US", "", `unknown currency code "XYZ"`},
+		{"ngn", "1234.5", "NGN", "en-NG", "₦1,234.50", ""},
+		{"language_only", "1234.5", "EUR", "fr", "1\u00a0234,50\u00a0€", ""},
+		{"too_large", "1e20", "USD", "en-US", "", `amount "1e20" is too large`},
+		{"invalid_locale", "1", "USD", "english", "", `invalid locale "english"`},
+		{"invalid_amount", "one", "USD", "en-US", "", `invalid amount "one"`},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := callback(t.Context(), &formatCurrencyArgs{Amount: tt.amount, Currency: tt.currency, Locale: tt.locale})
+			if tt.errSubstr != "" {
+				if err == nil || !strings.Contains(err.Error(), tt.errSubstr) {
+					t.Fatalf("Expected error containing %q but got %v", tt.errSubstr, err)
+				}
+				return
+			}
+			if err != nil {
+				t.Fatalf("Unexpected error: %v", err)
+			}
+			if got != tt.expected {
+				t.Fatalf("Expected %q but got %q", tt.expected, got)
+			}
+		})
+	}
+}
@@ -0,0 +1,161 @@
+// Copyright 2025 Marc-Antoine Ruel. All rights reserved.
+// Use of this source code is governed under the Apache License, Version 2.0
+// that can be found in the LICENSE file.
+
+package genaitools
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"math"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/maruel/genai"
+)
+
+// maxDurationSeconds bounds the durations formatted by FormatDuration, about
+// 31 million years.
+const maxDurationSeconds = 1e15
+
+// FormatDuration converts a number of seconds to a human readable duration
+// like "1 day 2 hours 3 minutes 4 seconds", or parses a duration back to a
+// number of seconds.
+//
+// The largest unit is the day. A negative duration is prefixed with "minus".
+// The text accepts the Go format like "1h30m" or "-1.5h" and the format
+// returned by this tool.
+var FormatDuration = genai.ToolDef{
+	Name:        "format_duration",
+	Description: "Converts a number of seconds to a human readable duration like \"1 day 2 hours 3 minutes 4 seconds\", or parses a duration like \"1h30m\" or \"2 hours 5 minutes\" to a number of seconds. Pass either seconds or text.",
+	Callback:    doFormatDuration,
+}
+
+type formatDurationArgs struct {
+	Seconds json.Number `json:"seconds,omitempty" jsonschema:"type=number,description=Number of seconds to format"`
+	Text    string      `json:"text,omitempty" jsonschema:"description=Duration to parse like 1h30m or 2 hours 5 minutes"`
+}
+
+var durationUnits = []struct {
+	name    string
+	seconds int64
+}{
+	{"day", 86400},
+	{"hour", 3600},
+	{"minute", 60},
+	{"second", 1},
+}
+
+func doFormatDuration(ctx context.Context, args *formatDurationArgs) (string, error) {
+	text := strings.TrimSpace(args.Text)
+	switch {
+	case args.Seconds != "" && text != "":
+		return "", errors.New("pass either seconds or text, not both")
+	case args.Seconds != "":
+		s, err := args.Seconds.Float64()
+		if err != nil || math.IsNaN(s) {
+			return "", fmt.Errorf("invalid seconds %q", args.Seconds)
+		}
+		if math.Abs(s) > maxDurationSeconds {
+			return "", fmt.Errorf("seconds %q is too large", args.Seconds)
+		}
+		return humanizeDuration(s), nil
+	case text != "":
+		s, err := parseHumanDuration(text)
+		if err != nil {
+			return "", err
+		}
+		return strconv.FormatFloat(s, 'f', -1, 64), nil
+	default:
+		return "", errors.New("missing seconds or text")
+	}
+}
+
+// humanizeDuration formats s seconds with days, hours, minutes and seconds.
+// The fraction of a second is kept up to the millisecond.
+func humanizeDuration(s float64) string {
+	prefix := ""
+	if s < 0 {
+		prefix = "minus "
+		s = -s
+	}
+	// Work in milliseconds to avoid floating point residues like 4.000000001.
+	ms := int64(math.Round(s * 1000))
+	whole, frac := ms/1000, ms%1000
+	var parts []string
+	for _, u := range durationUnits {
+		n := whole / u.seconds
+		whole %= u.seconds
+		if u.seconds == 1 && frac != 0 {
+			v := strconv.FormatFloat(float64(n)+float64(frac)/1000, 'f', -1, 64)
+			parts = append(parts, v+" seconds")
+			continue
+		}
+		if n == 0 {
+			continue
+		}
+		p := strconv.FormatInt(n, 10) + " " + u.name
+		if n != 1 {
+			p += "s"
+		}
+		parts = append(parts, p)
+	}
+	if len(parts) == 0 {
+		return "0 seconds"
+	}
+	return prefix + strings.Join(parts, " ")
+}
+
+// parseHumanDuration parses a Go duration like "1h30m" or a list of counts
+// and units like "1 day, 2 hours and 3.5 seconds" and returns the number of
+// seconds.
+func parseHumanDuration(text string) (float64, error) {
+	if d, err := time.ParseDuration(text); err == nil {
+		return d.Seconds(), nil
+	}
+	s := strings.ToLower(text)
+	sign := 1.
+	if rest, ok := strings.CutPrefix(s, "minus "); ok {
+		s, sign = rest, -1
+	} else if rest, ok := strings.CutPrefix(s, "-"); ok {
+		s, sign = rest, -1
+	}
+	fields := strings.FieldsFunc(s, func(r rune) bool { return r == ' ' || r == ',' })
+	fields = removeWord(fields, "and")
+	if len(fields) == 0 || len(fields)%2 != 0 {
+		return 0, fmt.Errorf("invalid duration %q; use a format like 1h30m or 1 hour 30 minutes", text)
+	}
+	total := 0.
+	for i := 0; i < len(fields); i += 2 {
+		n, err := strconv.ParseFloat(fields[i], 64)
+		if err != nil || n < 0 || math.IsInf(n, 0) {
+			return 0, fmt.Errorf("invalid duration %q: %q is not a number", text, fields[i])
+		}
+		unit := strings.TrimSuffix(fields[i+1], "s")
+		found := false
+		for _, u := range durationUnits {
+			if unit == u.name {
+				total += n * float64(u.seconds)
+				found = true
+				break
+			}
+		}
+		if !found {
+			return 0, fmt.Errorf("invalid duration %q: unknown unit %q; use days, hours, minutes or seconds", text, fields[i+1])
+		}
+	}
+	return sign * total, nil
+}
+
+func removeWord(fields []string, word string) []string {
+	out := fields[:0]
+	for _, f := range fields {
+		if f != word {
+			out = append(out, f)
+		}
+	}
+	return out
+}
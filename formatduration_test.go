@@ -0,0 +1,79 @@
+// Copyright 2025 Marc-Antoine Ruel. All rights reserved.
+// Use of this source code is governed under the Apache License, Version 2.0
+// that can be found in the LICENSE file.
+
+package genaitools
+
+import (
+	"context"
+	"encoding/json"
+	"strings"
+	"testing"
+)
+
+func TestFormatDuration(t *testing.T) {
+	callback := FormatDuration.Callback.(func(context.Context, *formatDurationArgs) (string, error))
+	tests := []struct {
+		name      string
+		args      formatDurationArgs
+		expected  string
+		errSubstr string
+	}{
+		{"humanize", formatDurationArgs{Seconds: "93784"}, "1 day 2 hours 3 minutes 4 seconds", ""},
+		{"zero", formatDurationArgs{Seconds: "0"}, "0 seconds", ""},
+		{"singular", formatDurationArgs{Seconds: "3661"}, "1 hour 1 minute 1 second", ""},
+		{"gaps", formatDurationArgs{Seconds: "172800.0"}, "2 days", ""},
+		{"one_second", formatDurationArgs{Seconds: "1"}, "1 second", ""},
+		{"fraction", formatDurationArgs{Seconds: "61.25"}, "1 minute 1.25 seconds", ""},
+		{"sub_second", formatDurationArgs{Seconds: "0.5"}, "0.5 seconds", ""},
+		{"sub_millisecond", formatDurationArgs{Seconds: "0.0001"}, "0 seconds", ""},
+		{"negative", formatDurationArgs{Seconds: "-5400"}, "minus 1 hour 30 minutes", ""},
+		{"large", formatDurationArgs{Seconds: "1e15"}, "11574074074 days 1 hour 46 minutes 40 seconds", ""},
+		{"parse_go", formatDurationArgs{Text: "1h30m"}, "5400", ""},
+		{"parse_go_negative", formatDurationArgs{Text: "-1.5h"}, "-5400", ""},
+		{"parse_go_ms", formatDurationArgs{Text: "300ms"}, "0.3", ""},
+		{"parse_go_zero", formatDurationArgs{Text: "0"}, "0", ""},
+		{"parse_human", formatDurationArgs{Text: "1 day 2 hours 3 minutes 4 seconds"}, "93784", ""},
+		{"parse_human_and", formatDurationArgs{Text: "2 Hours, 5 minutes and 1.5 seconds"}, "7501.5", ""},
+		{"parse_human_negative", formatDurationArgs{Text: "minus 1 hour 30 minutes"}, "-5400", ""},
+		{"too_large", formatDurationArgs{Seconds: "1e16"}, "", `seconds "1e16" is too large`},
+		{"both", formatDurationArgs{Seconds: "1", Text: "1s"}, "", "pass either seconds or text, not both"},
+		{"none", formatDurationArgs{}, "", "missing seconds or text"},
+		{"bad_unit", formatDurationArgs{Text: "3 fortnights"}, "", `unknown unit "fortnights"`},
+		{"bad_number", formatDurationArgs{Text: "x hours"}, "", `"x" is not a number`},
+		{"bad_format", formatDurationArgs{Text: "1 hour 30"}, "", "use a format like 1h30m"},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := callback(t.Context(), &tt.args)
+			if tt.errSubstr != "" {
+				if err == nil || !strings.Contains(err.Error(), tt.errSubstr) {
+					t.Fatalf("Expected error containing %q but got %v", tt.errSubstr, err)
+				}
+				return
+			}
+			if err != nil {
+				t.Fatalf("Unexpected error: %v", err)
+			}
+			if got != tt.expected {
+				t.Fatalf("Expected %q but got %q", tt.expected, got)
+			}
+		})
+	}
+
+	t.Run("round_trip", func(t *testing.T) {
+		for _, s := range []string{"0", "1", "59", "60", "61", "3599", "3600", "86399", "86400", "93784", "-93784", "2.5", "31536000"} {
+			text, err := callback(t.Context(), &formatDurationArgs{Seconds: json.Number(s)})
+			if err != nil {
+				t.Fatalf("Unexpected error: %v", err)
+			}
+			got, err := callback(t.Context(), &formatDurationArgs{Text: text})
+			if err != nil {
+				t.Fatalf("Unexpected error parsing %q: %v", text, err)
+			}
+			if got != s {
+				t.Fatalf("Expected %q but got %q for %q", s, got, text)
+			}
+		}
+	})
+}
@@ -0,0 +1,74 @@
+// Copyright 2025 Marc-Antoine Ruel. All rights reserved.
+// Use of this source code is governed under the Apache License, Version 2.0
+// that can be found in the LICENSE file.
+
+package genaitools
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"strings"
+
+	"github.com/maruel/genai"
+)
+
+// FormatJSON pretty-prints or minifies a JSON document.
+//
+// The key order and the number literals are preserved as is. Invalid JSON is
+// reported with the position of the error.
+var FormatJSON = genai.ToolDef{
+	Name:        "format_json",
+	Description: "Validates a JSON document and pretty-prints it with indentation or minifies it, preserving the key order. Reports the line and column of syntax errors.",
+	Callback:    doFormatJSON,
+}
+
+type formatJSONArgs struct {
+	JSON      string `json:"json" jsonschema:"description=JSON document"`
+	Operation string `json:"operation" jsonschema:"enum=pretty,enum=minify"`
+	Indent    int    `json:"indent,omitempty" jsonschema:"description=Number of spaces per indentation level when pretty-printing. Defaults to 2,minimum=1,maximum=8"`
+}
+
+func doFormatJSON(ctx context.Context, args *formatJSONArgs) (string, error) {
+	src := []byte(args.JSON)
+	if len(bytes.TrimSpace(src)) == 0 {
+		return "", errors.New("empty JSON document")
+	}
+	var out bytes.Buffer
+	var err error
+	switch args.Operation {
+	case "pretty":
+		indent := args.Indent
+		if indent == 0 {
+			indent = 2
+		}
+		if indent < 1 || indent > 8 {
+			return "", fmt.Errorf("invalid indent %d; must be between 1 and 8", args.Indent)
+		}
+		err = json.Indent(&out, src, "", strings.Repeat(" ", indent))
+	case "minify":
+		err = json.Compact(&out, src)
+	default:
+		return "", fmt.Errorf("unknown operation %q; supported operations are pretty and minify", args.Operation)
+	}
+	if err != nil {
+		return "", jsonSyntaxError(src, err)
+	}
+	return strings.TrimSpace(out.String()), nil
+}
+
+// jsonSyntaxError adds the line and column of a syntax error.
+func jsonSyntaxError(src []byte, err error) error {
+	var se *json.SyntaxError
+	if !errors.As(err, &se) {
+		return fmt.Errorf("invalid JSON: %w", err)
+	}
+	// Offset is the number of bytes read when the error occurred, so the
+	// faulty character is the previous one.
+	before := src[:max(se.Offset-1, 0)]
+	line := bytes.Count(before, []byte("\n")) + 1
+	col := len(before) - bytes.LastIndexByte(before, '\n')
+	return fmt.Errorf("invalid JSON at byte %d (line %d, column %d): %w", se.Offset, line, col, err)
+}
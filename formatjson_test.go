@@ -0,0 +1,55 @@
+// Copyright 2025 Marc-Antoine Ruel. All rights reserved.
+// Use of this source code is governed under the Apache License, Version 2.0
+// that can be found in the LICENSE file.
+
+package genaitools
+
+import (
+	"context"
+	"encoding/json"
+	"strings"
+	"testing"
+)
+
+func TestFormatJSON(t *testing.T) {
+	callback := FormatJSON.Callback.(func(context.Context, *formatJSONArgs) (string, error))
+	const doc = ` {"z": 1.50, "a": [1, {"b": "<x>"}], "e": {}, "n": null} `
+	tests := []struct {
+		name      string
+		args      formatJSONArgs
+		expected  string
+		errSubstr string
+	}{
+		{"pretty", formatJSONArgs{JSON: doc, Operation: "pretty"}, "{\n  \"z\": 1.50,\n  \"a\": [\n    1,\n    {\n      \"b\": \"<x>\"\n    }\n  ],\n  \"e\": {},\n  \"n\": null\n}", ""},
+		{"pretty_indent", formatJSONArgs{JSON: `[1,[]]`, Operation: "pretty", Indent: 4}, "[\n    1,\n    []\n]", ""},
+		{"minify", formatJSONArgs{JSON: "{\n  \"z\": 1.50,\n  \"a\": [ 1, 2 ]\n}\n", Operation: "minify"}, `{"z":1.50,"a":[1,2]}`, ""},
+		{"scalar", formatJSONArgs{JSON: ` "x" `, Operation: "minify"}, `"x"`, ""},
+		{"invalid", formatJSONArgs{JSON: "{\n  \"a\": 1,\n  \"b\": tru\n}", Operation: "pretty"}, "", "invalid JSON at byte 23 (line 3, column 11): invalid character '\\n' in literal true (expecting 'e')"},
+		{"trailing_comma", formatJSONArgs{JSON: `[1,]`, Operation: "minify"}, "", "invalid JSON at byte 4 (line 1, column 4): invalid character ']' looking for beginning of value"},
+		{"two_values", formatJSONArgs{JSON: `{} {}`, Operation: "minify"}, "", "invalid character '{' after top-level value"},
+		{"truncated", formatJSONArgs{JSON: `{"a": [1`, Operation: "minify"}, "", "unexpected end of JSON input"},
+		{"empty", formatJSONArgs{JSON: " \n", Operation: "pretty"}, "", "empty JSON document"},
+		{"bad_indent", formatJSONArgs{JSON: `{}`, Operation: "pretty", Indent: 9}, "", "invalid indent 9"},
+		{"bad_operation", formatJSONArgs{JSON: `{}`, Operation: "sort"}, "", "unknown operation"},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := callback(t.Context(), &tt.args)
+			if tt.errSubstr != "" {
+				if err == nil || !strings.Contains(err.Error(), tt.errSubstr) {
+					t.Fatalf("Expected error containing %q but got %v", tt.errSubstr, err)
+				}
+				return
+			}
+			if err != nil {
+				t.Fatalf("Unexpected error: %v", err)
+			}
+			if got != tt.expected {
+				t.Fatalf("Expected %q but got %q", tt.expected, got)
+			}
+			if !json.Valid([]byte(got)) {
+				t.Fatalf("Invalid JSON output %q", got)
+			}
+		})
+	}
+}
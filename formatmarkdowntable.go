@@ -0,0 +1,117 @@
+// Copyright 2025 Marc-Antoine Ruel. All rights reserved.
+// Use of this source code is governed under the Apache License, Version 2.0
+// that can be found in the LICENSE file.
+
+package genaitools
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"strings"
+	"unicode/utf8"
+
+	"github.com/maruel/genai"
+)
+
+// FormatMarkdownTable formats a table as a GitHub-flavored Markdown table with
+// the columns padded to the same width.
+//
+// Pipe characters in cells are escaped and line breaks are replaced with
+// <br>. The column width is counted in runes, so wide characters like CJK
+// may not align visually.
+var FormatMarkdownTable = genai.ToolDef{
+	Name:        "format_markdown_table",
+	Description: "Formats headers and rows as an aligned GitHub-flavored Markdown table. Each row must have one cell per header.",
+	Callback:    doFormatMarkdownTable,
+}
+
+type formatMarkdownTableArgs struct {
+	Headers []string   `json:"headers" jsonschema:"description=Column headers"`
+	Rows    [][]string `json:"rows" jsonschema:"description=Rows of cells; each row has one cell per header"`
+	Align   []string   `json:"align,omitempty" jsonschema:"description=Alignment of each column: left\\, center or right. Defaults to left"`
+}
+
+func doFormatMarkdownTable(ctx context.Context, args *formatMarkdownTableArgs) (string, error) {
+	n := len(args.Headers)
+	if n == 0 {
+		return "", errors.New("missing headers")
+	}
+	if len(args.Align) != 0 && len(args.Align) != n {
+		return "", fmt.Errorf("align has %d values; expected one per header (%d)", len(args.Align), n)
+	}
+	for i, a := range args.Align {
+		if a != "left" && a != "center" && a != "right" && a != "" {
+			return "", fmt.Errorf("invalid align %q for column %d; use left, center or right", a, i+1)
+		}
+	}
+	table := make([][]string, 0, len(args.Rows)+1)
+	table = append(table, args.Headers)
+	for i, row := range args.Rows {
+		if len(row) != n {
+			return "", fmt.Errorf("row %d has %d cells; expected %d like the headers", i+1, len(row), n)
+		}
+		table = append(table, row)
+	}
+	// Separator rows need at least 3 dashes.
+	widths := make([]int, n)
+	for i := range widths {
+		widths[i] = 3
+	}
+	cells := make([][]string, len(table))
+	for r, row := range table {
+		cells[r] = make([]string, n)
+		for c, cell := range row {
+			cells[r][c] = escapeMarkdownCell(cell)
+			widths[c] = max(widths[c], utf8.RuneCountInString(cells[r][c]))
+		}
+	}
+	var b strings.Builder
+	for r, row := range cells {
+		writeMarkdownRow(&b, row, widths, args.Align)
+		if r == 0 {
+			sep := make([]string, n)
+			for c, w := range widths {
+				align := ""
+				if len(args.Align) != 0 {
+					align = args.Align[c]
+				}
+				switch align {
+				case "center":
+					sep[c] = ":" + strings.Repeat("-", w-2) + ":"
+				case "right":
+					sep[c] = strings.Repeat("-", w-1) + ":"
+				default:
+					sep[c] = strings.Repeat("-", w)
+				}
+			}
+			writeMarkdownRow(&b, sep, widths, nil)
+		}
+	}
+	return strings.TrimSuffix(b.String(), "\n"), nil
+}
+
+func writeMarkdownRow(b *strings.Builder, row []string, widths []int, align []string) {
+	b.WriteString("|")
+	for c, cell := range row {
+		pad := widths[c] - utf8.RuneCountInString(cell)
+		left := 0
+		if len(align) != 0 {
+			switch align[c] {
+			case "center":
+				left = pad / 2
+			case "right":
+				left = pad
+			}
+		}
+		b.WriteString(" " + strings.Repeat(" ", left) + cell + strings.Repeat(" ", pad-left) + " |")
+	}
+	b.WriteString("\n")
+}
+
+// escapeMarkdownCell escapes the characters that would break a table row.
+func escapeMarkdownCell(s string) string {
+	s = strings.ReplaceAll(s, "\r\n", "\n")
+	s = strings.ReplaceAll(s, "|", `\|`)
+	return strings.ReplaceAll(strings.TrimSpace(s), "\n", "<br>")
+}
@@ -0,0 +1,88 @@
+// Copyright 2025 Marc-Antoine Ruel. All rights reserved.
+// Use of this source code is governed under the Apache License, Version 2.0
+// that can be found in the LICENSE file.
+
+package genaitools
+
+import (
+	"context"
+	"strings"
+	"testing"
+)
+
+func TestFormatMarkdownTable(t *testing.T) {
+	callback := FormatMarkdownTable.Callback.(func(context.Context, *formatMarkdownTableArgs) (string, error))
+	tests := []struct {
+		name      string
+		args      formatMarkdownTableArgs
+		expected  string
+		errSubstr string
+	}{
+		{
+			"padding",
+			formatMarkdownTableArgs{Headers: []string{"Name", "Qty"}, Rows: [][]string{{"apple", "1"}, {"kiwi", "12"}}},
+			"| Name  | Qty |\n" +
+				"| ----- | --- |\n" +
+				"| apple | 1   |\n" +
+				"| kiwi  | 12  |",
+			"",
+		},
+		{
+			"align",
+			formatMarkdownTableArgs{Headers: []string{"L", "Center", "R"}, Rows: [][]string{{"a", "b", "1"}, {"abcd", "c", "1000"}}, Align: []string{"left", "center", "right"}},
+			"| L    | Center |    R |\n" +
+				"| ---- | :----: | ---: |\n" +
+				"| a    |   b    |    1 |\n" +
+				"| abcd |   c    | 1000 |",
+			"",
+		},
+		{
+			"pipe_escaping",
+			formatMarkdownTableArgs{Headers: []string{"Expr"}, Rows: [][]string{{"a|b"}, {"x || y"}}},
+			"| Expr     |\n" +
+				"| -------- |\n" +
+				"| a\\|b     |\n" +
+				"| x \\|\\| y |",
+			"",
+		},
+		{
+			"newlines",
+			formatMarkdownTableArgs{Headers: []string{"Note"}, Rows: [][]string{{" line1\r\nline2 "}}},
+			"| Note           |\n" +
+				"| -------------- |\n" +
+				"| line1<br>line2 |",
+			"",
+		},
+		{
+			"unicode",
+			formatMarkdownTableArgs{Headers: []string{"Ville"}, Rows: [][]string{{"Zürich"}, {"Montréal"}}},
+			"| Ville    |\n" +
+				"| -------- |\n" +
+				"| Zürich   |\n" +
+				"| Montréal |",
+			"",
+		},
+		{"no_rows", formatMarkdownTableArgs{Headers: []string{"a", "b"}}, "| a   | b   |\n| --- | --- |", ""},
+		{"ragged", formatMarkdownTableArgs{Headers: []string{"a", "b"}, Rows: [][]string{{"1", "2"}, {"3"}}}, "", "row 2 has 1 cells; expected 2 like the headers"},
+		{"no_headers", formatMarkdownTableArgs{Rows: [][]string{{"1"}}}, "", "missing headers"},
+		{"align_count", formatMarkdownTableArgs{Headers: []string{"a", "b"}, Align: []string{"left"}}, "", "align has 1 values; expected one per header (2)"},
+		{"align_value", formatMarkdownTableArgs{Headers: []string{"a"}, Align: []string{"middle"}}, "", `invalid align "middle" for column 1`},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := callback(t.Context(), &tt.args)
+			if tt.errSubstr != "" {
+				if err == nil || !strings.Contains(err.Error(), tt.errSubstr) {
+					t.Fatalf("Expected error containing %q but got %v", tt.errSubstr, err)
+				}
+				return
+			}
+			if err != nil {
+				t.Fatalf("Unexpected error: %v", err)
+			}
+			if got != tt.expected {
+				t.Fatalf("Expected %q but got %q", tt.expected, got)
+			}
+		})
+	}
+}
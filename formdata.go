@@ -0,0 +1,154 @@
+// Copyright 2025 Marc-Antoine Ruel. All rights reserved.
+// Use of this source code is governed under the Apache License, Version 2.0
+// that can be found in the LICENSE file.
+
+package genaitools
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"io"
+	"mime"
+	"mime/multipart"
+	"net/url"
+	"strings"
+	"unicode/utf8"
+
+	"github.com/maruel/genai"
+)
+
+// FormData parses HTML form submission bodies or builds urlencoded ones.
+//
+// parse decodes an application/x-www-form-urlencoded or multipart/form-data
+// body into JSON. The multipart boundary is taken from the content type or
+// else from the first line of the body, and bare "\n" line endings are
+// accepted. File parts are summarized and their content is only included when
+// it is short text.
+//
+// build encodes fields as application/x-www-form-urlencoded, in order.
+var FormData = genai.ToolDef{
+	Name:        "form_data",
+	Description: "Parses an HTTP form body (application/x-www-form-urlencoded or multipart/form-data) into JSON fields and files, or builds an application/x-www-form-urlencoded body from name/value pairs.",
+	Callback:    doFormData,
+}
+
+type formDataArgs struct {
+	Operation   string      `json:"operation" jsonschema:"enum=parse,enum=build"`
+	Body        string      `json:"body,omitempty" jsonschema:"description=Body to parse"`
+	ContentType string      `json:"content_type,omitempty" jsonschema:"description=Content-Type header of the body to parse like multipart/form-data; boundary=X. Detected when omitted"`
+	Fields      []formField `json:"fields,omitempty" jsonschema:"description=Fields to encode in order. Repeat a name for multiple values"`
+}
+
+type formField struct {
+	Name  string `json:"name"`
+	Value string `json:"value"`
+}
+
+type formFile struct {
+	Field       string `json:"field"`
+	Filename    string `json:"filename"`
+	ContentType string `json:"content_type,omitempty"`
+	Size        int    `json:"size"`
+	Content     string `json:"content,omitempty"`
+}
+
+type formDataResult struct {
+	Fields map[string][]string `json:"fields"`
+	Files  []formFile          `json:"files,omitempty"`
+}
+
+// maxFormFileContent is the largest file content included in the result.
+const maxFormFileContent = 4096
+
+func doFormData(ctx context.Context, args *formDataArgs) (string, error) {
+	switch args.Operation {
+	case "parse":
+		res, err := parseFormData(args.Body, args.ContentType)
+		if err != nil {
+			return "", err
+		}
+		return marshalJSON(res)
+	case "build":
+		out := make([]string, len(args.Fields))
+		for i, f := range args.Fields {
+			if f.Name == "" {
+				return "", fmt.Errorf("field %d has an empty name", i)
+			}
+			out[i] = url.QueryEscape(f.Name) + "=" + url.QueryEscape(f.Value)
+		}
+		return strings.Join(out, "&"), nil
+	default:
+		return "", fmt.Errorf("unknown operation %q; supported operations are parse and build", args.Operation)
+	}
+}
+
+func parseFormData(body, contentType string) (*formDataResult, error) {
+	media, boundary := "", ""
+	if contentType != "" {
+		var params map[string]string
+		var err error
+		if media, params, err = mime.ParseMediaType(contentType); err != nil {
+			return nil, fmt.Errorf("invalid content_type %q: %w", contentType, err)
+		}
+		boundary = params["boundary"]
+	} else if strings.HasPrefix(body, "--") {
+		media = "multipart/form-data"
+	} else {
+		media = "application/x-www-form-urlencoded"
+	}
+	switch media {
+	case "application/x-www-form-urlencoded":
+		v, err := url.ParseQuery(strings.TrimRight(body, "\r\n"))
+		if err != nil {
+			return nil, fmt.Errorf("invalid urlencoded body: %w", err)
+		}
+		return &formDataResult{Fields: v}, nil
+	case "multipart/form-data":
+		return parseMultipart(body, boundary)
+	default:
+		return nil, fmt.Errorf("unsupported content_type %q; supported types are application/x-www-form-urlencoded and multipart/form-data", media)
+	}
+}
+
+func parseMultipart(body, boundary string) (*formDataResult, error) {
+	if !strings.Contains(body, "\r\n") {
+		body = strings.ReplaceAll(body, "\n", "\r\n")
+	}
+	if boundary == "" {
+		first, _, _ := strings.Cut(body, "\r\n")
+		if !strings.HasPrefix(first, "--") || len(first) == 2 {
+			return nil, errors.New("invalid multipart body: missing boundary; pass the content_type with its boundary parameter")
+		}
+		boundary = first[2:]
+	}
+	res := &formDataResult{Fields: map[string][]string{}}
+	r := multipart.NewReader(strings.NewReader(body), boundary)
+	for i := 0; ; i++ {
+		p, err := r.NextPart()
+		if err == io.EOF {
+			break
+		}
+		if err != nil {
+			return nil, fmt.Errorf("invalid multipart body at part %d: %w", i, err)
+		}
+		b, err := io.ReadAll(p)
+		if err != nil {
+			return nil, fmt.Errorf("invalid multipart body at part %d: %w", i, err)
+		}
+		name := p.FormName()
+		if name == "" {
+			return nil, fmt.Errorf("invalid multipart body at part %d: missing Content-Disposition name", i)
+		}
+		if p.FileName() == "" {
+			res.Fields[name] = append(res.Fields[name], string(b))
+			continue
+		}
+		f := formFile{Field: name, Filename: p.FileName(), ContentType: p.Header.Get("Content-Type"), Size: len(b)}
+		if len(b) <= maxFormFileContent && utf8.Valid(b) {
+			f.Content = string(b)
+		}
+		res.Files = append(res.Files, f)
+	}
+	return res, nil
+}
@@ -0,0 +1,90 @@
+// Copyright 2025 Marc-Antoine Ruel. All rights reserved.
+// Use of this source code is governed under the Apache License, Version 2.0
+// that can be found in the LICENSE file.
+
+package genaitools
+
+import (
+	"context"
+	"strings"
+	"testing"
+)
+
+func TestFormData(t *testing.T) {
+	callback := FormData.Callback.(func(context.Context, *formDataArgs) (string, error))
+	const multipartBody = "--XYZ\r\n" +
+		"Content-Disposition: form-data; name=\"title\"\r\n\r\n" +
+		"Hello <world>\r\n" +
+		"--XYZ\r\n" +
+		"Content-Disposition: form-data; name=\"upload\"; filename=\"a.txt\"\r\n" +
+		"Content-Type: text/plain\r\n\r\n" +
+		"file content\r\n" +
+		"--XYZ\r\n" +
+		"Content-Disposition: form-data; name=\"img\"; filename=\"b.png\"\r\n" +
+		"Content-Type: image/png\r\n\r\n" +
+		"\x89PNG\xff\r\n" +
+		"--XYZ--\r\n"
+	tests := []struct {
+		name      string
+		args      formDataArgs
+		expected  string
+		errSubstr string
+	}{
+		{
+			"urlencoded",
+			formDataArgs{Operation: "parse", Body: "name=J%C3%BCrgen+M&tag=a&tag=b&empty=\n"},
+			`{"fields":{"empty":[""],"name":["Jürgen M"],"tag":["a","b"]}}`,
+			"",
+		},
+		{
+			"urlencoded_content_type",
+			formDataArgs{Operation: "parse", Body: "--x=1", ContentType: "application/x-www-form-urlencoded; charset=utf-8"},
+			`{"fields":{"--x":["1"]}}`,
+			"",
+		},
+		{
+			"multipart",
+			formDataArgs{Operation: "parse", Body: multipartBody, ContentType: "multipart/form-data; boundary=XYZ"},
+			`{"fields":{"title":["Hello <world>"]},"files":[{"field":"upload","filename":"a.txt","content_type":"text/plain","size":12,"content":"file content"},{"field":"img","filename":"b.png","content_type":"image/png","size":5}]}`,
+			"",
+		},
+		{
+			"multipart_detected_lf",
+			formDataArgs{Operation: "parse", Body: "--b\nContent-Disposition: form-data; name=\"a\"\n\n1\n--b\nContent-Disposition: form-data; name=\"a\"\n\n2\n--b--\n"},
+			`{"fields":{"a":["1","2"]}}`,
+			"",
+		},
+		{
+			"build",
+			formDataArgs{Operation: "build", Fields: []formField{{"q", "a&b=c"}, {"name", "Jürgen M"}, {"q", ""}}},
+			"q=a%26b%3Dc&name=J%C3%BCrgen+M&q=",
+			"",
+		},
+		{"build_empty", formDataArgs{Operation: "build"}, "", ""},
+		{"bad_escape", formDataArgs{Operation: "parse", Body: "a=%zz"}, "", "invalid urlencoded body"},
+		{"bad_content_type", formDataArgs{Operation: "parse", Body: "{}", ContentType: "application/json"}, "", `unsupported content_type "application/json"`},
+		{"invalid_content_type", formDataArgs{Operation: "parse", Body: "a", ContentType: "multipart/; x"}, "", "invalid content_type"},
+		{"missing_boundary", formDataArgs{Operation: "parse", Body: "a=1", ContentType: "multipart/form-data"}, "", "missing boundary"},
+		{"truncated", formDataArgs{Operation: "parse", Body: "--b\r\nContent-Disposition: form-data; name=\"a\"\r\n\r\n1"}, "", "invalid multipart body at part 0"},
+		{"no_name", formDataArgs{Operation: "parse", Body: "--b\r\nContent-Type: text/plain\r\n\r\n1\r\n--b--\r\n"}, "", "missing Content-Disposition name"},
+		{"build_no_name", formDataArgs{Operation: "build", Fields: []formField{{"", "x"}}}, "", "field 0 has an empty name"},
+		{"bad_operation", formDataArgs{Operation: "encode"}, "", "unknown operation"},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := callback(t.Context(), &tt.args)
+			if tt.errSubstr != "" {
+				if err == nil || !strings.Contains(err.Error(), tt.errSubstr) {
+					t.Fatalf("Expected error containing %q but got %v", tt.errSubstr, err)
+				}
+				return
+			}
+			if err != nil {
+				t.Fatalf("Unexpected error: %v", err)
+			}
+			if got != tt.expected {
+				t.Fatalf("Expected %q but got %q", tt.expected, got)
+			}
+		})
+	}
+}
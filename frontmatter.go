@@ -0,0 +1,110 @@
+// Copyright 2025 Marc-Antoine Ruel. All rights reserved.
+// Use of this source code is governed under the Apache License, Version 2.0
+// that can be found in the LICENSE file.
+
+package genaitools
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"strings"
+
+	"github.com/maruel/genai"
+)
+
+// ParseFrontmatter splits the frontmatter from a document like Markdown.
+//
+// YAML frontmatter is fenced by "---" lines (the closing fence can also be
+// "..."), TOML frontmatter by "+++" lines. The opening fence must be the
+// first line. A document without frontmatter is returned as the body with no
+// metadata.
+var ParseFrontmatter = genai.ToolDef{
+	Name:        "parse_frontmatter",
+	Description: "Splits the YAML (---) or TOML (+++) frontmatter from a Markdown document and returns a JSON object with the format, the metadata as JSON and the body.",
+	Callback:    doParseFrontmatter,
+}
+
+type parseFrontmatterArgs struct {
+	Document string `json:"document" jsonschema:"description=Document starting with optional frontmatter"`
+}
+
+type frontmatterResult struct {
+	// Format is "yaml", "toml" or empty when there is no frontmatter.
+	Format   string         `json:"format"`
+	Metadata map[string]any `json:"metadata"`
+	Body     string         `json:"body"`
+}
+
+func doParseFrontmatter(ctx context.Context, args *parseFrontmatterArgs) (string, error) {
+	res, err := parseFrontmatter(args.Document)
+	if err != nil {
+		return "", err
+	}
+	b, err := json.Marshal(res)
+	if err != nil {
+		return "", fmt.Errorf("metadata cannot be represented as JSON: %w", err)
+	}
+	return string(b), nil
+}
+
+func parseFrontmatter(doc string) (*frontmatterResult, error) {
+	doc = strings.TrimPrefix(doc, "\ufeff")
+	first, rest, _ := strings.Cut(doc, "\n")
+	format := ""
+	closing := []string{}
+	switch strings.TrimRight(first, " \t\r") {
+	case "---":
+		format, closing = "yaml", []string{"---", "..."}
+	case "+++":
+		format, closing = "toml", []string{"+++"}
+	default:
+		return &frontmatterResult{Body: doc}, nil
+	}
+	var meta, body string
+	found := false
+	for off := 0; off < len(rest); {
+		line, _, _ := strings.Cut(rest[off:], "\n")
+		next := off + len(line) + 1
+		for _, c := range closing {
+			if strings.TrimRight(line, " \t\r") == c {
+				meta, found = rest[:off], true
+				if next < len(rest) {
+					body = rest[next:]
+				}
+				break
+			}
+		}
+		if found {
+			break
+		}
+		off = next
+	}
+	if !found {
+		return nil, fmt.Errorf("unterminated %s frontmatter; expected a closing %q line", strings.ToUpper(format), closing[0])
+	}
+	res := &frontmatterResult{Format: format, Body: body, Metadata: map[string]any{}}
+	if strings.TrimSpace(meta) == "" {
+		return res, nil
+	}
+	switch format {
+	case "yaml":
+		v, err := parseDocument(meta, "yaml")
+		if err != nil {
+			return nil, fmt.Errorf("invalid YAML frontmatter: %w", err)
+		}
+		m, ok := v.(map[string]any)
+		if !ok {
+			return nil, errors.New("invalid YAML frontmatter: it must be a mapping of keys to values")
+		}
+		res.Metadata = m
+	case "toml":
+		m, err := parseTOML(meta)
+		if err != nil {
+			return nil, fmt.Errorf("invalid TOML frontmatter: %w", err)
+		}
+		res.Metadata = m
+	}
+	return res, nil
+}
@@ -0,0 +1,65 @@
+// Copyright 2025 Marc-Antoine Ruel. All rights reserved.
+// Use of this source code is governed under the Apache License, Version 2.0
+// that can be found in the LICENSE file.
+
+package genaitools
+
+import (
+	"context"
+	"strings"
+	"testing"
+)
+
+func TestParseFrontmatter(t *testing.T) {
+	callback := ParseFrontmatter.Callback.(func(context.Context, *parseFrontmatterArgs) (string, error))
+	tests := []struct {
+		name      string
+		document  string
+		expected  string
+		errSubstr string
+	}{
+		{
+			"yaml",
+			"---\ntitle: Hello\ntags: [a, b]\ndraft: false\n---\n# Body\n",
+			`{"format":"yaml","metadata":{"draft":false,"tags":["a","b"],"title":"Hello"},"body":"# Body\n"}`,
+			"",
+		},
+		{
+			"yaml_dots_crlf",
+			"---\r\nn: 3\r\n...\r\nbody\r\n",
+			`{"format":"yaml","metadata":{"n":3},"body":"body\r\n"}`,
+			"",
+		},
+		{
+			"toml",
+			"+++\ntitle = \"Hello\" # comment\ndate = 2024-01-02\n[params]\nweight = 1.5\n+++\nbody",
+			`{"format":"toml","metadata":{"date":"2024-01-02","params":{"weight":1.5},"title":"Hello"},"body":"body"}`,
+			"",
+		},
+		{"empty", "---\n---\nbody", `{"format":"yaml","metadata":{},"body":"body"}`, ""},
+		{"no_body", "---\na: 1\n---", `{"format":"yaml","metadata":{"a":1},"body":""}`, ""},
+		{"none", "# Title\n---\nbody\n", `{"format":"","metadata":null,"body":"# Title\n---\nbody\n"}`, ""},
+		{"bom", "\ufeff+++\na = 1\n+++\n", `{"format":"toml","metadata":{"a":1},"body":""}`, ""},
+		{"unterminated", "---\ntitle: x\n", "", `unterminated YAML frontmatter; expected a closing "---" line`},
+		{"bad_yaml", "---\ntitle: [x\n---\n", "", "invalid YAML frontmatter"},
+		{"yaml_list", "---\n- a\n---\n", "", "it must be a mapping"},
+		{"bad_toml", "+++\ntitle = \n+++\n", "", "invalid TOML frontmatter: line 1: expected a value"},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := callback(t.Context(), &parseFrontmatterArgs{Document: tt.document})
+			if tt.errSubstr != "" {
+				if err == nil || !strings.Contains(err.Error(), tt.errSubstr) {
+					t.Fatalf("Expected error containing %q but got %v", tt.errSubstr, err)
+				}
+				return
+			}
+			if err != nil {
+				t.Fatalf("Unexpected error: %v", err)
+			}
+			if got != tt.expected {
+				t.Fatalf("Expected %s\ngot      %s", tt.expected, got)
+			}
+		})
+	}
+}
@@ -0,0 +1,87 @@
+// Copyright 2025 Marc-Antoine Ruel. All rights reserved.
+// Use of this source code is governed under the Apache License, Version 2.0
+// that can be found in the LICENSE file.
+
+package genaitools
+
+import (
+	"errors"
+	"fmt"
+	"io/fs"
+	"os"
+	"path/filepath"
+	"strings"
+)
+
+// resolveInRoot resolves the relative path p inside root and returns the
+// absolute path.
+//
+// It rejects paths escaping root, either lexically via ".." or via symlinks.
+// The path doesn't need to exist; in this case the deepest existing parent is
+// checked instead. Dangling symlinks are rejected.
+func resolveInRoot(root, p string) (string, error) {
+	if root == "" {
+		return "", errors.New("no root directory configured")
+	}
+	rootAbs, err := filepath.Abs(root)
+	if err != nil {
+		return "", err
+	}
+	rootReal, err := filepath.EvalSymlinks(rootAbs)
+	if err != nil {
+		return "", fmt.Errorf("invalid root directory: %w", err)
+	}
+	if filepath.IsAbs(p) || filepath.VolumeName(p) != "" {
+		return "", fmt.Errorf("path %q must be relative to the root directory", p)
+	}
+	full := filepath.Join(rootReal, p)
+	if !isInDir(rootReal, full) {
+		return "", fmt.Errorf("path %q escapes the root directory", p)
+	}
+	// Find the deepest existing ancestor and make sure symlinks do not point
+	// outside of root.
+	existing, rest := full, ""
+	for {
+		real, err := filepath.EvalSymlinks(existing)
+		if err == nil {
+			if !isInDir(rootReal, real) {
+				return "", fmt.Errorf("path %q escapes the root directory via a symlink", p)
+			}
+			return filepath.Join(real, rest), nil
+		}
+		if !errors.Is(err, fs.ErrNotExist) {
+			return "", err
+		}
+		if _, err := os.Lstat(existing); err == nil {
+			// A dangling symlink; creating the file would follow it wherever it
+			// points to.
+			return "", fmt.Errorf("path %q goes through a dangling symlink", p)
+		}
+		parent := filepath.Dir(existing)
+		if parent == existing {
+			return "", err
+		}
+		rest = filepath.Join(filepath.Base(existing), rest)
+		existing = parent
+	}
+}
+
+// isInDir returns true if p is dir or inside dir. Both must be clean absolute
+// paths.
+func isInDir(dir, p string) bool {
+	rel, err := filepath.Rel(dir, p)
+	return err == nil && rel != ".." && !strings.HasPrefix(rel, ".."+string(filepath.Separator)) && !filepath.IsAbs(rel)
+}
+
+// statInRoot is a convenience wrapper around resolveInRoot and os.Stat.
+func statInRoot(root, p string) (string, os.FileInfo, error) {
+	full, err := resolveInRoot(root, p)
+	if err != nil {
+		return "", nil, err
+	}
+	fi, err := os.Stat(full)
+	if err != nil {
+		return "", nil, fmt.Errorf("path %q: %w", p, errors.Unwrap(err))
+	}
+	return full, fi, nil
+}
@@ -0,0 +1,149 @@
+// Copyright 2025 Marc-Antoine Ruel. All rights reserved.
+// Use of this source code is governed under the Apache License, Version 2.0
+// that can be found in the LICENSE file.
+
+package genaitools
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"math"
+	"slices"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/maruel/genai"
+)
+
+// GapAnalysis computes the gaps between consecutive timestamps and summarizes
+// them, e.g. to find outages in an event stream.
+//
+// Timestamps can be RFC3339, RFC1123, "YYYY-MM-DD HH:MM:SS" (UTC),
+// "YYYY-MM-DD" or Unix seconds. Unsorted input is rejected unless sorting is
+// requested. Durations are returned in Go format like "1h30m0s".
+var GapAnalysis = genai.ToolDef{
+	Name:        "gap_analysis",
+	Description: "Computes the gaps between consecutive timestamps, e.g. of log entries, and returns a JSON summary with the minimum, maximum, mean and median gap, the location of the largest gap and optionally every gap longer than a threshold. Useful to find outages.",
+	Callback:    doGapAnalysis,
+}
+
+type gapAnalysisArgs struct {
+	Timestamps []string `json:"timestamps" jsonschema:"description=Timestamps in RFC3339\\, RFC1123\\, YYYY-MM-DD HH:MM:SS or YYYY-MM-DD format or as Unix seconds"`
+	Sort       bool     `json:"sort,omitempty" jsonschema:"description=Sort the timestamps first instead of rejecting unsorted input"`
+	Threshold  string   `json:"threshold,omitempty" jsonschema:"description=Report every gap longer than this duration like 5m or 1h30m"`
+}
+
+type gap struct {
+	Index    int    `json:"index"`
+	From     string `json:"from"`
+	To       string `json:"to"`
+	Duration string `json:"duration"`
+}
+
+type gapAnalysisResult struct {
+	Count      int    `json:"count"`
+	Start      string `json:"start"`
+	End        string `json:"end"`
+	Span       string `json:"span"`
+	MinGap     string `json:"min_gap"`
+	MaxGap     string `json:"max_gap"`
+	MeanGap    string `json:"mean_gap"`
+	MedianGap  string `json:"median_gap"`
+	LargestGap gap    `json:"largest_gap"`
+	// Gaps is only set when a threshold is specified.
+	Gaps *[]gap `json:"gaps_over_threshold,omitempty"`
+}
+
+// gapLayouts are the layouts tried in order by parseGapTimestamp.
+var gapLayouts = []string{
+	time.RFC3339,
+	time.RFC1123Z,
+	time.RFC1123,
+	"2006-01-02T15:04:05",
+	time.DateTime,
+	time.DateOnly,
+}
+
+func doGapAnalysis(ctx context.Context, args *gapAnalysisArgs) (string, error) {
+	if len(args.Timestamps) < 2 {
+		return "", errors.New("at least two timestamps are required")
+	}
+	var threshold time.Duration
+	if args.Threshold != "" {
+		var err error
+		if threshold, err = time.ParseDuration(args.Threshold); err != nil || threshold < 0 {
+			return "", fmt.Errorf("invalid threshold %q; expected a duration like 5m or 1h30m", args.Threshold)
+		}
+	}
+	type entry struct {
+		t   time.Time
+		raw string
+	}
+	entries := make([]entry, len(args.Timestamps))
+	for i, s := range args.Timestamps {
+		t, err := parseGapTimestamp(s)
+		if err != nil {
+			return "", fmt.Errorf("timestamp %d: %w", i, err)
+		}
+		entries[i] = entry{t, strings.TrimSpace(s)}
+	}
+	if args.Sort {
+		slices.SortStableFunc(entries, func(a, b entry) int { return a.t.Compare(b.t) })
+	}
+	res := gapAnalysisResult{Count: len(entries), Start: entries[0].raw, End: entries[len(entries)-1].raw}
+	gaps := make([]time.Duration, len(entries)-1)
+	var over []gap
+	for i := range gaps {
+		a, b := entries[i], entries[i+1]
+		if b.t.Before(a.t) {
+			return "", fmt.Errorf("timestamp %d %q is before timestamp %d %q; sort them first or set sort", i+1, b.raw, i, a.raw)
+		}
+		gaps[i] = b.t.Sub(a.t)
+		g := gap{Index: i, From: a.raw, To: b.raw, Duration: gaps[i].String()}
+		if i == 0 || gaps[i] > gaps[res.LargestGap.Index] {
+			res.LargestGap = g
+		}
+		if args.Threshold != "" && gaps[i] > threshold {
+			over = append(over, g)
+		}
+	}
+	if args.Threshold != "" {
+		if over == nil {
+			over = []gap{}
+		}
+		res.Gaps = &over
+	}
+	res.Span = entries[len(entries)-1].t.Sub(entries[0].t).String()
+	sorted := slices.Clone(gaps)
+	slices.Sort(sorted)
+	res.MinGap = sorted[0].String()
+	res.MaxGap = sorted[len(sorted)-1].String()
+	// Compute the mean from the span to avoid overflowing a sum of durations.
+	res.MeanGap = (entries[len(entries)-1].t.Sub(entries[0].t) / time.Duration(len(gaps))).String()
+	if n := len(sorted); n%2 == 1 {
+		res.MedianGap = sorted[n/2].String()
+	} else {
+		res.MedianGap = (sorted[n/2-1] + (sorted[n/2]-sorted[n/2-1])/2).String()
+	}
+	b, err := json.Marshal(res)
+	return string(b), err
+}
+
+// parseGapTimestamp parses s with one of gapLayouts or as Unix seconds.
+// Timestamps without a time zone are in UTC.
+func parseGapTimestamp(s string) (time.Time, error) {
+	s = strings.TrimSpace(s)
+	for _, l := range gapLayouts {
+		if t, err := time.Parse(l, s); err == nil {
+			return t, nil
+		}
+	}
+	if f, err := strconv.ParseFloat(s, 64); err == nil && !math.IsInf(f, 0) && !math.IsNaN(f) && math.Abs(f) < 1e11 {
+		sec, frac := math.Modf(f)
+		return time.Unix(int64(sec), int64(math.Round(frac*1e9))).UTC(), nil
+	}
+	return time.Time{}, fmt.Errorf("invalid timestamp %q; expected RFC3339, RFC1123, YYYY-MM-DD HH:MM:SS, YYYY-MM-DD or Unix seconds", s)
+}
@@ -0,0 +1,61 @@
+// Copyright 2025 Marc-Antoine Ruel. All rights reserved.
+// Use of this source code is governed under the Apache License, Version 2.0
+// that can be found in the LICENSE file.
+
+package genaitools
+
+import (
+	"context"
+	"strings"
+	"testing"
+)
+
+func TestGapAnalysis(t *testing.T) {
+	callback := GapAnalysis.Callback.(func(context.Context, *gapAnalysisArgs) (string, error))
+	tests := []struct {
+		name      string
+		args      gapAnalysisArgs
+		expected  string
+		errSubstr string
+	}{
+		{
+			"outage",
+			gapAnalysisArgs{Timestamps: []string{"2025-01-01T00:00:00Z", "2025-01-01T00:01:00Z", "2025-01-01T00:02:00Z", "2025-01-01T00:32:00Z", "2025-01-01T00:33:00Z"}, Threshold: "5m"},
+			`{"count":5,"start":"2025-01-01T00:00:00Z","end":"2025-01-01T00:33:00Z","span":"33m0s","min_gap":"1m0s","max_gap":"30m0s","mean_gap":"8m15s","median_gap":"1m0s","largest_gap":{"index":2,"from":"2025-01-01T00:02:00Z","to":"2025-01-01T00:32:00Z","duration":"30m0s"},"gaps_over_threshold":[{"index":2,"from":"2025-01-01T00:02:00Z","to":"2025-01-01T00:32:00Z","duration":"30m0s"}]}`,
+			"",
+		},
+		{
+			"mixed_layouts",
+			gapAnalysisArgs{Timestamps: []string{"2025-01-01 00:00:00", "2025-01-01T01:00:00+01:00", "1735689630", "Wed, 01 Jan 2025 00:01:00 UTC"}},
+			`{"count":4,"start":"2025-01-01 00:00:00","end":"Wed, 01 Jan 2025 00:01:00 UTC","span":"1m0s","min_gap":"0s","max_gap":"30s","mean_gap":"20s","median_gap":"30s","largest_gap":{"index":1,"from":"2025-01-01T01:00:00+01:00","to":"1735689630","duration":"30s"}}`,
+			"",
+		},
+		{
+			"sort",
+			gapAnalysisArgs{Timestamps: []string{"2025-01-03", "2025-01-01", "2025-01-02"}, Sort: true, Threshold: "48h"},
+			`{"count":3,"start":"2025-01-01","end":"2025-01-03","span":"48h0m0s","min_gap":"24h0m0s","max_gap":"24h0m0s","mean_gap":"24h0m0s","median_gap":"24h0m0s","largest_gap":{"index":0,"from":"2025-01-01","to":"2025-01-02","duration":"24h0m0s"},"gaps_over_threshold":[]}`,
+			"",
+		},
+		{"unsorted", gapAnalysisArgs{Timestamps: []string{"2025-01-02", "2025-01-01"}}, "", `timestamp 1 "2025-01-01" is before timestamp 0 "2025-01-02"; sort them first or set sort`},
+		{"one", gapAnalysisArgs{Timestamps: []string{"2025-01-01"}}, "", "at least two timestamps are required"},
+		{"bad_timestamp", gapAnalysisArgs{Timestamps: []string{"2025-01-01", "yesterday"}}, "", `timestamp 1: invalid timestamp "yesterday"`},
+		{"bad_threshold", gapAnalysisArgs{Timestamps: []string{"2025-01-01", "2025-01-02"}, Threshold: "5 minutes"}, "", `invalid threshold "5 minutes"`},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := callback(t.Context(), &tt.args)
+			if tt.errSubstr != "" {
+				if err == nil || !strings.Contains(err.Error(), tt.errSubstr) {
+					t.Fatalf("Expected error containing %q but got %v", tt.errSubstr, err)
+				}
+				return
+			}
+			if err != nil {
+				t.Fatalf("Unexpected error: %v", err)
+			}
+			if got != tt.expected {
+				t.Fatalf("Expected %q but got %q", tt.expected, got)
+			}
+		})
+	}
+}
@@ -10,7 +10,9 @@ import (
 	"encoding/json"
 	"fmt"
 	"math"
+	"math/big"
 	"strconv"
+	"strings"
 	"time"
 
 	"github.com/maruel/genai"
@@ -18,9 +20,11 @@ import (
 
 // Arithmetic executes the arithmetic operation over two numbers.
 //
-// It first tries to do the calculation using int64, then using float64.
+// It first tries to do the calculation using integers, then using float64.
+// Addition, subtraction and multiplication of integers are exact for any size.
 //
-// The supported operations are "addition", "subtraction", "multiplication" and "division".
+// The supported operations are "addition", "subtraction", "multiplication",
+// "division", "modulo", "power" and "sqrt". "sqrt" ignores the second number.
 var Arithmetic = genai.ToolDef{
 	Name:        "arithmetic",
 	Description: "Calculates a mathematical arithmetic operation with two numbers and returns the result.",
@@ -28,24 +32,52 @@ var Arithmetic = genai.ToolDef{
 }
 
 type calculateArgs struct {
-	Operation    string      `json:"operation" jsonschema:"enum=addition,enum=subtraction,enum=multiplication,enum=division"`
+	Operation    string      `json:"operation" jsonschema:"enum=addition,enum=subtraction,enum=multiplication,enum=division,enum=modulo,enum=power,enum=sqrt"`
 	FirstNumber  json.Number `json:"first_number" jsonschema:"type=number"`
-	SecondNumber json.Number `json:"second_number" jsonschema:"type=number"`
+	SecondNumber json.Number `json:"second_number,omitempty" jsonschema:"type=number"`
 }
 
 func doArithmetic(ctx context.Context, args *calculateArgs) (string, error) {
-	if i1, err := args.FirstNumber.Int64(); err == nil {
-		if i2, err := args.SecondNumber.Int64(); err == nil {
+	if args.Operation == "sqrt" {
+		n1, err := args.FirstNumber.Float64()
+		if err != nil {
+			return "", fmt.Errorf("couldn't understand the first number: %w", err)
+		}
+		if n1 < 0 {
+			return "", fmt.Errorf("cannot take the square root of negative number %s", args.FirstNumber)
+		}
+		return formatFloat(math.Sqrt(n1)), nil
+	}
+	if args.Operation == "division" || args.Operation == "modulo" {
+		// Check early: the int64 path would panic and the float64 path would
+		// return Inf or NaN.
+		if n2, err := args.SecondNumber.Float64(); err == nil && n2 == 0 {
+			return "", errDivideByZero
+		}
+	}
+	// Use math/big so results beyond int64 are exact instead of silently
+	// overflowing.
+	if b1, ok := new(big.Int).SetString(string(args.FirstNumber), 10); ok {
+		if b2, ok := new(big.Int).SetString(string(args.SecondNumber), 10); ok {
 			switch args.Operation {
 			case "addition":
-				return strconv.FormatInt(i1+i2, 10), nil
+				return b1.Add(b1, b2).String(), nil
 			case "subtraction":
-				return strconv.FormatInt(i1-i2, 10), nil
+				return b1.Sub(b1, b2).String(), nil
 			case "multiplication":
-				return strconv.FormatInt(i1*i2, 10), nil
+				return b1.Mul(b1, b2).String(), nil
 			case "division":
-				if i1%i2 == 0 {
-					return strconv.FormatInt(i1/i2, 10), nil
+				if q, r := new(big.Int).QuoRem(b1, b2, new(big.Int)); r.Sign() == 0 {
+					return q.String(), nil
+				}
+				// Otherwise fall back as float.
+			case "modulo":
+				return b1.Rem(b1, b2).String(), nil
+			case "power":
+				if b1.IsInt64() && b2.IsInt64() {
+					if r, ok := powInt64(b1.Int64(), b2.Int64()); ok {
+						return strconv.FormatInt(r, 10), nil
+					}
 				}
 				// Otherwise fall back as float.
 			default:
@@ -71,27 +103,94 @@ func doArithmetic(ctx context.Context, args *calculateArgs) (string, error) {
 		r = n1 * n2
 	case "division":
 		r = n1 / n2
+	case "modulo":
+		r = math.Mod(n1, n2)
+	case "power":
+		r = math.Pow(n1, n2)
 	default:
 		return "", fmt.Errorf("unknown operation %q", args.Operation)
 	}
+	return formatFloat(r), nil
+}
+
+// powInt64 returns base**exp. It returns false if exp is negative or the
+// result overflows int64.
+func powInt64(base, exp int64) (int64, bool) {
+	if exp < 0 {
+		return 0, false
+	}
+	switch base {
+	case 0:
+		if exp == 0 {
+			return 1, true
+		}
+		return 0, true
+	case 1:
+		return 1, true
+	case -1:
+		if exp%2 == 0 {
+			return 1, true
+		}
+		return -1, true
+	}
+	// |base| >= 2 so this overflows in less than 64 iterations.
+	r := int64(1)
+	for range exp {
+		p := r * base
+		if p/base != r {
+			return 0, false
+		}
+		r = p
+	}
+	return r, true
+}
+
+// formatFloat formats a float64 in a way that the LLM understands.
+func formatFloat(r float64) string {
 	// Do not use %g all the time because it tends to use exponents too quickly
 	// and the LLM is super confused about that.
 	// Do not use naive %f all the time because the LLM gets confused with
 	// decimals.
 	if r == math.Trunc(r) {
-		return fmt.Sprintf("%.0f", r), nil
+		return fmt.Sprintf("%.0f", r)
+	}
+	return fmt.Sprintf("%f", r)
+}
+
+// marshalJSON returns v as JSON without escaping HTML characters.
+func marshalJSON(v any) (string, error) {
+	var b strings.Builder
+	e := json.NewEncoder(&b)
+	e.SetEscapeHTML(false)
+	if err := e.Encode(v); err != nil {
+		return "", err
 	}
-	return fmt.Sprintf("%f", r), nil
+	return strings.TrimSuffix(b.String(), "\n"), nil
 }
 
 // GetTodayClockTime returns the current time and day in a format that the LLM
-// can understand. It includes the weekday.
+// can understand. It includes the weekday and the time zone abbreviation.
+//
+// The time zone defaults to the local one.
 var GetTodayClockTime = genai.ToolDef{
 	Name:        "today_date_current_clock_time",
 	Description: "Provides the current clock time and today's date.",
-	Callback: func(ctx context.Context, e *empty) (string, error) {
-		return time.Now().Format("Monday 2006-01-02 15:04"), nil
+	Callback: func(ctx context.Context, args *clockTimeArgs) (string, error) {
+		return doGetTodayClockTime(args, time.Now())
 	},
 }
 
-type empty struct{}
+type clockTimeArgs struct {
+	Timezone string `json:"timezone,omitempty" jsonschema:"description=IANA time zone like America/New_York. Defaults to the local time zone"`
+}
+
+func doGetTodayClockTime(args *clockTimeArgs, now time.Time) (string, error) {
+	if args.Timezone != "" {
+		loc, err := time.LoadLocation(args.Timezone)
+		if err != nil {
+			return "", fmt.Errorf("invalid timezone %q: %w", args.Timezone, err)
+		}
+		now = now.In(loc)
+	}
+	return now.Format("Monday 2006-01-02 15:04:05 MST"), nil
+}
@@ -38,12 +38,31 @@ func TestArithmetic(t *testing.T) {
 			{"division_int_exact", "division", "10", "2", "5", false, ""},
 			{"division_int_to_float", "division", "10", "3", "3.333333", false, ""},
 			{"large_int", "addition", "922337203685477580", "1", "922337203685477581", false, ""},
+			{"overflow_multiplication", "multiplication", "922337203685477580", "1000", "922337203685477580000", false, ""},
+			{"overflow_addition", "addition", "9223372036854775807", "1", "9223372036854775808", false, ""},
+			{"overflow_subtraction", "subtraction", "-9223372036854775808", "1", "-9223372036854775809", false, ""},
+			{"big_operands", "multiplication", "123456789012345678901234567890", "-987654321098765432109876543210", "-121932631137021795226185032733622923332237463801111263526900", false, ""},
+			{"big_addition", "addition", "99999999999999999999999999999999", "1", "100000000000000000000000000000000", false, ""},
+			{"big_division_exact", "division", "100000000000000000000000000000000", "4", "25000000000000000000000000000000", false, ""},
+			{"big_modulo", "modulo", "100000000000000000000000000000001", "7", "3", false, ""},
+			{"modulo_int", "modulo", "17", "5", "2", false, ""},
+			{"modulo_negative_int", "modulo", "-17", "5", "-2", false, ""},
+			{"power_int", "power", "2", "10", "1024", false, ""},
+			{"power_int_zero", "power", "7", "0", "1", false, ""},
+			{"power_int_negative_base", "power", "-3", "3", "-27", false, ""},
+			{"power_int_minus_one", "power", "-1", "1000000000001", "-1", false, ""},
+			{"power_int_overflow", "power", "2", "64", "18446744073709551616", false, ""},
+			{"power_negative_exponent", "power", "2", "-2", "0.25", false, ""},
+			{"sqrt_int", "sqrt", "16", "", "4", false, ""},
 
 			// Float operations
 			{"addition_float", "addition", "3.5", "2.1", "5.600000", false, ""},
 			{"subtraction_float", "subtraction", "7.5", "2.5", "5.000000", false, ""},
 			{"multiplication_float", "multiplication", "2.5", "4.0", "10", false, ""},
 			{"division_float", "division", "10.5", "2.1", "5.000000", false, ""},
+			{"modulo_float", "modulo", "7.5", "2", "1.500000", false, ""},
+			{"power_float", "power", "4", "0.5", "2", false, ""},
+			{"sqrt_float", "sqrt", "2", "", "1.414214", false, ""},
 
 			// Mixed integer and float
 			{"mixed_types", "addition", "5", "3.5", "8.500000", false, ""},
@@ -52,6 +71,12 @@ func TestArithmetic(t *testing.T) {
 			{"invalid_operation", "unknown", "5", "3", "", true, "unknown operation"},
 			{"invalid_first_number", "addition", "not_a_number", "3", "", true, "couldn't understand the first number"},
 			{"invalid_second_number", "addition", "5", "not_a_number", "", true, "couldn't understand the second number"},
+			{"sqrt_negative", "sqrt", "-4", "", "", true, "cannot take the square root of negative number -4"},
+			{"division_by_zero_int", "division", "10", "0", "", true, "cannot divide by zero"},
+			{"division_by_zero_float", "division", "10.5", "0.0", "", true, "cannot divide by zero"},
+			{"division_by_negative_zero_float", "division", "1.5", "-0.0", "", true, "cannot divide by zero"},
+			{"modulo_by_zero_int", "modulo", "17", "0", "", true, "cannot divide by zero"},
+			{"modulo_by_zero_float", "modulo", "7.5", "0", "", true, "cannot divide by zero"},
 		}
 
 		for _, tt := range tests {
@@ -134,15 +159,16 @@ func TestGetTodayClockTime(t *testing.T) {
 	ctx := t.Context()
 	before := time.Now()
 
-	// Call the callback directly with an empty struct
-	callback := GetTodayClockTime.Callback.(func(context.Context, *empty) (string, error))
-	result, err := callback(ctx, &empty{})
+	// Call the callback directly with no time zone.
+	callback := GetTodayClockTime.Callback.(func(context.Context, *clockTimeArgs) (string, error))
+	result, err := callback(ctx, &clockTimeArgs{})
 	if err != nil {
 		t.Fatalf("Unexpected error: %v", err)
 	}
 
-	// Verify the format follows "Monday 2006-01-02 15:04"
-	expectedPattern := `^(Monday|Tuesday|Wednesday|Thursday|Friday|Saturday|Sunday) [0-9]{4}-[0-9]{2}-[0-9]{2} [0-9]{2}:[0-9]{2}$`
+	// Verify the format follows "Monday 2006-01-02 15:04:05 MST"; seconds must
+	// be present.
+	expectedPattern := `^(Monday|Tuesday|Wednesday|Thursday|Friday|Saturday|Sunday) [0-9]{4}-[0-9]{2}-[0-9]{2} [0-9]{2}:[0-9]{2}:[0-9]{2} [-+A-Za-z0-9]+$`
 	matched, err := regexp.MatchString(expectedPattern, result)
 	if err != nil {
 		t.Fatalf("Regex error: %v", err)
@@ -151,15 +177,45 @@ func TestGetTodayClockTime(t *testing.T) {
 		t.Fatalf("Time format doesn't match expected pattern. Got: %q", result)
 	}
 
-	// Verify the time is within a reasonable range (last minute)
-	parsedTime, err := time.ParseInLocation("Monday 2006-01-02 15:04", result, time.Local)
+	// Verify the time is within a reasonable range, with seconds precision.
+	parsedTime, err := time.ParseInLocation("Monday 2006-01-02 15:04:05", result[:strings.LastIndexByte(result, ' ')], time.Local)
 	if err != nil {
 		t.Fatalf("Failed to parse time %q: %v", result, err)
 	}
-	if diff := parsedTime.Sub(before); diff > time.Minute {
-		t.Fatalf("Time is too old: %v - %v = %s", parsedTime, before, diff)
+	if parsedTime.Before(before.Truncate(time.Second)) {
+		t.Fatalf("Time is too old: %v < %v", parsedTime, before)
 	}
-	if parsedTime.After(before.Add(time.Minute)) {
+	if parsedTime.After(time.Now()) {
 		t.Fatalf("Time is in the future: %v", parsedTime)
 	}
+
+	now := time.Date(2025, 7, 4, 16, 30, 45, 0, time.UTC)
+	tests := []struct {
+		name      string
+		timezone  string
+		expected  string
+		errSubstr string
+	}{
+		{"new_york", "America/New_York", "Friday 2025-07-04 12:30:45 EDT", ""},
+		{"tokyo_next_day", "Asia/Tokyo", "Saturday 2025-07-05 01:30:45 JST", ""},
+		{"empty", "", "Friday 2025-07-04 16:30:45 UTC", ""},
+		{"invalid", "Mars/Olympus_Mons", "", `invalid timezone "Mars/Olympus_Mons"`},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := doGetTodayClockTime(&clockTimeArgs{Timezone: tt.timezone}, now)
+			if tt.errSubstr != "" {
+				if err == nil || !strings.Contains(err.Error(), tt.errSubstr) {
+					t.Fatalf("Expected error containing %q but got %v", tt.errSubstr, err)
+				}
+				return
+			}
+			if err != nil {
+				t.Fatalf("Unexpected error: %v", err)
+			}
+			if got != tt.expected {
+				t.Fatalf("Expected %q but got %q", tt.expected, got)
+			}
+		})
+	}
 }
@@ -0,0 +1,166 @@
+// Copyright 2025 Marc-Antoine Ruel. All rights reserved.
+// Use of this source code is governed under the Apache License, Version 2.0
+// that can be found in the LICENSE file.
+
+package genaitools
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"math"
+
+	"github.com/maruel/genai"
+)
+
+// earthRadiusKm is the mean radius of the Earth in kilometers.
+const earthRadiusKm = 6371.0088
+
+// GeoBearing computes great-circle navigation on a spherical Earth.
+//
+// The "bearing" operation returns the initial and final bearings from the start
+// to the destination. The "destination" operation returns the point reached by
+// traveling from the start along a great circle with an initial bearing for a
+// distance. Bearings are in degrees clockwise from true north.
+var GeoBearing = genai.ToolDef{
+	Name:        "geo_bearing",
+	Description: "Computes the great-circle initial bearing between two latitude/longitude coordinates, or the destination point given a start, an initial bearing and a distance in km. Returns JSON.",
+	Callback:    doGeoBearing,
+}
+
+type geoBearingArgs struct {
+	Operation   string   `json:"operation" jsonschema:"enum=bearing,enum=destination"`
+	Latitude    float64  `json:"latitude" jsonschema:"description=Latitude of the start in decimal degrees"`
+	Longitude   float64  `json:"longitude" jsonschema:"description=Longitude of the start in decimal degrees"`
+	ToLatitude  *float64 `json:"to_latitude,omitempty" jsonschema:"description=Latitude of the destination for the bearing operation"`
+	ToLongitude *float64 `json:"to_longitude,omitempty" jsonschema:"description=Longitude of the destination for the bearing operation"`
+	Bearing     *float64 `json:"bearing,omitempty" jsonschema:"description=Initial bearing in degrees clockwise from north for the destination operation"`
+	DistanceKm  *float64 `json:"distance_km,omitempty" jsonschema:"description=Distance in kilometers for the destination operation"`
+}
+
+type geoBearingResult struct {
+	InitialBearing *float64 `json:"initial_bearing,omitempty"`
+	FinalBearing   float64  `json:"final_bearing"`
+	Compass        string   `json:"compass,omitempty"`
+	Latitude       *float64 `json:"latitude,omitempty"`
+	Longitude      *float64 `json:"longitude,omitempty"`
+}
+
+func doGeoBearing(ctx context.Context, args *geoBearingArgs) (string, error) {
+	if err := checkCoordinates("", args.Latitude, args.Longitude); err != nil {
+		return "", err
+	}
+	var res geoBearingResult
+	switch args.Operation {
+	case "bearing":
+		if args.ToLatitude == nil || args.ToLongitude == nil {
+			return "", errors.New("to_latitude and to_longitude are required for the bearing operation")
+		}
+		if err := checkCoordinates("to_", *args.ToLatitude, *args.ToLongitude); err != nil {
+			return "", err
+		}
+		if *args.ToLatitude == args.Latitude && *args.ToLongitude == args.Longitude {
+			return "", errors.New("the points are identical; the bearing is undefined")
+		}
+		b := roundBearing(initialBearing(args.Latitude, args.Longitude, *args.ToLatitude, *args.ToLongitude))
+		res.InitialBearing = &b
+		res.Compass = compassPoint(b)
+		res.FinalBearing = roundBearing(initialBearing(*args.ToLatitude, *args.ToLongitude, args.Latitude, args.Longitude) + 180)
+	case "destination":
+		if args.Bearing == nil || args.DistanceKm == nil {
+			return "", errors.New("bearing and distance_km are required for the destination operation")
+		}
+		if math.IsNaN(*args.Bearing) || math.IsInf(*args.Bearing, 0) {
+			return "", fmt.Errorf("invalid bearing %v", *args.Bearing)
+		}
+		if !(*args.DistanceKm >= 0) || math.IsInf(*args.DistanceKm, 0) {
+			return "", fmt.Errorf("invalid distance_km %v; it must be a non-negative number", *args.DistanceKm)
+		}
+		lat, lon := destinationPoint(args.Latitude, args.Longitude, *args.Bearing, *args.DistanceKm)
+		lat, lon = roundCoord(lat), roundCoord(lon)
+		res.Latitude, res.Longitude = &lat, &lon
+		if lat == args.Latitude && lon == args.Longitude {
+			res.FinalBearing = roundBearing(*args.Bearing)
+		} else {
+			res.FinalBearing = roundBearing(initialBearing(lat, lon, args.Latitude, args.Longitude) + 180)
+		}
+	default:
+		return "", fmt.Errorf("unknown operation %q", args.Operation)
+	}
+	b, _ := json.Marshal(res)
+	return string(b), nil
+}
+
+// checkCoordinates returns an error if the coordinates are out of range.
+func checkCoordinates(prefix string, lat, lon float64) error {
+	if !(lat >= -90 && lat <= 90) {
+		return fmt.Errorf("invalid %slatitude %v; it must be between -90 and 90", prefix, lat)
+	}
+	if !(lon >= -180 && lon <= 180) {
+		return fmt.Errorf("invalid %slongitude %v; it must be between -180 and 180", prefix, lon)
+	}
+	return nil
+}
+
+// initialBearing returns the initial bearing in degrees in [0, 360) of the
+// great circle from the first point to the second one.
+func initialBearing(lat1, lon1, lat2, lon2 float64) float64 {
+	φ1, φ2 := radians(lat1), radians(lat2)
+	Δλ := radians(lon2 - lon1)
+	y := math.Sin(Δλ) * math.Cos(φ2)
+	x := math.Cos(φ1)*math.Sin(φ2) - math.Sin(φ1)*math.Cos(φ2)*math.Cos(Δλ)
+	return normalizeBearing(degrees(math.Atan2(y, x)))
+}
+
+// destinationPoint returns the point reached from the start after traveling
+// distanceKm along the great circle with the initial bearing.
+func destinationPoint(lat, lon, bearing, distanceKm float64) (float64, float64) {
+	φ1, λ1, θ := radians(lat), radians(lon), radians(bearing)
+	δ := distanceKm / earthRadiusKm
+	φ2 := math.Asin(math.Sin(φ1)*math.Cos(δ) + math.Cos(φ1)*math.Sin(δ)*math.Cos(θ))
+	λ2 := λ1 + math.Atan2(math.Sin(θ)*math.Sin(δ)*math.Cos(φ1), math.Cos(δ)-math.Sin(φ1)*math.Sin(φ2))
+	// Normalize to [-180, 180).
+	lon2 := math.Mod(degrees(λ2)+540, 360) - 180
+	return degrees(φ2), lon2
+}
+
+func normalizeBearing(b float64) float64 {
+	b = math.Mod(b, 360)
+	if b < 0 {
+		b += 360
+	}
+	return b
+}
+
+// compassPoint returns the 16-wind compass point of a bearing, e.g. "NNE".
+func compassPoint(b float64) string {
+	points := [...]string{"N", "NNE", "NE", "ENE", "E", "ESE", "SE", "SSE", "S", "SSW", "SW", "WSW", "W", "WNW", "NW", "NNW"}
+	return points[int(math.Round(b/22.5))%16]
+}
+
+func radians(d float64) float64 {
+	return d * math.Pi / 180
+}
+
+func degrees(r float64) float64 {
+	return r * 180 / math.Pi
+}
+
+// roundBearing normalizes a bearing to [0, 360) rounded to 6 decimals.
+func roundBearing(b float64) float64 {
+	if b = roundCoord(normalizeBearing(b)); b == 360 {
+		return 0
+	}
+	return b
+}
+
+// roundCoord rounds to 6 decimals, about 10cm at the equator.
+func roundCoord(v float64) float64 {
+	v = math.Round(v*1e6) / 1e6
+	if v == 0 {
+		// Avoid -0.
+		return 0
+	}
+	return v
+}
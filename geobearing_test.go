@@ -0,0 +1,85 @@
+// Copyright 2025 Marc-Antoine Ruel. All rights reserved.
+// Use of this source code is governed under the Apache License, Version 2.0
+// that can be found in the LICENSE file.
+
+package genaitools
+
+import (
+	"context"
+	"math"
+	"strings"
+	"testing"
+)
+
+func TestGeoBearing(t *testing.T) {
+	callback := GeoBearing.Callback.(func(context.Context, *geoBearingArgs) (string, error))
+	f := func(v float64) *float64 { return &v }
+	tests := []struct {
+		name      string
+		args      geoBearingArgs
+		expected  string
+		errSubstr string
+	}{
+		{
+			"london_paris",
+			geoBearingArgs{Operation: "bearing", Latitude: 51.5074, Longitude: -0.1278, ToLatitude: f(48.8566), ToLongitude: f(2.3522)},
+			`{"initial_bearing":148.115617,"final_bearing":150.021093,"compass":"SSE"}`,
+			"",
+		},
+		{
+			"baghdad_osaka",
+			geoBearingArgs{Operation: "bearing", Latitude: 35, Longitude: 45, ToLatitude: f(35), ToLongitude: f(135)},
+			`{"initial_bearing":60.162434,"final_bearing":119.837566,"compass":"ENE"}`,
+			"",
+		},
+		{
+			"due_south",
+			geoBearingArgs{Operation: "bearing", Latitude: 10, Longitude: 0, ToLatitude: f(-10), ToLongitude: f(0)},
+			`{"initial_bearing":180,"final_bearing":180,"compass":"S"}`,
+			"",
+		},
+		{
+			"destination",
+			geoBearingArgs{Operation: "destination", Latitude: 51.5074, Longitude: -0.1278, Bearing: f(148.1), DistanceKm: f(343.5)},
+			`{"final_bearing":150.00604,"latitude":48.857461,"longitude":2.352922}`,
+			"",
+		},
+		{
+			"antimeridian",
+			geoBearingArgs{Operation: "destination", Latitude: 0, Longitude: 179, Bearing: f(90), DistanceKm: f(222.39)},
+			`{"final_bearing":90,"latitude":0,"longitude":-179.000001}`,
+			"",
+		},
+		{
+			"zero_distance",
+			geoBearingArgs{Operation: "destination", Latitude: 1, Longitude: 2, Bearing: f(-90), DistanceKm: f(0)},
+			`{"final_bearing":270,"latitude":1,"longitude":2}`,
+			"",
+		},
+		{"identical", geoBearingArgs{Operation: "bearing", Latitude: 1, Longitude: 2, ToLatitude: f(1), ToLongitude: f(2)}, "", "the points are identical"},
+		{"missing_to", geoBearingArgs{Operation: "bearing", Latitude: 1, Longitude: 2}, "", "to_latitude and to_longitude are required"},
+		{"missing_distance", geoBearingArgs{Operation: "destination", Bearing: f(1)}, "", "bearing and distance_km are required"},
+		{"bad_latitude", geoBearingArgs{Operation: "bearing", Latitude: 91}, "", "invalid latitude 91; it must be between -90 and 90"},
+		{"bad_to_longitude", geoBearingArgs{Operation: "bearing", ToLatitude: f(0), ToLongitude: f(-181)}, "", "invalid to_longitude -181"},
+		{"nan", geoBearingArgs{Operation: "bearing", Latitude: math.NaN()}, "", "invalid latitude NaN"},
+		{"negative_distance", geoBearingArgs{Operation: "destination", Bearing: f(1), DistanceKm: f(-1)}, "", "invalid distance_km -1"},
+		{"bad_operation", geoBearingArgs{Operation: "distance"}, "", `unknown operation "distance"`},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := callback(t.Context(), &tt.args)
+			if tt.errSubstr != "" {
+				if err == nil || !strings.Contains(err.Error(), tt.errSubstr) {
+					t.Fatalf("Expected error containing %q but got %v", tt.errSubstr, err)
+				}
+				return
+			}
+			if err != nil {
+				t.Fatalf("Unexpected error: %v", err)
+			}
+			if got != tt.expected {
+				t.Fatalf("Expected %q but got %q", tt.expected, got)
+			}
+		})
+	}
+}
@@ -0,0 +1,63 @@
+// Copyright 2025 Marc-Antoine Ruel. All rights reserved.
+// Use of this source code is governed under the Apache License, Version 2.0
+// that can be found in the LICENSE file.
+
+package genaitools
+
+import (
+	"context"
+	"fmt"
+	"math"
+
+	"github.com/maruel/genai"
+)
+
+// kmPerMile is the length of the international mile in kilometers.
+const kmPerMile = 1.609344
+
+// GeoDistance computes the great-circle distance between two coordinates with
+// the haversine formula on a spherical Earth.
+//
+// The error of the spherical model is up to about 0.5% compared to the WGS84
+// ellipsoid.
+var GeoDistance = genai.ToolDef{
+	Name:        "geo_distance",
+	Description: "Computes the great-circle distance between two latitude/longitude coordinates in kilometers or miles.",
+	Callback:    doGeoDistance,
+}
+
+type geoDistanceArgs struct {
+	Lat1 float64 `json:"lat1" jsonschema:"description=Latitude of the first point in decimal degrees"`
+	Lon1 float64 `json:"lon1" jsonschema:"description=Longitude of the first point in decimal degrees"`
+	Lat2 float64 `json:"lat2" jsonschema:"description=Latitude of the second point in decimal degrees"`
+	Lon2 float64 `json:"lon2" jsonschema:"description=Longitude of the second point in decimal degrees"`
+	Unit string  `json:"unit,omitempty" jsonschema:"description=Unit of the distance. Defaults to km,enum=km,enum=mi"`
+}
+
+func doGeoDistance(ctx context.Context, args *geoDistanceArgs) (string, error) {
+	if err := checkCoordinates("", args.Lat1, args.Lon1); err != nil {
+		return "", err
+	}
+	if err := checkCoordinates("", args.Lat2, args.Lon2); err != nil {
+		return "", err
+	}
+	d := haversineKm(args.Lat1, args.Lon1, args.Lat2, args.Lon2)
+	switch args.Unit {
+	case "", "km":
+		return formatFloat(d) + " km", nil
+	case "mi":
+		return formatFloat(d/kmPerMile) + " mi", nil
+	default:
+		return "", fmt.Errorf("unknown unit %q; supported units are km and mi", args.Unit)
+	}
+}
+
+// haversineKm returns the great-circle distance in kilometers between two
+// points.
+func haversineKm(lat1, lon1, lat2, lon2 float64) float64 {
+	φ1, φ2 := radians(lat1), radians(lat2)
+	Δφ, Δλ := radians(lat2-lat1), radians(lon2-lon1)
+	a := math.Sin(Δφ/2)*math.Sin(Δφ/2) + math.Cos(φ1)*math.Cos(φ2)*math.Sin(Δλ/2)*math.Sin(Δλ/2)
+	// Clamp since rounding can push a slightly above 1 for antipodal points.
+	return 2 * earthRadiusKm * math.Asin(math.Sqrt(math.Min(a, 1)))
+}
@@ -0,0 +1,76 @@
+// Copyright 2025 Marc-Antoine Ruel. All rights reserved.
+// Use of this source code is governed under the Apache License, Version 2.0
+// that can be found in the LICENSE file.
+
+package genaitools
+
+import (
+	"context"
+	"math"
+	"strconv"
+	"strings"
+	"testing"
+)
+
+func TestGeoDistance(t *testing.T) {
+	callback := GeoDistance.Callback.(func(context.Context, *geoDistanceArgs) (string, error))
+	tests := []struct {
+		name     string
+		args     geoDistanceArgs
+		expected float64
+		unit     string
+	}{
+		// Reference great-circle distances; the tolerance covers the spherical
+		// model.
+		{"paris_london", geoDistanceArgs{Lat1: 48.8566, Lon1: 2.3522, Lat2: 51.5074, Lon2: -0.1278}, 343.5, "km"},
+		{"new_york_los_angeles", geoDistanceArgs{Lat1: 40.7128, Lon1: -74.0060, Lat2: 34.0522, Lon2: -118.2437, Unit: "km"}, 3936, "km"},
+		{"new_york_los_angeles_mi", geoDistanceArgs{Lat1: 40.7128, Lon1: -74.0060, Lat2: 34.0522, Lon2: -118.2437, Unit: "mi"}, 2445.6, "mi"},
+		{"sydney_tokyo", geoDistanceArgs{Lat1: -33.8688, Lon1: 151.2093, Lat2: 35.6762, Lon2: 139.6503}, 7823, "km"},
+		{"antimeridian", geoDistanceArgs{Lat1: 0, Lon1: 179.5, Lat2: 0, Lon2: -179.5}, 111.2, "km"},
+		{"same", geoDistanceArgs{Lat1: 45, Lon1: 45, Lat2: 45, Lon2: 45}, 0, "km"},
+		{"antipodes", geoDistanceArgs{Lat1: 90, Lon1: 0, Lat2: -90, Lon2: 0}, math.Pi * earthRadiusKm, "km"},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := callback(t.Context(), &tt.args)
+			if err != nil {
+				t.Fatalf("Unexpected error: %v", err)
+			}
+			v, unit, ok := strings.Cut(got, " ")
+			if !ok || unit != tt.unit {
+				t.Fatalf("Expected unit %q but got %q", tt.unit, got)
+			}
+			f, err := strconv.ParseFloat(v, 64)
+			if err != nil {
+				t.Fatalf("Unexpected error: %v", err)
+			}
+			if math.Abs(f-tt.expected) > tt.expected*0.005+0.001 {
+				t.Fatalf("Expected about %v but got %q", tt.expected, got)
+			}
+		})
+	}
+}
+
+func TestGeoDistanceError(t *testing.T) {
+	callback := GeoDistance.Callback.(func(context.Context, *geoDistanceArgs) (string, error))
+	tests := []struct {
+		name      string
+		args      geoDistanceArgs
+		errSubstr string
+	}{
+		{"lat1", geoDistanceArgs{Lat1: 91}, "invalid latitude 91"},
+		{"lon1", geoDistanceArgs{Lon1: -180.5}, "invalid longitude -180.5"},
+		{"lat2", geoDistanceArgs{Lat2: -90.1}, "invalid latitude -90.1"},
+		{"lon2", geoDistanceArgs{Lon2: 200}, "invalid longitude 200"},
+		{"nan", geoDistanceArgs{Lat1: math.NaN()}, "invalid latitude NaN"},
+		{"unit", geoDistanceArgs{Unit: "nm"}, `unknown unit "nm"`},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			_, err := callback(t.Context(), &tt.args)
+			if err == nil || !strings.Contains(err.Error(), tt.errSubstr) {
+				t.Fatalf("Expected error containing %q but got %v", tt.errSubstr, err)
+			}
+		})
+	}
+}
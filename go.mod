@@ -3,15 +3,20 @@ module github.com/maruel/genaitools
 go 1.24.4
 
 require (
+	github.com/alecthomas/chroma/v2 v2.24.1
 	github.com/maruel/genai v0.2.0
 	github.com/maruel/roundtrippers v0.5.0
+	golang.org/x/net v0.48.0
 	golang.org/x/sys v0.39.0
+	golang.org/x/text v0.32.0
+	gopkg.in/yaml.v3 v3.0.1
 )
 
 require (
 	github.com/andybalholm/brotli v1.2.0 // indirect
 	github.com/bahlo/generic-list-go v0.2.0 // indirect
 	github.com/buger/jsonparser v1.1.1 // indirect
+	github.com/dlclark/regexp2 v1.12.0 // indirect
 	github.com/invopop/jsonschema v0.13.0 // indirect
 	github.com/klauspost/compress v1.18.2 // indirect
 	github.com/mailru/easyjson v0.9.1 // indirect
@@ -19,5 +24,4 @@ require (
 	github.com/wk8/go-ordered-map/v2 v2.1.8 // indirect
 	go.yaml.in/yaml/v4 v4.0.0-rc.3 // indirect
 	gopkg.in/dnaeon/go-vcr.v4 v4.0.6 // indirect
-	gopkg.in/yaml.v3 v3.0.1 // indirect
 )
@@ -0,0 +1,179 @@
+// Copyright 2025 Marc-Antoine Ruel. All rights reserved.
+// Use of this source code is governed under the Apache License, Version 2.0
+// that can be found in the LICENSE file.
+
+package genaitools
+
+import (
+	"bufio"
+	"bytes"
+	"context"
+	"fmt"
+	"io/fs"
+	"os"
+	"path"
+	"regexp"
+	"strings"
+
+	"github.com/maruel/genai"
+)
+
+const (
+	// maxGrepFiles is the maximum number of files searched by the grep tool.
+	maxGrepFiles = 10000
+	// maxGrepLine is the maximum number of bytes of a matching line returned
+	// by the grep tool.
+	maxGrepLine = 500
+)
+
+// NewGrep returns a tool that searches the lines matching a regular expression
+// in the files inside root.
+//
+// It returns one "path:line: text" entry per matching line, with the path
+// relative to root. Binary files and directories starting with a dot like
+// .git are skipped. Symlinks are not followed. The search stops after 100
+// matches by default and after 10000 files. Long lines are truncated. Paths
+// passed by the LLM are relative to root and cannot escape it.
+func NewGrep(root string) genai.ToolDef {
+	return genai.ToolDef{
+		Name:        "grep",
+		Description: "Searches files recursively for the lines matching a regular expression (Go RE2 syntax) and returns them as path:line: text, like grep -rn.",
+		Callback: func(ctx context.Context, args *grepArgs) (string, error) {
+			return doGrep(ctx, root, args)
+		},
+	}
+}
+
+type grepArgs struct {
+	Pattern    string `json:"pattern" jsonschema:"description=Regular expression to search for"`
+	Glob       string `json:"glob,omitempty" jsonschema:"description=Only search the files whose name matches this glob like *.go. Globs containing / match the relative path"`
+	Path       string `json:"path,omitempty" jsonschema:"description=Relative path of the directory to search. Defaults to the root"`
+	IgnoreCase bool   `json:"ignore_case,omitempty" jsonschema:"description=Match case insensitively"`
+	MaxMatches int    `json:"max_matches,omitempty" jsonschema:"description=Maximum number of matches to return. Defaults to 100"`
+}
+
+func doGrep(ctx context.Context, root string, args *grepArgs) (string, error) {
+	if len(args.Pattern) > maxRegexPattern {
+		return "", fmt.Errorf("pattern is %d bytes; the maximum is %d bytes", len(args.Pattern), maxRegexPattern)
+	}
+	expr := args.Pattern
+	if args.IgnoreCase {
+		expr = "(?i)" + expr
+	}
+	re, err := regexp.Compile(expr)
+	if err != nil {
+		return "", fmt.Errorf("invalid pattern: %w", err)
+	}
+	if _, err = path.Match(args.Glob, ""); err != nil {
+		return "", fmt.Errorf("invalid glob %q: %w", args.Glob, err)
+	}
+	maxMatches := args.MaxMatches
+	if maxMatches <= 0 {
+		maxMatches = 100
+	}
+	p := args.Path
+	if p == "" {
+		p = "."
+	}
+	dir, fi, err := statInRoot(root, p)
+	if err != nil {
+		return "", err
+	}
+	if !fi.IsDir() {
+		return "", fmt.Errorf("path %q is not a directory", args.Path)
+	}
+	prefix := path.Clean(strings.ReplaceAll(p, "\\", "/"))
+	var out []string
+	files := 0
+	stopped := ""
+	fsys := os.DirFS(dir)
+	err = fs.WalkDir(fsys, ".", func(name string, d fs.DirEntry, err error) error {
+		if err != nil {
+			return err
+		}
+		if err = ctx.Err(); err != nil {
+			return err
+		}
+		if d.IsDir() {
+			if name != "." && strings.HasPrefix(d.Name(), ".") {
+				return fs.SkipDir
+			}
+			return nil
+		}
+		if !d.Type().IsRegular() || !grepGlobMatch(args.Glob, name) {
+			return nil
+		}
+		if files++; files > maxGrepFiles {
+			stopped = fmt.Sprintf("... [stopped after searching %d files]", maxGrepFiles)
+			return fs.SkipAll
+		}
+		rel := path.Join(prefix, name)
+		matches, err := grepFile(fsys, name, rel, re, maxMatches-len(out))
+		if err != nil {
+			return err
+		}
+		out = append(out, matches...)
+		if len(out) >= maxMatches {
+			stopped = fmt.Sprintf("... [stopped after %d matches]", maxMatches)
+			return fs.SkipAll
+		}
+		return nil
+	})
+	if err != nil {
+		return "", err
+	}
+	if len(out) == 0 {
+		if stopped != "" {
+			return "", fmt.Errorf("pattern %q does not match; %s", args.Pattern, strings.Trim(stopped, ".[] "))
+		}
+		return "", fmt.Errorf("pattern %q does not match", args.Pattern)
+	}
+	if stopped != "" {
+		out = append(out, stopped)
+	}
+	return strings.Join(out, "\n"), nil
+}
+
+// grepGlobMatch returns true if the slash separated relative path name
+// matches glob.
+func grepGlobMatch(glob, name string) bool {
+	if glob == "" {
+		return true
+	}
+	if !strings.Contains(glob, "/") {
+		name = path.Base(name)
+	}
+	ok, _ := path.Match(glob, name)
+	return ok
+}
+
+// grepFile returns up to max matching lines of the file name, reported as rel.
+// Binary files are skipped.
+func grepFile(fsys fs.FS, name, rel string, re *regexp.Regexp, max int) ([]string, error) {
+	f, err := fsys.Open(name)
+	if err != nil {
+		return nil, err
+	}
+	defer f.Close()
+	r := bufio.NewReader(f)
+	if head, _ := r.Peek(8192); bytes.IndexByte(head, 0) >= 0 {
+		return nil, nil
+	}
+	var out []string
+	s := bufio.NewScanner(r)
+	s.Buffer(nil, 1<<20)
+	for n := 1; s.Scan() && len(out) < max; n++ {
+		line := s.Bytes()
+		if !re.Match(line) {
+			continue
+		}
+		if len(line) > maxGrepLine {
+			line = append(trimPartialRune(line[:maxGrepLine]), "..."...)
+		}
+		// The scanner drops the trailing \r of CRLF line endings.
+		out = append(out, fmt.Sprintf("%s:%d: %s", rel, n, line))
+	}
+	// Ignore the rest of a file with a line longer than the buffer; it is
+	// likely not text.
+	return out, nil
+}
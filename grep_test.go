@@ -0,0 +1,92 @@
+// Copyright 2025 Marc-Antoine Ruel. All rights reserved.
+// Use of this source code is governed under the Apache License, Version 2.0
+// that can be found in the LICENSE file.
+
+package genaitools
+
+import (
+	"context"
+	"fmt"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+)
+
+func TestGrep(t *testing.T) {
+	root := t.TempDir()
+	files := map[string]string{
+		"main.go":          "package main\n\n// TODO: fix\nfunc main() {}\r\n",
+		"lib/util.go":      "package lib\n// todo lowercase\nvar x = 1\n",
+		"lib/util_test.go": "package lib\n// TODO: test\n",
+		"README.md":        "# TODO list\n",
+		"bin.dat":          "TODO\x00binary",
+		".git/HEAD":        "TODO hidden\n",
+		"long.txt":         strings.Repeat("é", 300) + "TODO",
+		"many.txt":         strings.Repeat("match\n", 10),
+	}
+	for name, content := range files {
+		p := filepath.Join(root, name)
+		if err := os.MkdirAll(filepath.Dir(p), 0o755); err != nil {
+			t.Fatal(err)
+		}
+		if err := os.WriteFile(p, []byte(content), 0o644); err != nil {
+			t.Fatal(err)
+		}
+	}
+	callback := NewGrep(root).Callback.(func(context.Context, *grepArgs) (string, error))
+	tests := []struct {
+		name      string
+		args      grepArgs
+		expected  string
+		errSubstr string
+	}{
+		{"glob", grepArgs{Pattern: "TODO", Glob: "*.go"}, "lib/util_test.go:2: // TODO: test\nmain.go:3: // TODO: fix", ""},
+		{"ignore_case", grepArgs{Pattern: "todo", Glob: "*.go", IgnoreCase: true}, "lib/util.go:2: // todo lowercase\nlib/util_test.go:2: // TODO: test\nmain.go:3: // TODO: fix", ""},
+		{"path_glob", grepArgs{Pattern: "package", Glob: "lib/*_test.go"}, "lib/util_test.go:1: package lib", ""},
+		{"subdir", grepArgs{Pattern: "^var", Path: "lib"}, "lib/util.go:3: var x = 1", ""},
+		{"crlf", grepArgs{Pattern: `\{\}$`, Glob: "main.go"}, "main.go:4: func main() {}", ""},
+		{"long_line", grepArgs{Pattern: "TODO", Glob: "long.txt"}, "long.txt:1: " + strings.Repeat("é", 250) + "...", ""},
+		{"cap", grepArgs{Pattern: "match", MaxMatches: 3}, "many.txt:1: match\nmany.txt:2: match\nmany.txt:3: match\n... [stopped after 3 matches]", ""},
+		{"no_match", grepArgs{Pattern: "nothing"}, "", `pattern "nothing" does not match`},
+		{"bad_pattern", grepArgs{Pattern: "("}, "", "invalid pattern"},
+		{"bad_glob", grepArgs{Pattern: "a", Glob: "["}, "", `invalid glob "["`},
+		{"not_dir", grepArgs{Pattern: "a", Path: "main.go"}, "", `path "main.go" is not a directory`},
+		{"escape", grepArgs{Pattern: "a", Path: ".."}, "", "escapes the root directory"},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := callback(t.Context(), &tt.args)
+			if tt.errSubstr != "" {
+				if err == nil || !strings.Contains(err.Error(), tt.errSubstr) {
+					t.Fatalf("Expected error containing %q but got %v", tt.errSubstr, err)
+				}
+				return
+			}
+			if err != nil {
+				t.Fatalf("Unexpected error: %v", err)
+			}
+			if got != tt.expected {
+				t.Fatalf("Expected %q but got %q", tt.expected, got)
+			}
+		})
+	}
+}
+
+func TestGrepCapAcrossFiles(t *testing.T) {
+	root := t.TempDir()
+	for i := range 5 {
+		if err := os.WriteFile(filepath.Join(root, fmt.Sprintf("f%d.txt", i)), []byte("needle\nhay\nneedle\n"), 0o644); err != nil {
+			t.Fatal(err)
+		}
+	}
+	callback := NewGrep(root).Callback.(func(context.Context, *grepArgs) (string, error))
+	got, err := callback(t.Context(), &grepArgs{Pattern: "needle", MaxMatches: 5})
+	if err != nil {
+		t.Fatal(err)
+	}
+	want := "f0.txt:1: needle\nf0.txt:3: needle\nf1.txt:1: needle\nf1.txt:3: needle\nf2.txt:1: needle\n... [stopped after 5 matches]"
+	if got != want {
+		t.Fatalf("Expected %q but got %q", want, got)
+	}
+}
@@ -0,0 +1,57 @@
+// Copyright 2025 Marc-Antoine Ruel. All rights reserved.
+// Use of this source code is governed under the Apache License, Version 2.0
+// that can be found in the LICENSE file.
+
+package genaitools
+
+import (
+	"context"
+	"crypto/md5"
+	"crypto/sha1"
+	"crypto/sha256"
+	"crypto/sha512"
+	"encoding/hex"
+	"fmt"
+	"hash"
+
+	"github.com/maruel/genai"
+)
+
+// Hash computes a cryptographic digest and returns it as lowercase
+// hexadecimal.
+//
+// The supported algorithms are "md5", "sha1", "sha256" and "sha512". Binary
+// data can be passed encoded as base64.
+var Hash = genai.ToolDef{
+	Name:        "hash",
+	Description: "Computes the MD5, SHA-1, SHA-256 or SHA-512 digest of data and returns it as hexadecimal. Useful to verify checksums.",
+	Callback:    doHash,
+}
+
+type hashArgs struct {
+	Algorithm string `json:"algorithm" jsonschema:"enum=md5,enum=sha1,enum=sha256,enum=sha512"`
+	Data      string `json:"data" jsonschema:"description=Data to hash"`
+	Encoding  string `json:"encoding,omitempty" jsonschema:"description=Encoding of data. Use base64 for binary data. Defaults to text,enum=text,enum=base64"`
+}
+
+func doHash(ctx context.Context, args *hashArgs) (string, error) {
+	var h hash.Hash
+	switch args.Algorithm {
+	case "md5":
+		h = md5.New()
+	case "sha1":
+		h = sha1.New()
+	case "sha256":
+		h = sha256.New()
+	case "sha512":
+		h = sha512.New()
+	default:
+		return "", fmt.Errorf("unknown algorithm %q; supported algorithms are md5, sha1, sha256 and sha512", args.Algorithm)
+	}
+	b, err := decodeData(args.Data, args.Encoding)
+	if err != nil {
+		return "", err
+	}
+	h.Write(b)
+	return hex.EncodeToString(h.Sum(nil)), nil
+}
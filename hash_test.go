@@ -0,0 +1,49 @@
+// Copyright 2025 Marc-Antoine Ruel. All rights reserved.
+// Use of this source code is governed under the Apache License, Version 2.0
+// that can be found in the LICENSE file.
+
+package genaitools
+
+import (
+	"context"
+	"strings"
+	"testing"
+)
+
+func TestHash(t *testing.T) {
+	callback := Hash.Callback.(func(context.Context, *hashArgs) (string, error))
+	tests := []struct {
+		name      string
+		args      hashArgs
+		expected  string
+		errSubstr string
+	}{
+		// Test vectors from FIPS 180 and RFC 1321.
+		{"sha256_empty", hashArgs{Algorithm: "sha256"}, "e3b0c44298fc1c149afbf4c8996fb92427ae41e4649b934ca495991b7852b855", ""},
+		{"sha256_abc", hashArgs{Algorithm: "sha256", Data: "abc"}, "ba7816bf8f01cfea414140de5dae2223b00361a396177a9cb410ff61f20015ad", ""},
+		{"sha1_abc", hashArgs{Algorithm: "sha1", Data: "abc"}, "a9993e364706816aba3e25717850c26c9cd0d89d", ""},
+		{"md5_empty", hashArgs{Algorithm: "md5"}, "d41d8cd98f00b204e9800998ecf8427e", ""},
+		{"md5_abc", hashArgs{Algorithm: "md5", Data: "abc"}, "900150983cd24fb0d6963f7d28e17f72", ""},
+		{"sha512_abc", hashArgs{Algorithm: "sha512", Data: "abc"}, "ddaf35a193617abacc417349ae20413112e6fa4e89a97ea20a9eeee64b55d39a2192992a274fc1a836ba3c23a3feebbd454d4423643ce80e2a9ac94fa54ca49f", ""},
+		{"base64", hashArgs{Algorithm: "sha256", Data: "YWJj", Encoding: "base64"}, "ba7816bf8f01cfea414140de5dae2223b00361a396177a9cb410ff61f20015ad", ""},
+		{"bad_algorithm", hashArgs{Algorithm: "sha3", Data: "a"}, "", "unknown algorithm \"sha3\"; supported algorithms are md5, sha1, sha256 and sha512"},
+		{"bad_base64", hashArgs{Algorithm: "md5", Data: "!!!", Encoding: "base64"}, "", "invalid base64"},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := callback(t.Context(), &tt.args)
+			if tt.errSubstr != "" {
+				if err == nil || !strings.Contains(err.Error(), tt.errSubstr) {
+					t.Fatalf("Expected error containing %q but got %v", tt.errSubstr, err)
+				}
+				return
+			}
+			if err != nil {
+				t.Fatalf("Unexpected error: %v", err)
+			}
+			if got != tt.expected {
+				t.Fatalf("Expected %q but got %q", tt.expected, got)
+			}
+		})
+	}
+}
@@ -0,0 +1,86 @@
+// Copyright 2025 Marc-Antoine Ruel. All rights reserved.
+// Use of this source code is governed under the Apache License, Version 2.0
+// that can be found in the LICENSE file.
+
+package genaitools
+
+import (
+	"context"
+	"fmt"
+	"strings"
+
+	"github.com/alecthomas/chroma/v2"
+	"github.com/alecthomas/chroma/v2/formatters"
+	chromahtml "github.com/alecthomas/chroma/v2/formatters/html"
+	"github.com/alecthomas/chroma/v2/lexers"
+	"github.com/alecthomas/chroma/v2/styles"
+	"github.com/maruel/genai"
+)
+
+// highlightStyle is the Chroma style used for both formats. It has a dark
+// background, which the HTML output sets on the <pre> element.
+const highlightStyle = "monokai"
+
+// HighlightCode highlights source code for display in a terminal (ANSI escape
+// codes, 256 colors) or a web page (HTML with inline styles).
+//
+// The highlighting is done by Chroma, which supports several hundred
+// languages. The language is detected from the code when not specified. When
+// the language is unknown, the code is returned unchanged with a note.
+var HighlightCode = genai.ToolDef{
+	Name:        "highlight_code",
+	Description: "Syntax highlights source code as ANSI escape codes for a terminal or as HTML and returns a JSON object with the language, the highlighted code and an optional note.",
+	Callback:    doHighlightCode,
+}
+
+type highlightCodeArgs struct {
+	Code     string `json:"code" jsonschema:"description=Source code to highlight"`
+	Language string `json:"language,omitempty" jsonschema:"description=Language like go\\, python\\, javascript\\, rust\\, c\\, java\\, shell\\, sql or json. Detected from the code when empty"`
+	Format   string `json:"format" jsonschema:"description=ansi for a terminal or html for a web page,enum=ansi,enum=html"`
+}
+
+type highlightCodeResult struct {
+	Language string `json:"language"`
+	Output   string `json:"output"`
+	Note     string `json:"note,omitempty"`
+}
+
+func doHighlightCode(ctx context.Context, args *highlightCodeArgs) (string, error) {
+	var f chroma.Formatter
+	switch args.Format {
+	case "ansi":
+		f = formatters.TTY256
+	case "html":
+		f = chromahtml.New(chromahtml.PreventSurroundingPre(false))
+	default:
+		return "", fmt.Errorf("unknown format %q; use ansi or html", args.Format)
+	}
+	res := highlightCodeResult{Output: args.Code}
+	var l chroma.Lexer
+	if name := strings.TrimSpace(args.Language); name != "" {
+		if l = lexers.Get(name); l == nil {
+			res.Note = fmt.Sprintf("unknown language %q; the code is returned unchanged", args.Language)
+		}
+	} else if l = lexers.Analyse(args.Code); l == nil {
+		res.Note = "could not detect the language; the code is returned unchanged"
+	}
+	if l != nil {
+		it, err := chroma.Coalesce(l).Tokenise(nil, args.Code)
+		if err != nil {
+			return "", fmt.Errorf("failed to highlight the code: %w", err)
+		}
+		tokens := it.Tokens()
+		if n := len(tokens); n != 0 && !strings.HasSuffix(args.Code, "\n") {
+			// Some lexers append a newline to the code.
+			tokens[n-1].Value = strings.TrimSuffix(tokens[n-1].Value, "\n")
+		}
+		var b strings.Builder
+		if err = f.Format(&b, styles.Get(highlightStyle), chroma.Literator(tokens...)); err != nil {
+			return "", fmt.Errorf("failed to highlight the code: %w", err)
+		}
+		res.Language = strings.ToLower(l.Config().Name)
+		res.Output = b.String()
+	}
+	// Do not escape <, > and & since the HTML output would be unreadable.
+	return marshalJSON(res)
+}
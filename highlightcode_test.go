@@ -0,0 +1,109 @@
+// Copyright 2025 Marc-Antoine Ruel. All rights reserved.
+// Use of this source code is governed under the Apache License, Version 2.0
+// that can be found in the LICENSE file.
+
+package genaitools
+
+import (
+	"context"
+	"encoding/json"
+	"regexp"
+	"strings"
+	"testing"
+)
+
+func TestHighlightCode(t *testing.T) {
+	callback := HighlightCode.Callback.(func(context.Context, *highlightCodeArgs) (string, error))
+	tests := []struct {
+		name      string
+		args      highlightCodeArgs
+		expected  string
+		errSubstr string
+	}{
+		{
+			"go_ansi",
+			highlightCodeArgs{Code: "x := 42 // hi", Language: "Go", Format: "ansi"},
+			`{"language":"go","output":"\u001b[38;5;148mx\u001b[0m\u001b[38;5;231m \u001b[0m\u001b[38;5;197m:=\u001b[0m\u001b[38;5;231m \u001b[0m\u001b[38;5;141m42\u001b[0m\u001b[38;5;231m \u001b[0m\u001b[38;5;242m// hi\u001b[0m"}`,
+			"",
+		},
+		{
+			"go_raw_string",
+			highlightCodeArgs{Code: "return `a\nb`", Language: "golang", Format: "ansi"},
+			`{"language":"go","output":"\u001b[38;5;81mreturn\u001b[0m\u001b[38;5;231m \u001b[0m\u001b[38;5;186m` + "`a\\u001b[0m\\n\\u001b[38;5;186mb`" + `\u001b[0m"}`,
+			"",
+		},
+		{
+			"html_escape",
+			highlightCodeArgs{Code: `if a < b: s = "<x>"`, Language: "py", Format: "html"},
+			`{"language":"python","output":"<pre style=\"color:#f8f8f2;background-color:#272822;-webkit-text-size-adjust:none;\"><code><span style=\"display:flex;\"><span><span style=\"color:#66d9ef\">if</span> a <span style=\"color:#f92672\">&lt;</span> b: s <span style=\"color:#f92672\">=</span> <span style=\"color:#e6db74\">&#34;&lt;x&gt;&#34;</span></span></span></code></pre>"}`,
+			"",
+		},
+		{
+			"sql_escaped_quote",
+			highlightCodeArgs{Code: "select 'it''s' -- c", Language: "sql", Format: "ansi"},
+			`{"language":"sql","output":"\u001b[38;5;81mselect\u001b[0m\u001b[38;5;231m \u001b[0m\u001b[38;5;186m'it''s'\u001b[0m\u001b[38;5;231m \u001b[0m\u001b[38;5;242m-- c\u001b[0m"}`,
+			"",
+		},
+		{
+			"detected",
+			highlightCodeArgs{Code: "#!/bin/bash\necho hi\n", Format: "ansi"},
+			`{"language":"bash","output":"\u001b[38;5;242m#!/bin/bash\u001b[0m\n\u001b[38;5;231mecho\u001b[0m\u001b[38;5;231m hi\u001b[0m\n"}`,
+			"",
+		},
+		{
+			"unknown_language",
+			highlightCodeArgs{Code: "x <- 1", Language: "klingon", Format: "ansi"},
+			`{"language":"","output":"x <- 1","note":"unknown language \"klingon\"; the code is returned unchanged"}`,
+			"",
+		},
+		{
+			"undetected",
+			highlightCodeArgs{Code: "hello world", Format: "html"},
+			`{"language":"","output":"hello world","note":"could not detect the language; the code is returned unchanged"}`,
+			"",
+		},
+		{"bad_format", highlightCodeArgs{Code: "x", Format: "rtf"}, "", `unknown format "rtf"`},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := callback(t.Context(), &tt.args)
+			if tt.errSubstr != "" {
+				if err == nil || !strings.Contains(err.Error(), tt.errSubstr) {
+					t.Fatalf("Expected error containing %q but got %v", tt.errSubstr, err)
+				}
+				return
+			}
+			if err != nil {
+				t.Fatalf("Unexpected error: %v", err)
+			}
+			if got != tt.expected {
+				t.Fatalf("Expected %q but got %q", tt.expected, got)
+			}
+		})
+	}
+}
+
+func TestHighlightCodeKeepsText(t *testing.T) {
+	// Removing the escape codes must always return the original code, even
+	// when strings or comments are unterminated.
+	callback := HighlightCode.Callback.(func(context.Context, *highlightCodeArgs) (string, error))
+	reANSI := regexp.MustCompile("\x1b\\[[0-9;]*m")
+	for _, code := range []string{"/* open", `"open`, "'''open", "x = `open", "a\\", "é \"ü\" 1.5e3"} {
+		for _, lang := range []string{"go", "python", "javascript", "rust", "c", "java", "bash", "sql", "json"} {
+			got, err := callback(t.Context(), &highlightCodeArgs{Code: code, Language: lang, Format: "ansi"})
+			if err != nil {
+				t.Fatalf("Unexpected error: %v", err)
+			}
+			var res highlightCodeResult
+			if err = json.Unmarshal([]byte(got), &res); err != nil {
+				t.Fatal(err)
+			}
+			if res.Note != "" {
+				t.Fatalf("%s: Unexpected note %q", lang, res.Note)
+			}
+			if s := reANSI.ReplaceAllString(res.Output, ""); s != code {
+				t.Errorf("%s: Expected %q but got %q", lang, code, s)
+			}
+		}
+	}
+}
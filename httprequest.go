@@ -0,0 +1,143 @@
+// Copyright 2025 Marc-Antoine Ruel. All rights reserved.
+// Use of this source code is governed under the Apache License, Version 2.0
+// that can be found in the LICENSE file.
+
+package genaitools
+
+import (
+	"context"
+	"encoding/base64"
+	"fmt"
+	"io"
+	"net/http"
+	"net/url"
+	"slices"
+	"strings"
+	"unicode/utf8"
+
+	"github.com/maruel/genai"
+)
+
+// maxHTTPRequestBody is the maximum size of the body sent by NewHTTPRequest.
+const maxHTTPRequestBody = 1 << 20
+
+// deniedHTTPHeaders are the request headers that cannot be set since they
+// are managed by the client or could be used for request smuggling or to
+// abuse a proxy.
+var deniedHTTPHeaders = []string{
+	"Connection",
+	"Content-Length",
+	"Expect",
+	"Host",
+	"Keep-Alive",
+	"Proxy-Authorization",
+	"Proxy-Connection",
+	"Te",
+	"Trailer",
+	"Transfer-Encoding",
+	"Upgrade",
+}
+
+// NewHTTPRequest returns a tool that sends an HTTP request with client and
+// returns the response as JSON. client defaults to http.DefaultClient.
+//
+// The request body is limited to 1MiB and the headers managed by the client
+// like Host, Content-Length or Transfer-Encoding cannot be set. Redirects,
+// timeout and response body truncation are the same as NewFetchURL. A
+// response body that is not valid UTF-8 is returned base64 encoded.
+func NewHTTPRequest(client *http.Client) genai.ToolDef {
+	c := http.Client{}
+	if client != nil {
+		c = *client
+	}
+	if c.CheckRedirect == nil {
+		c.CheckRedirect = checkFetchRedirect
+	}
+	return genai.ToolDef{
+		Name:        "http_request",
+		Description: "Sends an HTTP request with a method, headers and body to an http or https URL, e.g. to call a REST API. Returns JSON with the status code, the response headers and the response body.",
+		Callback: func(ctx context.Context, args *httpRequestArgs) (string, error) {
+			return doHTTPRequest(ctx, &c, args)
+		},
+	}
+}
+
+type httpRequestArgs struct {
+	Method  string            `json:"method,omitempty" jsonschema:"enum=GET,enum=HEAD,enum=POST,enum=PUT,enum=PATCH,enum=DELETE,enum=OPTIONS,description=HTTP method; defaults to GET"`
+	URL     string            `json:"url" jsonschema:"description=Absolute http or https URL"`
+	Headers map[string]string `json:"headers,omitempty" jsonschema:"description=Request headers like {\"Content-Type\": \"application/json\"}"`
+	Body    string            `json:"body,omitempty" jsonschema:"description=Request body"`
+}
+
+type httpRequestResult struct {
+	Status       int         `json:"status"`
+	Headers      http.Header `json:"headers"`
+	Body         string      `json:"body"`
+	BodyEncoding string      `json:"body_encoding,omitempty"`
+	Truncated    bool        `json:"truncated,omitempty"`
+}
+
+func doHTTPRequest(ctx context.Context, c *http.Client, args *httpRequestArgs) (string, error) {
+	method := strings.ToUpper(strings.TrimSpace(args.Method))
+	switch method {
+	case "":
+		method = http.MethodGet
+	case http.MethodGet, http.MethodHead, http.MethodPost, http.MethodPut, http.MethodPatch, http.MethodDelete, http.MethodOptions:
+	default:
+		return "", fmt.Errorf("unsupported method %q", args.Method)
+	}
+	u, err := url.Parse(args.URL)
+	if err != nil {
+		return "", fmt.Errorf("invalid URL: %w", err)
+	}
+	if err = checkFetchScheme(u); err != nil {
+		return "", err
+	}
+	if len(args.Body) > maxHTTPRequestBody {
+		return "", fmt.Errorf("request body is %d bytes; the maximum is %d", len(args.Body), maxHTTPRequestBody)
+	}
+	if c.Timeout == 0 {
+		var cancel context.CancelFunc
+		ctx, cancel = context.WithTimeout(ctx, fetchTimeout)
+		defer cancel()
+	}
+	var body io.Reader
+	if args.Body != "" {
+		body = strings.NewReader(args.Body)
+	}
+	req, err := http.NewRequestWithContext(ctx, method, u.String(), body)
+	if err != nil {
+		return "", err
+	}
+	req.Header.Set("User-Agent", UserAgent)
+	for k, v := range args.Headers {
+		ck := http.CanonicalHeaderKey(strings.TrimSpace(k))
+		if slices.Contains(deniedHTTPHeaders, ck) || strings.HasPrefix(ck, "Proxy-") {
+			return "", fmt.Errorf("header %q cannot be set", k)
+		}
+		req.Header.Set(ck, v)
+	}
+	resp, err := c.Do(req)
+	if err != nil {
+		return "", err
+	}
+	defer resp.Body.Close()
+	b, err := io.ReadAll(io.LimitReader(resp.Body, maxFetchBody+1))
+	if err != nil {
+		return "", fmt.Errorf("failed to read the response: %w", err)
+	}
+	res := httpRequestResult{Status: resp.StatusCode, Headers: resp.Header}
+	if res.Truncated = len(b) > maxFetchBody; res.Truncated {
+		b = b[:maxFetchBody]
+		if t := trimPartialRune(b); utf8.Valid(t) {
+			b = t
+		}
+	}
+	if utf8.Valid(b) {
+		res.Body = string(b)
+	} else {
+		res.Body = base64.StdEncoding.EncodeToString(b)
+		res.BodyEncoding = "base64"
+	}
+	return marshalJSON(res)
+}
@@ -0,0 +1,136 @@
+// Copyright 2025 Marc-Antoine Ruel. All rights reserved.
+// Use of this source code is governed under the Apache License, Version 2.0
+// that can be found in the LICENSE file.
+
+package genaitools
+
+import (
+	"context"
+	"encoding/json"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+)
+
+func TestHTTPRequest(t *testing.T) {
+	mux := http.NewServeMux()
+	mux.HandleFunc("POST /items", func(w http.ResponseWriter, r *http.Request) {
+		var item map[string]any
+		w.Header()["Date"] = nil
+		if r.Header.Get("Content-Type") != "application/json" {
+			http.Error(w, "bad content type", http.StatusUnsupportedMediaType)
+			return
+		}
+		if err := json.NewDecoder(r.Body).Decode(&item); err != nil {
+			http.Error(w, err.Error(), http.StatusBadRequest)
+			return
+		}
+		item["id"] = 42
+		item["token"] = r.Header.Get("Authorization")
+		item["agent"] = r.UserAgent()
+		w.Header().Set("Content-Type", "application/json")
+		w.WriteHeader(http.StatusCreated)
+		_ = json.NewEncoder(w).Encode(item)
+	})
+	mux.HandleFunc("/echo", func(w http.ResponseWriter, r *http.Request) {
+		b, _ := io.ReadAll(r.Body)
+		w.Header()["Date"] = nil
+		w.Header().Set("Content-Type", "text/plain")
+		_, _ = w.Write([]byte(r.Method + " " + string(b)))
+	})
+	mux.HandleFunc("/redirect", func(w http.ResponseWriter, r *http.Request) {
+		http.Redirect(w, r, "/echo", http.StatusTemporaryRedirect)
+	})
+	mux.HandleFunc("/binary", func(w http.ResponseWriter, r *http.Request) {
+		w.Header()["Date"] = nil
+		w.Header().Set("Content-Type", "image/png")
+		_, _ = w.Write([]byte{0x89, 'P', 'N', 'G', 0xff, 0xfe})
+	})
+	mux.HandleFunc("/big", func(w http.ResponseWriter, r *http.Request) {
+		_, _ = w.Write([]byte(strings.Repeat("a", maxFetchBody+10)))
+	})
+	srv := httptest.NewServer(mux)
+	defer srv.Close()
+
+	callback := NewHTTPRequest(srv.Client()).Callback.(func(context.Context, *httpRequestArgs) (string, error))
+	tests := []struct {
+		name      string
+		args      httpRequestArgs
+		expected  string
+		errSubstr string
+	}{
+		{
+			"post_json",
+			httpRequestArgs{
+				Method:  "post",
+				URL:     srv.URL + "/items",
+				Headers: map[string]string{"content-type": "application/json", "Authorization": "Bearer x"},
+				Body:    `{"name": "apple"}`,
+			},
+			`{"status":201,"headers":{"Content-Length":["105"],"Content-Type":["application/json"]},"body":"{\"agent\":\"` + UserAgent + `\",\"id\":42,\"name\":\"apple\",\"token\":\"Bearer x\"}\n"}`,
+			"",
+		},
+		{
+			"post_wrong_content_type",
+			httpRequestArgs{Method: "POST", URL: srv.URL + "/items", Body: `{}`},
+			`{"status":415,"headers":{"Content-Length":["17"],"Content-Type":["text/plain; charset=utf-8"],"X-Content-Type-Options":["nosniff"]},"body":"bad content type\n"}`,
+			"",
+		},
+		{
+			"get_default",
+			httpRequestArgs{URL: srv.URL + "/echo"},
+			`{"status":200,"headers":{"Content-Length":["4"],"Content-Type":["text/plain"]},"body":"GET "}`,
+			"",
+		},
+		{
+			"redirect_keeps_body",
+			httpRequestArgs{Method: "PUT", URL: srv.URL + "/redirect", Body: "data"},
+			`{"status":200,"headers":{"Content-Length":["8"],"Content-Type":["text/plain"]},"body":"PUT data"}`,
+			"",
+		},
+		{
+			"binary",
+			httpRequestArgs{URL: srv.URL + "/binary"},
+			`{"status":200,"headers":{"Content-Length":["6"],"Content-Type":["image/png"]},"body":"iVBOR//+","body_encoding":"base64"}`,
+			"",
+		},
+		{"denied_header", httpRequestArgs{URL: srv.URL + "/echo", Headers: map[string]string{"host": "evil"}}, "", `header "host" cannot be set`},
+		{"denied_proxy", httpRequestArgs{URL: srv.URL + "/echo", Headers: map[string]string{"Proxy-Foo": "x"}}, "", `header "Proxy-Foo" cannot be set`},
+		{"body_too_large", httpRequestArgs{Method: "POST", URL: srv.URL + "/echo", Body: strings.Repeat("x", maxHTTPRequestBody+1)}, "", "request body is 1048577 bytes; the maximum is 1048576"},
+		{"method", httpRequestArgs{Method: "TRACE", URL: srv.URL + "/echo"}, "", `unsupported method "TRACE"`},
+		{"scheme", httpRequestArgs{URL: "file:///etc/passwd"}, "", `unsupported URL scheme "file"`},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := callback(t.Context(), &tt.args)
+			if tt.errSubstr != "" {
+				if err == nil || !strings.Contains(err.Error(), tt.errSubstr) {
+					t.Fatalf("Expected error containing %q but got %v", tt.errSubstr, err)
+				}
+				return
+			}
+			if err != nil {
+				t.Fatalf("Unexpected error: %v", err)
+			}
+			if got != tt.expected {
+				t.Fatalf("Expected %q but got %q", tt.expected, got)
+			}
+		})
+	}
+
+	t.Run("truncated", func(t *testing.T) {
+		got, err := callback(t.Context(), &httpRequestArgs{URL: srv.URL + "/big"})
+		if err != nil {
+			t.Fatalf("Unexpected error: %v", err)
+		}
+		var res httpRequestResult
+		if err = json.Unmarshal([]byte(got), &res); err != nil {
+			t.Fatal(err)
+		}
+		if !res.Truncated || len(res.Body) != maxFetchBody {
+			t.Fatalf("Expected a truncated body of %d bytes but got %d bytes, truncated=%t", maxFetchBody, len(res.Body), res.Truncated)
+		}
+	})
+}
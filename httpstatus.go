@@ -0,0 +1,94 @@
+// Copyright 2025 Marc-Antoine Ruel. All rights reserved.
+// Use of this source code is governed under the Apache License, Version 2.0
+// that can be found in the LICENSE file.
+
+package genaitools
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+
+	"github.com/maruel/genai"
+)
+
+// HTTPStatus explains an HTTP status code.
+//
+// It returns the canonical reason phrase, the category (informational,
+// success, redirect, client error or server error) and a short explanation.
+var HTTPStatus = genai.ToolDef{
+	Name:        "http_status",
+	Description: "Explains an HTTP status code: returns its reason phrase, its category and a short explanation.",
+	Callback:    doHTTPStatus,
+}
+
+type httpStatusArgs struct {
+	Code int `json:"code" jsonschema:"description=HTTP status code\\, e.g. 404"`
+}
+
+// httpStatusExplanations is a short explanation of the most common codes.
+var httpStatusExplanations = map[int]string{
+	http.StatusContinue:                      "The client should continue sending the request body.",
+	http.StatusSwitchingProtocols:            "The server is switching to the protocol requested in the Upgrade header.",
+	http.StatusOK:                            "The request succeeded.",
+	http.StatusCreated:                       "The request succeeded and a new resource was created.",
+	http.StatusAccepted:                      "The request was accepted for processing but is not completed yet.",
+	http.StatusNoContent:                     "The request succeeded and there is no content to return.",
+	http.StatusPartialContent:                "Only the range of the resource requested via the Range header is returned.",
+	http.StatusMovedPermanently:              "The resource moved permanently to the URL in the Location header.",
+	http.StatusFound:                         "The resource is temporarily at the URL in the Location header.",
+	http.StatusSeeOther:                      "The result must be retrieved with a GET at the URL in the Location header.",
+	http.StatusNotModified:                   "The cached version is still valid; no body is returned.",
+	http.StatusTemporaryRedirect:             "Repeat the request with the same method at the URL in the Location header.",
+	http.StatusPermanentRedirect:             "Repeat this and future requests with the same method at the URL in the Location header.",
+	http.StatusBadRequest:                    "The server cannot process the request due to a client error, e.g. malformed syntax or invalid parameters.",
+	http.StatusUnauthorized:                  "Authentication is required or the provided credentials are invalid.",
+	http.StatusPaymentRequired:               "Payment or a higher quota is required.",
+	http.StatusForbidden:                     "The client is authenticated but not allowed to access the resource.",
+	http.StatusNotFound:                      "The resource does not exist at this URL.",
+	http.StatusMethodNotAllowed:              "The HTTP method is not supported for this resource; see the Allow header.",
+	http.StatusNotAcceptable:                 "No representation matches the Accept headers of the request.",
+	http.StatusRequestTimeout:                "The server timed out waiting for the request.",
+	http.StatusConflict:                      "The request conflicts with the current state of the resource.",
+	http.StatusGone:                          "The resource was permanently removed.",
+	http.StatusRequestEntityTooLarge:         "The request body is larger than the server accepts.",
+	http.StatusUnsupportedMediaType:          "The Content-Type of the request body is not supported.",
+	http.StatusUnprocessableEntity:           "The request is well formed but contains semantic errors, e.g. failed validation.",
+	http.StatusTooManyRequests:               "Rate limited; retry later, possibly after the delay in the Retry-After header.",
+	http.StatusInternalServerError:           "The server encountered an unexpected error.",
+	http.StatusNotImplemented:                "The server does not support the functionality required.",
+	http.StatusBadGateway:                    "A gateway or proxy received an invalid response from the upstream server.",
+	http.StatusServiceUnavailable:            "The server is temporarily unable to handle the request, e.g. overloaded or in maintenance.",
+	http.StatusGatewayTimeout:                "A gateway or proxy did not receive a timely response from the upstream server.",
+	http.StatusHTTPVersionNotSupported:       "The HTTP version used in the request is not supported.",
+	http.StatusNetworkAuthenticationRequired: "The client must authenticate to gain network access, e.g. a captive portal.",
+}
+
+func doHTTPStatus(ctx context.Context, args *httpStatusArgs) (string, error) {
+	if args.Code < 100 || args.Code > 599 {
+		return "", fmt.Errorf("invalid HTTP status code %d; must be between 100 and 599", args.Code)
+	}
+	category := ""
+	generic := ""
+	switch args.Code / 100 {
+	case 1:
+		category, generic = "informational", "The request was received and the process is continuing."
+	case 2:
+		category, generic = "success", "The request was successfully received, understood and accepted."
+	case 3:
+		category, generic = "redirect", "Further action is needed to complete the request."
+	case 4:
+		category, generic = "client error", "The request contains an error and should not be repeated as is."
+	case 5:
+		category, generic = "server error", "The server failed to fulfill a valid request; it may succeed later."
+	}
+	phrase := http.StatusText(args.Code)
+	if phrase == "" {
+		phrase = "Unknown"
+	}
+	explanation := httpStatusExplanations[args.Code]
+	if explanation == "" {
+		explanation = generic
+	}
+	return fmt.Sprintf("%d %s (%s): %s", args.Code, phrase, category, explanation), nil
+}
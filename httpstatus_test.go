@@ -0,0 +1,38 @@
+// Copyright 2025 Marc-Antoine Ruel. All rights reserved.
+// Use of this source code is governed under the Apache License, Version 2.0
+// that can be found in the LICENSE file.
+
+package genaitools
+
+import (
+	"context"
+	"testing"
+)
+
+func TestHTTPStatus(t *testing.T) {
+	callback := HTTPStatus.Callback.(func(context.Context, *httpStatusArgs) (string, error))
+	tests := []struct {
+		code int
+		want string
+	}{
+		{200, "200 OK (success): The request succeeded."},
+		{404, "404 Not Found (client error): The resource does not exist at this URL."},
+		{418, "418 I'm a teapot (client error): The request contains an error and should not be repeated as is."},
+		{503, "503 Service Unavailable (server error): The server is temporarily unable to handle the request, e.g. overloaded or in maintenance."},
+		{299, "299 Unknown (success): The request was successfully received, understood and accepted."},
+	}
+	for _, tt := range tests {
+		got, err := callback(t.Context(), &httpStatusArgs{Code: tt.code})
+		if err != nil {
+			t.Fatalf("Unexpected error: %v", err)
+		}
+		if got != tt.want {
+			t.Fatalf("Expected %q but got %q", tt.want, got)
+		}
+	}
+	for _, code := range []int{0, 99, 600} {
+		if _, err := callback(t.Context(), &httpStatusArgs{Code: code}); err == nil {
+			t.Fatalf("expected error for %d", code)
+		}
+	}
+}
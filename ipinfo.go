@@ -0,0 +1,131 @@
+// Copyright 2025 Marc-Antoine Ruel. All rights reserved.
+// Use of this source code is governed under the Apache License, Version 2.0
+// that can be found in the LICENSE file.
+
+package genaitools
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"net/netip"
+	"strconv"
+	"strings"
+
+	"github.com/maruel/genai"
+)
+
+// IPInfo classifies an IPv4 or IPv6 address without doing any network call.
+//
+// It reports the version, the scope flags from net/netip, the special-purpose
+// range (RFC 6890) the address belongs to, if any, and the reverse DNS name
+// in in-addr.arpa or ip6.arpa. IPv4-mapped IPv6 addresses are classified as
+// the IPv4 address.
+var IPInfo = genai.ToolDef{
+	Name:        "ip_info",
+	Description: "Classifies an IPv4 or IPv6 address: version, whether it is private, loopback, multicast, link-local or public, and its reverse DNS name, as JSON. Does no network call.",
+	Callback:    doIPInfo,
+}
+
+type ipInfoArgs struct {
+	IP string `json:"ip" jsonschema:"description=IPv4 or IPv6 address like 192.168.1.1 or 2001:db8::1"`
+}
+
+type ipInfoResult struct {
+	IP            string `json:"ip"`
+	Version       int    `json:"version"`
+	IPv4Mapped    bool   `json:"ipv4_mapped,omitempty"`
+	Zone          string `json:"zone,omitempty"`
+	Unspecified   bool   `json:"unspecified"`
+	Loopback      bool   `json:"loopback"`
+	Private       bool   `json:"private"`
+	LinkLocal     bool   `json:"link_local"`
+	Multicast     bool   `json:"multicast"`
+	GlobalUnicast bool   `json:"global_unicast"`
+	Public        bool   `json:"public"`
+	Special       string `json:"special,omitempty"`
+	ReverseDNS    string `json:"reverse_dns"`
+}
+
+// specialRanges are special-purpose ranges not covered by the netip.Addr
+// methods.
+var specialRanges = []struct {
+	prefix netip.Prefix
+	name   string
+}{
+	{netip.MustParsePrefix("0.0.0.0/8"), "this network (RFC 791)"},
+	{netip.MustParsePrefix("100.64.0.0/10"), "shared address space, carrier-grade NAT (RFC 6598)"},
+	{netip.MustParsePrefix("192.0.0.0/24"), "IETF protocol assignments (RFC 6890)"},
+	{netip.MustParsePrefix("192.0.2.0/24"), "documentation, TEST-NET-1 (RFC 5737)"},
+	{netip.MustParsePrefix("198.18.0.0/15"), "benchmarking (RFC 2544)"},
+	{netip.MustParsePrefix("198.51.100.0/24"), "documentation, TEST-NET-2 (RFC 5737)"},
+	{netip.MustParsePrefix("203.0.113.0/24"), "documentation, TEST-NET-3 (RFC 5737)"},
+	{netip.MustParsePrefix("240.0.0.0/4"), "reserved (RFC 1112)"},
+	{netip.MustParsePrefix("255.255.255.255/32"), "limited broadcast (RFC 919)"},
+	{netip.MustParsePrefix("64:ff9b::/96"), "IPv4/IPv6 translation (RFC 6052)"},
+	{netip.MustParsePrefix("100::/64"), "discard-only (RFC 6666)"},
+	{netip.MustParsePrefix("2001::/32"), "Teredo (RFC 4380)"},
+	{netip.MustParsePrefix("2001:db8::/32"), "documentation (RFC 3849)"},
+	{netip.MustParsePrefix("2002::/16"), "6to4 (RFC 3056)"},
+}
+
+func doIPInfo(ctx context.Context, args *ipInfoArgs) (string, error) {
+	s := strings.TrimSpace(args.IP)
+	// Accept the bracketed form used in URLs.
+	if strings.HasPrefix(s, "[") && strings.HasSuffix(s, "]") {
+		s = s[1 : len(s)-1]
+	}
+	ip, err := netip.ParseAddr(s)
+	if err != nil {
+		return "", fmt.Errorf("invalid IP %q: %w", args.IP, err)
+	}
+	res := ipInfoResult{
+		IP:         ip.String(),
+		Version:    6,
+		IPv4Mapped: ip.Is4In6(),
+		Zone:       ip.Zone(),
+		ReverseDNS: reverseDNSName(ip),
+	}
+	a := ip.Unmap()
+	if a.Is4() {
+		res.Version = 4
+	}
+	res.Unspecified = a.IsUnspecified()
+	res.Loopback = a.IsLoopback()
+	res.Private = a.IsPrivate()
+	res.LinkLocal = a.IsLinkLocalUnicast() || a.IsLinkLocalMulticast()
+	res.Multicast = a.IsMulticast()
+	res.GlobalUnicast = a.IsGlobalUnicast()
+	for _, r := range specialRanges {
+		if r.prefix.Contains(a.WithZone("")) {
+			res.Special = r.name
+			break
+		}
+	}
+	res.Public = res.GlobalUnicast && !res.Private && res.Special == ""
+	b, err := json.Marshal(res)
+	return string(b), err
+}
+
+// reverseDNSName returns the PTR name of ip in in-addr.arpa or ip6.arpa.
+func reverseDNSName(ip netip.Addr) string {
+	var b strings.Builder
+	if ip.Is4() {
+		a := ip.As4()
+		for i := 3; i >= 0; i-- {
+			b.WriteString(strconv.Itoa(int(a[i])) + ".")
+		}
+		b.WriteString("in-addr.arpa.")
+		return b.String()
+	}
+	a := ip.As16()
+	const hex = "0123456789abcdef"
+	for i := 15; i >= 0; i-- {
+		b.WriteByte(hex[a[i]&0xf])
+		b.WriteByte('.')
+		b.WriteByte(hex[a[i]>>4])
+		b.WriteByte('.')
+	}
+	b.WriteString("ip6.arpa.")
+	return b.String()
+}
@@ -0,0 +1,53 @@
+// Copyright 2025 Marc-Antoine Ruel. All rights reserved.
+// Use of this source code is governed under the Apache License, Version 2.0
+// that can be found in the LICENSE file.
+
+package genaitools
+
+import (
+	"context"
+	"strings"
+	"testing"
+)
+
+func TestIPInfo(t *testing.T) {
+	callback := IPInfo.Callback.(func(context.Context, *ipInfoArgs) (string, error))
+	tests := []struct {
+		name      string
+		ip        string
+		expected  string
+		errSubstr string
+	}{
+		{"loopback4", "127.0.0.1", `{"ip":"127.0.0.1","version":4,"unspecified":false,"loopback":true,"private":false,"link_local":false,"multicast":false,"global_unicast":false,"public":false,"reverse_dns":"1.0.0.127.in-addr.arpa."}`, ""},
+		{"private4", "10.0.0.1", `{"ip":"10.0.0.1","version":4,"unspecified":false,"loopback":false,"private":true,"link_local":false,"multicast":false,"global_unicast":true,"public":false,"reverse_dns":"1.0.0.10.in-addr.arpa."}`, ""},
+		{"loopback6", "::1", `{"ip":"::1","version":6,"unspecified":false,"loopback":true,"private":false,"link_local":false,"multicast":false,"global_unicast":false,"public":false,"reverse_dns":"1.0.0.0.0.0.0.0.0.0.0.0.0.0.0.0.0.0.0.0.0.0.0.0.0.0.0.0.0.0.0.0.ip6.arpa."}`, ""},
+		{"public4", " 8.8.4.4 ", `{"ip":"8.8.4.4","version":4,"unspecified":false,"loopback":false,"private":false,"link_local":false,"multicast":false,"global_unicast":true,"public":true,"reverse_dns":"4.4.8.8.in-addr.arpa."}`, ""},
+		{"public6", "[2606:4700:4700::1111]", `{"ip":"2606:4700:4700::1111","version":6,"unspecified":false,"loopback":false,"private":false,"link_local":false,"multicast":false,"global_unicast":true,"public":true,"reverse_dns":"1.1.1.1.0.0.0.0.0.0.0.0.0.0.0.0.0.0.0.0.0.0.7.4.0.0.7.4.6.0.6.2.ip6.arpa."}`, ""},
+		{"multicast", "224.0.0.251", `{"ip":"224.0.0.251","version":4,"unspecified":false,"loopback":false,"private":false,"link_local":true,"multicast":true,"global_unicast":false,"public":false,"reverse_dns":"251.0.0.224.in-addr.arpa."}`, ""},
+		{"link_local_zone", "fe80::1%eth0", `{"ip":"fe80::1%eth0","version":6,"zone":"eth0","unspecified":false,"loopback":false,"private":false,"link_local":true,"multicast":false,"global_unicast":false,"public":false,"reverse_dns":"1.0.0.0.0.0.0.0.0.0.0.0.0.0.0.0.0.0.0.0.0.0.0.0.0.0.0.0.0.8.e.f.ip6.arpa."}`, ""},
+		{"ula", "fd00::1", `{"ip":"fd00::1","version":6,"unspecified":false,"loopback":false,"private":true,"link_local":false,"multicast":false,"global_unicast":true,"public":false,"reverse_dns":"1.0.0.0.0.0.0.0.0.0.0.0.0.0.0.0.0.0.0.0.0.0.0.0.0.0.0.0.0.0.d.f.ip6.arpa."}`, ""},
+		{"documentation", "192.0.2.7", `{"ip":"192.0.2.7","version":4,"unspecified":false,"loopback":false,"private":false,"link_local":false,"multicast":false,"global_unicast":true,"public":false,"special":"documentation, TEST-NET-1 (RFC 5737)","reverse_dns":"7.2.0.192.in-addr.arpa."}`, ""},
+		{"mapped", "::ffff:10.1.2.3", `{"ip":"::ffff:10.1.2.3","version":4,"ipv4_mapped":true,"unspecified":false,"loopback":false,"private":true,"link_local":false,"multicast":false,"global_unicast":true,"public":false,"reverse_dns":"3.0.2.0.1.0.a.0.f.f.f.f.0.0.0.0.0.0.0.0.0.0.0.0.0.0.0.0.0.0.0.0.ip6.arpa."}`, ""},
+		{"unspecified", "0.0.0.0", `{"ip":"0.0.0.0","version":4,"unspecified":true,"loopback":false,"private":false,"link_local":false,"multicast":false,"global_unicast":false,"public":false,"special":"this network (RFC 791)","reverse_dns":"0.0.0.0.in-addr.arpa."}`, ""},
+		{"malformed", "256.1.1.1", "", `invalid IP "256.1.1.1"`},
+		{"cidr", "10.0.0.0/8", "", `invalid IP "10.0.0.0/8"`},
+		{"empty", "", "", `invalid IP ""`},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := callback(t.Context(), &ipInfoArgs{IP: tt.ip})
+			if tt.errSubstr != "" {
+				if err == nil || !strings.Contains(err.Error(), tt.errSubstr) {
+					t.Fatalf("Expected error containing %q but got %v", tt.errSubstr, err)
+				}
+				return
+			}
+			if err != nil {
+				t.Fatalf("Unexpected error: %v", err)
+			}
+			if got != tt.expected {
+				t.Fatalf("Expected %q but got %q", tt.expected, got)
+			}
+		})
+	}
+}
@@ -0,0 +1,202 @@
+// Copyright 2025 Marc-Antoine Ruel. All rights reserved.
+// Use of this source code is governed under the Apache License, Version 2.0
+// that can be found in the LICENSE file.
+
+package genaitools
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"net/url"
+	"slices"
+	"strings"
+
+	"github.com/maruel/genai"
+)
+
+// NormalizeURL canonicalizes a URL so equivalent URLs compare equal.
+//
+// The scheme and host are lowercased, the default port is removed, "." and
+// ".." path segments are resolved, percent-encoding is normalized (unreserved
+// characters are decoded and hex digits uppercased), an empty path becomes "/"
+// and the query parameters are sorted by name. The relative order of repeated
+// parameters is kept since it can be significant. The fragment is optionally
+// removed.
+var NormalizeURL = genai.ToolDef{
+	Name:        "normalize_url",
+	Description: "Canonicalizes an absolute URL for comparison or deduplication: lowercases the scheme and host, removes the default port, resolves . and .. in the path, normalizes percent-encoding, sorts the query parameters and optionally strips the fragment.",
+	Callback:    doNormalizeURL,
+}
+
+type normalizeURLArgs struct {
+	URL           string `json:"url" jsonschema:"description=Absolute URL"`
+	StripFragment bool   `json:"strip_fragment,omitempty" jsonschema:"description=Remove the #fragment"`
+}
+
+var defaultPorts = map[string]string{
+	"ftp":   "21",
+	"http":  "80",
+	"https": "443",
+	"ws":    "80",
+	"wss":   "443",
+}
+
+func doNormalizeURL(ctx context.Context, args *normalizeURLArgs) (string, error) {
+	u, err := url.Parse(strings.TrimSpace(args.URL))
+	if err != nil {
+		return "", fmt.Errorf("invalid URL: %w", err)
+	}
+	if u.Scheme == "" {
+		return "", fmt.Errorf("invalid URL %q: missing scheme; it must be absolute like https://example.com/", args.URL)
+	}
+	scheme := strings.ToLower(u.Scheme)
+	if u.Opaque != "" {
+		// E.g. mailto:user@example.com.
+		return scheme + ":" + u.Opaque, nil
+	}
+	if u.Host == "" && (scheme == "http" || scheme == "https") {
+		return "", fmt.Errorf("invalid URL %q: missing host", args.URL)
+	}
+	var b strings.Builder
+	b.WriteString(scheme + "://")
+	if u.User != nil {
+		b.WriteString(u.User.String() + "@")
+	}
+	host := strings.TrimSuffix(strings.ToLower(u.Hostname()), ".")
+	if strings.Contains(host, ":") {
+		// IPv6 literal.
+		host = "[" + host + "]"
+	}
+	b.WriteString(host)
+	if port := u.Port(); port != "" && port != defaultPorts[scheme] {
+		b.WriteString(":" + port)
+	}
+	p, err := normalizeEscapes(u.EscapedPath())
+	if err != nil {
+		return "", fmt.Errorf("invalid URL path: %w", err)
+	}
+	if p = removeDotSegments(p); p == "" && u.Host != "" {
+		p = "/"
+	}
+	b.WriteString(p)
+	if u.RawQuery != "" {
+		q, err := normalizeQuery(u.RawQuery)
+		if err != nil {
+			return "", fmt.Errorf("invalid URL query: %w", err)
+		}
+		if q != "" {
+			b.WriteString("?" + q)
+		}
+	}
+	if u.Fragment != "" && !args.StripFragment {
+		b.WriteString("#" + u.EscapedFragment())
+	}
+	return b.String(), nil
+}
+
+// normalizeQuery sorts the query parameters by name, keeping the order of the
+// values of repeated parameters.
+func normalizeQuery(raw string) (string, error) {
+	type param struct{ name, value string }
+	var params []param
+	for kv := range strings.SplitSeq(raw, "&") {
+		if kv == "" {
+			continue
+		}
+		k, v, hasValue := strings.Cut(kv, "=")
+		k, err := normalizeEscapes(k)
+		if err != nil {
+			return "", err
+		}
+		if v, err = normalizeEscapes(v); err != nil {
+			return "", err
+		}
+		if hasValue {
+			v = "=" + v
+		}
+		params = append(params, param{k, v})
+	}
+	slices.SortStableFunc(params, func(a, b param) int {
+		return strings.Compare(a.name, b.name)
+	})
+	out := make([]string, len(params))
+	for i, p := range params {
+		out[i] = p.name + p.value
+	}
+	return strings.Join(out, "&"), nil
+}
+
+// normalizeEscapes decodes the percent-encoded unreserved characters and
+// uppercases the hex digits of the other escapes.
+func normalizeEscapes(s string) (string, error) {
+	if !strings.Contains(s, "%") {
+		return s, nil
+	}
+	var b strings.Builder
+	for i := 0; i < len(s); i++ {
+		if s[i] != '%' {
+			b.WriteByte(s[i])
+			continue
+		}
+		if i+2 >= len(s) || !isHexDigit(s[i+1]) || !isHexDigit(s[i+2]) {
+			return "", errors.New("invalid percent-encoding")
+		}
+		c := unhex(s[i+1])<<4 | unhex(s[i+2])
+		if isASCIILetter(c) || isDigit(c) || c == '-' || c == '.' || c == '_' || c == '~' {
+			b.WriteByte(c)
+		} else {
+			b.WriteString("%" + strings.ToUpper(s[i+1:i+3]))
+		}
+		i += 2
+	}
+	return b.String(), nil
+}
+
+// removeDotSegments implements RFC 3986 section 5.2.4.
+func removeDotSegments(p string) string {
+	var out []string
+	segs := strings.Split(p, "/")
+	for i, s := range segs {
+		last := i == len(segs)-1
+		switch s {
+		case ".":
+			if last {
+				out = append(out, "")
+			}
+		case "..":
+			if len(out) > 1 {
+				out = out[:len(out)-1]
+			}
+			if last {
+				out = append(out, "")
+			}
+		default:
+			out = append(out, s)
+		}
+	}
+	r := strings.Join(out, "/")
+	if strings.HasPrefix(p, "/") && !strings.HasPrefix(r, "/") {
+		r = "/" + r
+	}
+	return r
+}
+
+func isASCIILetter(c byte) bool {
+	return (c >= 'a' && c <= 'z') || (c >= 'A' && c <= 'Z')
+}
+
+func isHexDigit(c byte) bool {
+	return isDigit(c) || (c >= 'a' && c <= 'f') || (c >= 'A' && c <= 'F')
+}
+
+func unhex(c byte) byte {
+	switch {
+	case isDigit(c):
+		return c - '0'
+	case c >= 'a' && c <= 'f':
+		return c - 'a' + 10
+	default:
+		return c - 'A' + 10
+	}
+}
@@ -0,0 +1,60 @@
+// Copyright 2025 Marc-Antoine Ruel. All rights reserved.
+// Use of this source code is governed under the Apache License, Version 2.0
+// that can be found in the LICENSE file.
+
+package genaitools
+
+import (
+	"context"
+	"strings"
+	"testing"
+)
+
+func TestNormalizeURL(t *testing.T) {
+	callback := NormalizeURL.Callback.(func(context.Context, *normalizeURLArgs) (string, error))
+	tests := []struct {
+		name          string
+		url           string
+		stripFragment bool
+		expected      string
+		errSubstr     string
+	}{
+		{"case", "HTTP://Example.COM/Path", false, "http://example.com/Path", ""},
+		{"default_port", "https://example.com:443/a", false, "https://example.com/a", ""},
+		{"other_port", "http://example.com:8080/a", false, "http://example.com:8080/a", ""},
+		{"empty_path", "https://example.com", false, "https://example.com/", ""},
+		{"trailing_dot", "https://example.com./", false, "https://example.com/", ""},
+		{"dot_segments", "http://a.com/b/c/./../../g/./h/.", false, "http://a.com/g/h/", ""},
+		{"dot_above_root", "http://a.com/../../x", false, "http://a.com/x", ""},
+		{"escapes", "http://a.com/%7euser/%e2%82%ac%2Fx?q=%41%2f", false, "http://a.com/~user/%E2%82%AC%2Fx?q=A%2F", ""},
+		{"query_sorted", "http://a.com/?b=2&a=1&b=1&c", false, "http://a.com/?a=1&b=2&b=1&c", ""},
+		{"empty_query", "http://a.com/x?", false, "http://a.com/x", ""},
+		{"fragment", "http://a.com/x#Sec", false, "http://a.com/x#Sec", ""},
+		{"strip_fragment", "http://a.com/x#Sec", true, "http://a.com/x", ""},
+		{"userinfo", "https://User:pw@Example.com:443", false, "https://User:pw@example.com/", ""},
+		{"ipv6", "http://[2001:DB8::1]:80/", false, "http://[2001:db8::1]/", ""},
+		{"opaque", "MAILTO:someone@example.com", false, "mailto:someone@example.com", ""},
+		{"spaces", "  https://example.com/a b  ", false, "https://example.com/a%20b", ""},
+		{"relative", "/just/a/path", false, "", "missing scheme"},
+		{"no_host", "https:///path", false, "", "missing host"},
+		{"bad_escape", "http://a.com/%zz", false, "", "invalid URL"},
+		{"bad_query_escape", "http://a.com/?a=%z", false, "", "invalid URL query: invalid percent-encoding"},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := callback(t.Context(), &normalizeURLArgs{URL: tt.url, StripFragment: tt.stripFragment})
+			if tt.errSubstr != "" {
+				if err == nil || !strings.Contains(err.Error(), tt.errSubstr) {
+					t.Fatalf("Expected error containing %q but got %v", tt.errSubstr, err)
+				}
+				return
+			}
+			if err != nil {
+				t.Fatalf("Unexpected error: %v", err)
+			}
+			if got != tt.expected {
+				t.Fatalf("Expected %q but got %q", tt.expected, got)
+			}
+		})
+	}
+}
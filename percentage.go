@@ -0,0 +1,84 @@
+// Copyright 2025 Marc-Antoine Ruel. All rights reserved.
+// Use of this source code is governed under the Apache License, Version 2.0
+// that can be found in the LICENSE file.
+
+package genaitools
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"math"
+	"strconv"
+	"strings"
+
+	"github.com/maruel/genai"
+)
+
+// Percentage computes the common percentage questions.
+//
+//   - percent_of: a percent of b, e.g. 15% of 80 is 12.
+//   - change: the percent change from a to b, e.g. 80 to 60 is -25%.
+//   - is_what_percent: a as a percent of b, e.g. 12 is 15% of 80.
+//
+// Results are rounded to two decimals and keep at least one, e.g. "12.0" or
+// "33.33%".
+var Percentage = genai.ToolDef{
+	Name:        "percentage",
+	Description: "Computes percentages: percent_of returns a percent of b; change returns the percent change from a to b; is_what_percent returns a as a percent of b.",
+	Callback:    doPercentage,
+}
+
+type percentageArgs struct {
+	Operation string      `json:"operation" jsonschema:"description=percent_of is a% of b; change is the percent change from a to b; is_what_percent is a as a percent of b,enum=percent_of,enum=change,enum=is_what_percent"`
+	A         json.Number `json:"a" jsonschema:"type=number"`
+	B         json.Number `json:"b" jsonschema:"type=number"`
+}
+
+func doPercentage(ctx context.Context, args *percentageArgs) (string, error) {
+	a, err := args.A.Float64()
+	if err != nil {
+		return "", fmt.Errorf("couldn't understand a %q: %w", args.A, err)
+	}
+	b, err := args.B.Float64()
+	if err != nil {
+		return "", fmt.Errorf("couldn't understand b %q: %w", args.B, err)
+	}
+	var r float64
+	suffix := "%"
+	switch args.Operation {
+	case "percent_of":
+		r = a / 100 * b
+		suffix = ""
+	case "change":
+		if a == 0 {
+			return "", errors.New("the percent change from 0 is undefined")
+		}
+		r = (b - a) / math.Abs(a) * 100
+	case "is_what_percent":
+		if b == 0 {
+			return "", errDivideByZero
+		}
+		r = a / b * 100
+	default:
+		return "", fmt.Errorf("unknown operation %q; supported operations are percent_of, change and is_what_percent", args.Operation)
+	}
+	if math.IsInf(r, 0) || math.IsNaN(r) {
+		return "", fmt.Errorf("the result overflows: %v", r)
+	}
+	s := formatPercent(r)
+	if args.Operation == "change" && !strings.HasPrefix(s, "-") && s != "0.0" {
+		s = "+" + s
+	}
+	return s + suffix, nil
+}
+
+// formatPercent rounds to two decimals and trims a trailing zero.
+func formatPercent(v float64) string {
+	s := strings.TrimSuffix(strconv.FormatFloat(v, 'f', 2, 64), "0")
+	if s == "-0.0" {
+		return "0.0"
+	}
+	return s
+}
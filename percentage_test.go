@@ -0,0 +1,59 @@
+// Copyright 2025 Marc-Antoine Ruel. All rights reserved.
+// Use of this source code is governed under the Apache License, Version 2.0
+// that can be found in the LICENSE file.
+
+package genaitools
+
+import (
+	"context"
+	"strings"
+	"testing"
+)
+
+func TestPercentage(t *testing.T) {
+	callback := Percentage.Callback.(func(context.Context, *percentageArgs) (string, error))
+	tests := []struct {
+		name      string
+		args      percentageArgs
+		expected  string
+		errSubstr string
+	}{
+		{"percent_of", percentageArgs{"percent_of", "15", "80"}, "12.0", ""},
+		{"percent_of_fraction", percentageArgs{"percent_of", "7.5", "19.99"}, "1.5", ""},
+		{"percent_of_round", percentageArgs{"percent_of", "33", "10.1"}, "3.33", ""},
+		{"percent_of_negative", percentageArgs{"percent_of", "20", "-50"}, "-10.0", ""},
+		{"change_increase", percentageArgs{"change", "80", "100"}, "+25.0%", ""},
+		{"change_decrease", percentageArgs{"change", "80", "60"}, "-25.0%", ""},
+		{"change_none", percentageArgs{"change", "42", "42"}, "0.0%", ""},
+		{"change_thirds", percentageArgs{"change", "3", "2"}, "-33.33%", ""},
+		{"change_negative_base", percentageArgs{"change", "-50", "-25"}, "+50.0%", ""},
+		{"change_sign_flip", percentageArgs{"change", "-50", "50"}, "+200.0%", ""},
+		{"change_tiny", percentageArgs{"change", "1000000", "999999.99"}, "0.0%", ""},
+		{"is_what_percent", percentageArgs{"is_what_percent", "12", "80"}, "15.0%", ""},
+		{"is_what_percent_over", percentageArgs{"is_what_percent", "3", "2"}, "150.0%", ""},
+		{"is_what_percent_third", percentageArgs{"is_what_percent", "1", "3"}, "33.33%", ""},
+		{"change_from_zero", percentageArgs{"change", "0", "5"}, "", "the percent change from 0 is undefined"},
+		{"divide_by_zero", percentageArgs{"is_what_percent", "5", "0"}, "", "divide by zero"},
+		{"overflow", percentageArgs{"percent_of", "1e308", "1e308"}, "", "the result overflows"},
+		{"invalid_a", percentageArgs{"change", "x", "1"}, "", `couldn't understand a "x"`},
+		{"invalid_b", percentageArgs{"change", "1", ""}, "", `couldn't understand b ""`},
+		{"unknown", percentageArgs{"ratio", "1", "2"}, "", `unknown operation "ratio"`},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := callback(t.Context(), &tt.args)
+			if tt.errSubstr != "" {
+				if err == nil || !strings.Contains(err.Error(), tt.errSubstr) {
+					t.Fatalf("Expected error containing %q but got %v", tt.errSubstr, err)
+				}
+				return
+			}
+			if err != nil {
+				t.Fatalf("Unexpected error: %v", err)
+			}
+			if got != tt.expected {
+				t.Fatalf("Expected %q but got %q", tt.expected, got)
+			}
+		})
+	}
+}
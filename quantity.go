@@ -0,0 +1,196 @@
+// Copyright 2025 Marc-Antoine Ruel. All rights reserved.
+// Use of this source code is governed under the Apache License, Version 2.0
+// that can be found in the LICENSE file.
+
+package genaitools
+
+import (
+	"context"
+	"errors"
+	"fmt"
+
+	"github.com/maruel/genai"
+)
+
+// QuantityMath evaluates an expression mixing numbers and units, like
+// "5 km + 300 m" or "2 h * 3".
+//
+// Quantities are converted to the base unit of their dimension, computed, then
+// converted back to the unit of the first quantity in the expression or to the
+// requested unit. When the first quantity has another dimension than the
+// result, the base unit of the result's dimension is used. Length, mass, time, volume and data units are supported.
+var QuantityMath = genai.ToolDef{
+	Name:        "quantity_math",
+	Description: "Evaluates an expression mixing numbers and units (length, mass, time, volume, data), e.g. \"5 km + 300 m\" or \"2 h * 3\", and returns the result with its unit.",
+	Callback:    doQuantityMath,
+}
+
+type quantityMathArgs struct {
+	Expression string `json:"expression" jsonschema:"description=Expression like 5 km + 300 m"`
+	Unit       string `json:"unit,omitempty" jsonschema:"description=Unit of the result. Defaults to the unit of the first quantity"`
+}
+
+func doQuantityMath(ctx context.Context, args *quantityMathArgs) (string, error) {
+	toks, err := tokenize(args.Expression)
+	if err != nil {
+		return "", err
+	}
+	p := quantityParser{exprParser: exprParser{toks: toks}}
+	q, err := p.additive()
+	if err != nil {
+		return "", err
+	}
+	if t := p.peek(); t.kind != tokEOF {
+		return "", fmt.Errorf("unexpected %q at position %d", t.s, t.pos)
+	}
+	out := p.first
+	if out.dim != q.dim {
+		// The first unit was canceled out, e.g. "1 km / 1 m * 2 h".
+		out = baseUnit(q.dim)
+	}
+	if args.Unit != "" {
+		if out, err = lookupUnit(args.Unit); err != nil {
+			return "", err
+		}
+		if out.dim != q.dim {
+			return "", fmt.Errorf("cannot express %s in %s which is a %s unit", dimName(q.dim), out.name, out.dim)
+		}
+	}
+	if q.dim == "" {
+		return formatFloat(q.v), nil
+	}
+	return formatFloat(q.v/out.factor) + " " + out.name, nil
+}
+
+// quantity is a value in the base unit of its dimension. An empty dimension is
+// a plain number.
+type quantity struct {
+	v   float64
+	dim string
+}
+
+func dimName(dim string) string {
+	if dim == "" {
+		return "a number without unit"
+	}
+	return dim
+}
+
+// quantityParser is a recursive descent parser like exprParser that keeps
+// track of dimensions.
+type quantityParser struct {
+	exprParser
+	// first is the first unit seen, used to format the result.
+	first unit
+}
+
+func (p *quantityParser) additive() (quantity, error) {
+	l, err := p.term()
+	if err != nil {
+		return l, err
+	}
+	for {
+		op, ok := p.acceptOp("+", "-")
+		if !ok {
+			return l, nil
+		}
+		r, err := p.term()
+		if err != nil {
+			return r, err
+		}
+		if l.dim != r.dim {
+			verb := "add"
+			if op == "-" {
+				verb = "subtract"
+			}
+			return l, fmt.Errorf("cannot %s %s and %s", verb, dimName(l.dim), dimName(r.dim))
+		}
+		if op == "+" {
+			l.v += r.v
+		} else {
+			l.v -= r.v
+		}
+	}
+}
+
+func (p *quantityParser) term() (quantity, error) {
+	l, err := p.unary()
+	if err != nil {
+		return l, err
+	}
+	for {
+		op, ok := p.acceptOp("*", "/")
+		if !ok {
+			return l, nil
+		}
+		r, err := p.unary()
+		if err != nil {
+			return r, err
+		}
+		if op == "*" {
+			if l.dim != "" && r.dim != "" {
+				return l, fmt.Errorf("cannot multiply %s by %s", l.dim, r.dim)
+			}
+			l = quantity{v: l.v * r.v, dim: l.dim + r.dim}
+			continue
+		}
+		if r.v == 0 {
+			return l, errDivideByZero
+		}
+		switch {
+		case r.dim == "":
+			l.v /= r.v
+		case l.dim == r.dim:
+			// A ratio is a plain number.
+			l = quantity{v: l.v / r.v}
+		default:
+			return l, fmt.Errorf("cannot divide %s by %s", dimName(l.dim), r.dim)
+		}
+	}
+}
+
+func (p *quantityParser) unary() (quantity, error) {
+	if op, ok := p.acceptOp("-", "+"); ok {
+		q, err := p.unary()
+		if op == "-" {
+			q.v = -q.v
+		}
+		return q, err
+	}
+	return p.primary()
+}
+
+func (p *quantityParser) primary() (quantity, error) {
+	t := p.next()
+	switch t.kind {
+	case tokNum:
+		if u := p.peek(); u.kind == tokIdent {
+			p.next()
+			un, err := lookupUnit(u.s)
+			if err != nil {
+				return quantity{}, fmt.Errorf("%w at position %d", err, u.pos)
+			}
+			if p.first.name == "" {
+				p.first = un
+			}
+			return quantity{v: t.num * un.factor, dim: un.dim}, nil
+		}
+		return quantity{v: t.num}, nil
+	case tokIdent:
+		return quantity{}, fmt.Errorf("expected a number before %q at position %d", t.s, t.pos)
+	case tokOp:
+		if t.s == "(" {
+			q, err := p.additive()
+			if err != nil {
+				return q, err
+			}
+			if _, ok := p.acceptOp(")"); !ok {
+				return q, fmt.Errorf("unbalanced parentheses: missing \")\" for \"(\" at position %d", t.pos)
+			}
+			return q, nil
+		}
+	case tokEOF:
+		return quantity{}, errors.New("unexpected end of expression")
+	}
+	return quantity{}, fmt.Errorf("unexpected %q at position %d", t.s, t.pos)
+}
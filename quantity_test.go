@@ -0,0 +1,59 @@
+// Copyright 2025 Marc-Antoine Ruel. All rights reserved.
+// Use of this source code is governed under the Apache License, Version 2.0
+// that can be found in the LICENSE file.
+
+package genaitools
+
+import (
+	"context"
+	"strings"
+	"testing"
+)
+
+func TestQuantityMath(t *testing.T) {
+	callback := QuantityMath.Callback.(func(context.Context, *quantityMathArgs) (string, error))
+	tests := []struct {
+		name      string
+		expr      string
+		unit      string
+		expected  string
+		errSubstr string
+	}{
+		{"add_length", "5 km + 300 m", "", "5.300000 km", ""},
+		{"add_length_in_m", "5 km + 300 m", "m", "5300 m", ""},
+		{"multiply_time", "2 h * 3", "", "6 h", ""},
+		{"scalar_first", "3 * 2 hours", "min", "360 min", ""},
+		{"aliases", "1 mile - 1 foot", "ft", "5279 ft", ""},
+		{"divide", "10 kg / 4", "", "2.500000 kg", ""},
+		{"ratio", "1 km / 250 m", "", "4", ""},
+		{"ratio_then_unit", "5 km / 1 m * 2 h", "", "36000000 s", ""},
+		{"data", "1 GiB + 512 MiB", "MiB", "1536 MiB", ""},
+		{"parentheses", "(1 h + 30 min) * 2", "", "3 h", ""},
+		{"negative", "-2 d + 1 wk", "d", "5 d", ""},
+		{"plain_number", "2 + 3", "", "5", ""},
+		{"incompatible_add", "5 km + 2 h", "", "", "cannot add length and time"},
+		{"incompatible_sub", "5 km - 2", "", "", "cannot subtract length and a number without unit"},
+		{"multiply_units", "2 m * 3 m", "", "", "cannot multiply length by length"},
+		{"unknown_unit", "5 parsecs", "", "", `unknown unit "parsecs"`},
+		{"bad_target", "5 km", "kg", "", "cannot express length in kg"},
+		{"divide_by_zero", "5 km / 0", "", "", "cannot divide by zero"},
+		{"unit_without_number", "km + 5 m", "", "", "expected a number"},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := callback(t.Context(), &quantityMathArgs{Expression: tt.expr, Unit: tt.unit})
+			if tt.errSubstr != "" {
+				if err == nil || !strings.Contains(err.Error(), tt.errSubstr) {
+					t.Fatalf("Expected error containing %q but got %v", tt.errSubstr, err)
+				}
+				return
+			}
+			if err != nil {
+				t.Fatalf("Unexpected error: %v", err)
+			}
+			if got != tt.expected {
+				t.Fatalf("Expected %q but got %q", tt.expected, got)
+			}
+		})
+	}
+}
@@ -0,0 +1,234 @@
+// Copyright 2025 Marc-Antoine Ruel. All rights reserved.
+// Use of this source code is governed under the Apache License, Version 2.0
+// that can be found in the LICENSE file.
+
+package genaitools
+
+import (
+	"context"
+	"encoding/csv"
+	"errors"
+	"fmt"
+	"io"
+	"regexp"
+	"strconv"
+	"strings"
+
+	"github.com/maruel/genai"
+)
+
+// QueryCSV filters and projects the rows of a CSV document.
+//
+// The first row is used as the header when all its fields are non-empty,
+// unique and not numbers, unless overridden. Without header, the columns are
+// named A, B, C, etc. like in a spreadsheet.
+//
+// The filter is a single predicate "column op value" where op is one of =,
+// ==, !=, <, <=, >, >= or contains. Values are compared as numbers when both
+// sides are numbers and as strings otherwise.
+//
+// Quoted fields follow RFC 4180. Rows shorter than the header have null for
+// the missing cells and the extra cells of longer rows are named after their
+// spreadsheet column.
+var QueryCSV = genai.ToolDef{
+	Name:        "query_csv",
+	Description: "Selects rows and columns from a CSV document and returns the matching rows as a JSON array of objects keyed by column name. The filter is a single predicate like: price > 5, name = \"Bob\" or city contains york. Without header row, columns are named A, B, C.",
+	Callback:    doQueryCSV,
+}
+
+type queryCSVArgs struct {
+	CSV    string   `json:"csv" jsonschema:"description=CSV document"`
+	Select []string `json:"select,omitempty" jsonschema:"description=Columns to return in order; all when omitted"`
+	Where  string   `json:"where,omitempty" jsonschema:"description=Filter like: column > 5. Operators are =\\, !=\\, <\\, <=\\, >\\, >= and contains. Quote values or column names containing spaces or operators"`
+	Header *bool    `json:"header,omitempty" jsonschema:"description=Whether the first row is a header; detected when omitted"`
+}
+
+// csvPredicate is a parsed "column op value" filter.
+type csvPredicate struct {
+	column int
+	op     string
+	value  string
+	number float64
+	isNum  bool
+}
+
+var reCSVWhere = regexp.MustCompile(`^\s*(?:"([^"]*)"|(.+?))\s*(==|!=|<=|>=|=|<|>|\scontains\s)\s*(.*?)\s*$`)
+
+func doQueryCSV(ctx context.Context, args *queryCSVArgs) (string, error) {
+	r := csv.NewReader(strings.NewReader(args.CSV))
+	r.FieldsPerRecord = -1
+	var rows [][]string
+	for {
+		rec, err := r.Read()
+		if err == io.EOF {
+			break
+		}
+		if err != nil {
+			return "", fmt.Errorf("invalid CSV: %w", err)
+		}
+		rows = append(rows, rec)
+	}
+	if len(rows) == 0 {
+		return "", errors.New("empty CSV document")
+	}
+	hasHeader := isCSVHeader(rows[0])
+	if args.Header != nil {
+		hasHeader = *args.Header
+	}
+	var columns []string
+	if hasHeader {
+		columns = rows[0]
+		rows = rows[1:]
+	}
+	width := len(columns)
+	for _, row := range rows {
+		width = max(width, len(row))
+	}
+	for i := len(columns); i < width; i++ {
+		columns = append(columns, columnLetters(int64(i+1)))
+	}
+
+	selected := make([]int, 0, len(columns))
+	if len(args.Select) == 0 {
+		for i := range columns {
+			selected = append(selected, i)
+		}
+	}
+	for _, name := range args.Select {
+		i, err := csvColumn(columns, name)
+		if err != nil {
+			return "", err
+		}
+		selected = append(selected, i)
+	}
+	var pred *csvPredicate
+	if strings.TrimSpace(args.Where) != "" {
+		var err error
+		if pred, err = parseCSVWhere(columns, args.Where); err != nil {
+			return "", err
+		}
+	}
+
+	var out []string
+	for _, row := range rows {
+		if pred != nil && !pred.match(row) {
+			continue
+		}
+		var b strings.Builder
+		b.WriteByte('{')
+		for j, i := range selected {
+			if j != 0 {
+				b.WriteByte(',')
+			}
+			k, _ := marshalJSON(columns[i])
+			v := "null"
+			if i < len(row) {
+				v, _ = marshalJSON(row[i])
+			}
+			b.WriteString(k + ":" + v)
+		}
+		b.WriteByte('}')
+		out = append(out, b.String())
+	}
+	if len(out) == 0 {
+		return "[]", nil
+	}
+	return "[\n" + strings.Join(out, ",\n") + "\n]", nil
+}
+
+// isCSVHeader returns true if the row looks like a header row.
+func isCSVHeader(row []string) bool {
+	seen := map[string]bool{}
+	for _, f := range row {
+		f = strings.TrimSpace(f)
+		if f == "" || seen[f] {
+			return false
+		}
+		if _, err := strconv.ParseFloat(f, 64); err == nil {
+			return false
+		}
+		seen[f] = true
+	}
+	return true
+}
+
+// csvColumn returns the index of the column name, falling back to a case
+// insensitive match.
+func csvColumn(columns []string, name string) (int, error) {
+	found := -1
+	for i, c := range columns {
+		if c == name {
+			return i, nil
+		}
+		if strings.EqualFold(strings.TrimSpace(c), strings.TrimSpace(name)) {
+			if found != -1 {
+				return 0, fmt.Errorf("column %q is ambiguous", name)
+			}
+			found = i
+		}
+	}
+	if found == -1 {
+		return 0, fmt.Errorf("unknown column %q; columns are: %s", name, strings.Join(columns, ", "))
+	}
+	return found, nil
+}
+
+func parseCSVWhere(columns []string, where string) (*csvPredicate, error) {
+	m := reCSVWhere.FindStringSubmatch(where)
+	if m == nil {
+		return nil, fmt.Errorf("invalid where %q: expected column op value with op one of =, !=, <, <=, >, >=, contains", where)
+	}
+	name := m[1] + m[2]
+	i, err := csvColumn(columns, name)
+	if err != nil {
+		return nil, fmt.Errorf("invalid where %q: %w", where, err)
+	}
+	p := &csvPredicate{column: i, op: strings.TrimSpace(m[3]), value: m[4]}
+	if p.op == "==" {
+		p.op = "="
+	}
+	if len(p.value) >= 2 && (p.value[0] == '"' || p.value[0] == '\'') && p.value[len(p.value)-1] == p.value[0] {
+		p.value = p.value[1 : len(p.value)-1]
+	} else if p.number, err = strconv.ParseFloat(p.value, 64); err == nil {
+		p.isNum = true
+	}
+	return p, nil
+}
+
+func (p *csvPredicate) match(row []string) bool {
+	if p.column >= len(row) {
+		return false
+	}
+	cell := row[p.column]
+	if p.op == "contains" {
+		return strings.Contains(cell, p.value)
+	}
+	c := 0
+	if n, err := strconv.ParseFloat(strings.TrimSpace(cell), 64); p.isNum && err == nil {
+		switch {
+		case n < p.number:
+			c = -1
+		case n > p.number:
+			c = 1
+		}
+	} else if p.isNum && p.op != "=" && p.op != "!=" {
+		// Do not compare a number with a non-number.
+		return false
+	} else {
+		c = strings.Compare(cell, p.value)
+	}
+	switch p.op {
+	case "=":
+		return c == 0
+	case "!=":
+		return c != 0
+	case "<":
+		return c < 0
+	case "<=":
+		return c <= 0
+	case ">":
+		return c > 0
+	default:
+		return c >= 0
+	}
+}
@@ -0,0 +1,73 @@
+// Copyright 2025 Marc-Antoine Ruel. All rights reserved.
+// Use of this source code is governed under the Apache License, Version 2.0
+// that can be found in the LICENSE file.
+
+package genaitools
+
+import (
+	"context"
+	"strings"
+	"testing"
+)
+
+func TestQueryCSV(t *testing.T) {
+	callback := QueryCSV.Callback.(func(context.Context, *queryCSVArgs) (string, error))
+	const doc = "name,city,price\n" +
+		"apple,New York,1.50\n" +
+		"\"pear, green\",Paris,10\n" +
+		"\"say \"\"hi\"\"\",york,2\n" +
+		"short\n" +
+		"kiwi,Rome,n/a,extra\n"
+	no := false
+	tests := []struct {
+		name      string
+		csv       string
+		sel       []string
+		where     string
+		header    *bool
+		expected  string
+		errSubstr string
+	}{
+		{"all", "a,b\n1,2\n", nil, "", nil, "[\n{\"a\":\"1\",\"b\":\"2\"}\n]", ""},
+		{"select", doc, []string{"price", "Name"}, "", nil, "[\n" +
+			"{\"price\":\"1.50\",\"name\":\"apple\"},\n" +
+			"{\"price\":\"10\",\"name\":\"pear, green\"},\n" +
+			"{\"price\":\"2\",\"name\":\"say \\\"hi\\\"\"},\n" +
+			"{\"price\":null,\"name\":\"short\"},\n" +
+			"{\"price\":\"n/a\",\"name\":\"kiwi\"}\n]", ""},
+		{"numeric", doc, []string{"name"}, "price > 5", nil, "[\n{\"name\":\"pear, green\"}\n]", ""},
+		{"numeric_le", doc, []string{"name"}, "price<=2", nil, "[\n{\"name\":\"apple\"},\n{\"name\":\"say \\\"hi\\\"\"}\n]", ""},
+		{"numeric_eq", doc, []string{"name"}, "price == 1.5", nil, "[\n{\"name\":\"apple\"}\n]", ""},
+		{"string_eq", doc, []string{"name"}, `city = "Paris"`, nil, "[\n{\"name\":\"pear, green\"}\n]", ""},
+		{"string_ne", doc, []string{"name"}, "city != 'Paris'", nil, "[\n{\"name\":\"apple\"},\n{\"name\":\"say \\\"hi\\\"\"},\n{\"name\":\"kiwi\"}\n]", ""},
+		{"contains", doc, []string{"name"}, "city contains York", nil, "[\n{\"name\":\"apple\"}\n]", ""},
+		{"quoted_column", "unit price,x\n3,y\n", []string{"x"}, `"unit price" >= 3`, nil, "[\n{\"x\":\"y\"}\n]", ""},
+		{"no_match", doc, nil, "price > 100", nil, "[]", ""},
+		{"extra_cells", doc, []string{"D"}, "name = kiwi", nil, "[\n{\"D\":\"extra\"}\n]", ""},
+		{"no_header", "1,2\n3,4\n", []string{"B"}, "A > 1", nil, "[\n{\"B\":\"4\"}\n]", ""},
+		{"header_override", "x,y\nz,w\n", nil, "", &no, "[\n{\"A\":\"x\",\"B\":\"y\"},\n{\"A\":\"z\",\"B\":\"w\"}\n]", ""},
+		{"unknown_column", doc, []string{"color"}, "", nil, "", `unknown column "color"; columns are: name, city, price, D`},
+		{"unknown_where_column", doc, nil, "color = red", nil, "", `invalid where "color = red": unknown column "color"`},
+		{"bad_where", doc, nil, "price", nil, "", "expected column op value"},
+		{"unterminated_quote", "a,b\n\"x,y\n", nil, "", nil, "", "invalid CSV: parse error on line 2, column 6: extraneous or missing \" in quoted-field"},
+		{"bare_quote", "a,b\nx\"y,z\n", nil, "", nil, "", "invalid CSV: parse error on line 2, column 2: bare \" in non-quoted-field"},
+		{"empty", "", nil, "", nil, "", "empty CSV document"},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := callback(t.Context(), &queryCSVArgs{CSV: tt.csv, Select: tt.sel, Where: tt.where, Header: tt.header})
+			if tt.errSubstr != "" {
+				if err == nil || !strings.Contains(err.Error(), tt.errSubstr) {
+					t.Fatalf("Expected error containing %q but got %v", tt.errSubstr, err)
+				}
+				return
+			}
+			if err != nil {
+				t.Fatalf("Unexpected error: %v", err)
+			}
+			if got != tt.expected {
+				t.Fatalf("Expected %q but got %q", tt.expected, got)
+			}
+		})
+	}
+}
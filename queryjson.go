@@ -0,0 +1,200 @@
+// Copyright 2025 Marc-Antoine Ruel. All rights reserved.
+// Use of this source code is governed under the Apache License, Version 2.0
+// that can be found in the LICENSE file.
+
+package genaitools
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"maps"
+	"slices"
+	"strconv"
+	"strings"
+
+	"github.com/maruel/genai"
+)
+
+// QueryJSON selects values from a JSON document with a JSONPath-like path.
+//
+// The path starts with an optional "$" followed by ".key" or "['key']" to
+// select an object member, "[n]" to select an array element (negative indices
+// count from the end) and ".*" or "[*]" to select all the members or elements.
+//
+// A path without wildcard returns the value: strings are returned as is and
+// other values as JSON. A path with wildcards returns the JSON array of the
+// matches. An error is returned when nothing matches.
+var QueryJSON = genai.ToolDef{
+	Name:        "query_json",
+	Description: "Selects a value from a JSON document with a JSONPath-like path like $.items[0].name or $.items[*].id and returns it; strings as is and other values as JSON.",
+	Callback:    doQueryJSON,
+}
+
+type queryJSONArgs struct {
+	JSON string `json:"json" jsonschema:"description=JSON document"`
+	Path string `json:"path" jsonschema:"description=Path like $.items[0].name. Use * to select all the members or elements"`
+}
+
+// jsonPathSegment is one step of a path: a key, an index or a wildcard.
+type jsonPathSegment struct {
+	key      string
+	index    int
+	isIndex  bool
+	wildcard bool
+}
+
+func (s *jsonPathSegment) String() string {
+	switch {
+	case s.wildcard:
+		return "[*]"
+	case s.isIndex:
+		return "[" + strconv.Itoa(s.index) + "]"
+	case isJSONPathName(s.key):
+		return "." + s.key
+	default:
+		return "[" + strconv.Quote(s.key) + "]"
+	}
+}
+
+func doQueryJSON(ctx context.Context, args *queryJSONArgs) (string, error) {
+	segs, err := parseJSONPath(args.Path)
+	if err != nil {
+		return "", err
+	}
+	doc, err := parseDocument(args.JSON, "json")
+	if err != nil {
+		return "", err
+	}
+	wildcard := false
+	nodes := []any{doc}
+	prefix := "$"
+	for i := range segs {
+		s := &segs[i]
+		wildcard = wildcard || s.wildcard
+		var next []any
+		var miss error
+		for _, n := range nodes {
+			v, err := selectJSONPath(n, s)
+			if err != nil {
+				miss = err
+			}
+			next = append(next, v...)
+		}
+		if len(next) == 0 {
+			if miss == nil || len(nodes) != 1 {
+				miss = errors.New("no match")
+			}
+			return "", fmt.Errorf("path %q does not match at %s%s: %w", args.Path, prefix, s, miss)
+		}
+		nodes = next
+		prefix += s.String()
+	}
+	if wildcard {
+		return marshalJSON(nodes)
+	}
+	if str, ok := nodes[0].(string); ok {
+		return str, nil
+	}
+	return marshalJSON(nodes[0])
+}
+
+// selectJSONPath returns the values of v selected by s.
+func selectJSONPath(v any, s *jsonPathSegment) ([]any, error) {
+	switch t := v.(type) {
+	case map[string]any:
+		if s.wildcard {
+			// Sort the keys for a deterministic output.
+			var out []any
+			for _, k := range slices.Sorted(maps.Keys(t)) {
+				out = append(out, t[k])
+			}
+			return out, nil
+		}
+		if s.isIndex {
+			return nil, fmt.Errorf("cannot index an object with %d", s.index)
+		}
+		if x, ok := t[s.key]; ok {
+			return []any{x}, nil
+		}
+		return nil, fmt.Errorf("key %q not found", s.key)
+	case []any:
+		if s.wildcard {
+			return t, nil
+		}
+		if !s.isIndex {
+			return nil, fmt.Errorf("cannot select key %q in an array", s.key)
+		}
+		i := s.index
+		if i < 0 {
+			i += len(t)
+		}
+		if i < 0 || i >= len(t) {
+			return nil, fmt.Errorf("index %d out of range for an array of length %d", s.index, len(t))
+		}
+		return []any{t[i]}, nil
+	default:
+		return nil, fmt.Errorf("cannot select into %s", valueType(v))
+	}
+}
+
+// parseJSONPath splits a path like "$.a[0]['b c'].*" into segments.
+func parseJSONPath(path string) ([]jsonPathSegment, error) {
+	p := strings.TrimSpace(path)
+	p = strings.TrimPrefix(p, "$")
+	var segs []jsonPathSegment
+	for first := true; p != ""; first = false {
+		switch {
+		case p[0] == '.' || first && p[0] != '[':
+			if p[0] == '.' {
+				p = p[1:]
+			}
+			if strings.HasPrefix(p, "*") {
+				segs = append(segs, jsonPathSegment{wildcard: true})
+				p = p[1:]
+				continue
+			}
+			end := strings.IndexAny(p, ".[")
+			if end < 0 {
+				end = len(p)
+			}
+			if end == 0 {
+				return nil, fmt.Errorf("invalid path %q: empty key", path)
+			}
+			segs = append(segs, jsonPathSegment{key: p[:end]})
+			p = p[end:]
+		case p[0] == '[':
+			end := strings.IndexByte(p, ']')
+			if p[1:] != "" && (p[1] == '\'' || p[1] == '"') {
+				// The key may contain ']'.
+				q := p[1]
+				k := strings.IndexByte(p[2:], q)
+				if k < 0 || len(p) < k+4 || p[k+3] != ']' {
+					return nil, fmt.Errorf("invalid path %q: unterminated quoted key", path)
+				}
+				segs = append(segs, jsonPathSegment{key: p[2 : k+2]})
+				p = p[k+4:]
+				continue
+			}
+			if end < 0 {
+				return nil, fmt.Errorf("invalid path %q: missing ']'", path)
+			}
+			inner := strings.TrimSpace(p[1:end])
+			if inner == "*" {
+				segs = append(segs, jsonPathSegment{wildcard: true})
+			} else if i, err := strconv.Atoi(inner); err == nil {
+				segs = append(segs, jsonPathSegment{index: i, isIndex: true})
+			} else {
+				return nil, fmt.Errorf("invalid path %q: %q is not an index; quote keys like ['key']", path, inner)
+			}
+			p = p[end+1:]
+		default:
+			return nil, fmt.Errorf("invalid path %q: unexpected %q", path, p[0])
+		}
+	}
+	return segs, nil
+}
+
+func isJSONPathName(s string) bool {
+	return s != "" && !strings.ContainsAny(s, ".[]'\" *")
+}
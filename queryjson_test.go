@@ -0,0 +1,84 @@
+// Copyright 2025 Marc-Antoine Ruel. All rights reserved.
+// Use of this source code is governed under the Apache License, Version 2.0
+// that can be found in the LICENSE file.
+
+package genaitools
+
+import (
+	"context"
+	"strings"
+	"testing"
+)
+
+func TestQueryJSON(t *testing.T) {
+	callback := QueryJSON.Callback.(func(context.Context, *queryJSONArgs) (string, error))
+	const doc = `{
+		"items": [
+			{"name": "apple", "price": 1.50, "tags": ["red", "fruit"]},
+			{"name": "<b>", "price": 10000000000000000001, "tags": []}
+		],
+		"meta": {"count": 2, "ok": true, "next": null, "a.b": "dotted", "z": 1},
+		"empty": {}
+	}`
+	tests := []struct {
+		name      string
+		path      string
+		expected  string
+		errSubstr string
+	}{
+		{"string", "$.items[0].name", "apple", ""},
+		{"no_dollar", "items[0].name", "apple", ""},
+		{"number", "$.items[0].price", "1.50", ""},
+		{"big_number", "$.items[1].price", "10000000000000000001", ""},
+		{"html", "$.items[1].name", "<b>", ""},
+		{"bool", "$.meta.ok", "true", ""},
+		{"null", "$.meta.next", "null", ""},
+		{"negative_index", "$.items[-1].tags", "[]", ""},
+		{"object", "$.items[0].tags", `["red","fruit"]`, ""},
+		{"quoted_key", `$.meta['a.b']`, "dotted", ""},
+		{"double_quoted_key", `$["meta"]["count"]`, "2", ""},
+		{"root", "$", "", ""},
+		{"wildcard_array", "$.items[*].name", `["apple","<b>"]`, ""},
+		{"wildcard_object", "$.meta.*", `["dotted",2,null,true,1]`, ""},
+		{"nested_wildcards", "$.items[*].tags[*]", `["red","fruit"]`, ""},
+		{"wildcard_partial", "$.items[*].tags[1]", `["fruit"]`, ""},
+		{"missing_key", "$.items[0].color", "", `path "$.items[0].color" does not match at $.items[0].color: key "color" not found`},
+		{"out_of_range", "$.items[5]", "", "does not match at $.items[5]: index 5 out of range for an array of length 2"},
+		{"key_in_array", "$.items.name", "", `cannot select key "name" in an array`},
+		{"index_in_object", "$.meta[0]", "", "cannot index an object with 0"},
+		{"into_scalar", "$.meta.count.x", "", "cannot select into integer"},
+		{"wildcard_no_match", "$.items[*].tags[5]", "", "does not match at $.items[*].tags[5]: no match"},
+		{"wildcard_empty", "$.empty.*", "", "does not match at $.empty[*]: no match"},
+		{"bad_index", "$.items[x]", "", `"x" is not an index; quote keys like ['key']`},
+		{"unterminated", "$.items[0", "", "missing ']'"},
+		{"unterminated_quote", "$['items", "", "unterminated quoted key"},
+		{"empty_key", "$.items..name", "", "empty key"},
+		{"trailing", "$.items[0]x", "", `unexpected 'x'`},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := callback(t.Context(), &queryJSONArgs{JSON: doc, Path: tt.path})
+			if tt.errSubstr != "" {
+				if err == nil || !strings.Contains(err.Error(), tt.errSubstr) {
+					t.Fatalf("Expected error containing %q but got %v", tt.errSubstr, err)
+				}
+				return
+			}
+			if err != nil {
+				t.Fatalf("Unexpected error: %v", err)
+			}
+			if tt.name == "root" {
+				if !strings.HasPrefix(got, `{"empty":{},"items":[`) {
+					t.Fatalf("Expected the whole document but got %q", got)
+				}
+				return
+			}
+			if got != tt.expected {
+				t.Fatalf("Expected %q but got %q", tt.expected, got)
+			}
+		})
+	}
+	if _, err := callback(t.Context(), &queryJSONArgs{JSON: "{", Path: "$"}); err == nil || !strings.Contains(err.Error(), "invalid JSON") {
+		t.Fatalf("Expected invalid JSON error but got %v", err)
+	}
+}
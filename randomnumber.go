@@ -0,0 +1,58 @@
+// Copyright 2025 Marc-Antoine Ruel. All rights reserved.
+// Use of this source code is governed under the Apache License, Version 2.0
+// that can be found in the LICENSE file.
+
+package genaitools
+
+import (
+	"context"
+	"crypto/rand"
+	"fmt"
+	"math/big"
+	"strings"
+
+	"github.com/maruel/genai"
+)
+
+// RandomNumber returns uniformly distributed random integers in an inclusive
+// range, one per line.
+//
+// It uses crypto/rand, which has no modulo bias and is suitable for secrets
+// like PINs.
+var RandomNumber = genai.ToolDef{
+	Name:        "random_number",
+	Description: "Returns cryptographically secure, uniformly distributed random integers between min and max inclusive, one per line. Use it instead of making up random numbers.",
+	Callback:    doRandomNumber,
+}
+
+type randomNumberArgs struct {
+	Min   int64 `json:"min" jsonschema:"description=Smallest possible value"`
+	Max   int64 `json:"max" jsonschema:"description=Largest possible value"`
+	Count int   `json:"count,omitempty" jsonschema:"description=Number of values to return. Defaults to 1,minimum=1,maximum=1000"`
+}
+
+func doRandomNumber(ctx context.Context, args *randomNumberArgs) (string, error) {
+	if args.Min > args.Max {
+		return "", fmt.Errorf("min %d is greater than max %d", args.Min, args.Max)
+	}
+	n := args.Count
+	if n == 0 {
+		n = 1
+	}
+	if n < 1 || n > 1000 {
+		return "", fmt.Errorf("invalid count %d; must be between 1 and 1000", args.Count)
+	}
+	lo := big.NewInt(args.Min)
+	// The size of the range may overflow int64.
+	size := new(big.Int).Sub(big.NewInt(args.Max), lo)
+	size.Add(size, big.NewInt(1))
+	out := make([]string, n)
+	for i := range out {
+		v, err := rand.Int(rand.Reader, size)
+		if err != nil {
+			return "", err
+		}
+		out[i] = v.Add(v, lo).String()
+	}
+	return strings.Join(out, "\n"), nil
+}
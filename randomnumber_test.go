@@ -0,0 +1,74 @@
+// Copyright 2025 Marc-Antoine Ruel. All rights reserved.
+// Use of this source code is governed under the Apache License, Version 2.0
+// that can be found in the LICENSE file.
+
+package genaitools
+
+import (
+	"context"
+	"math"
+	"strconv"
+	"strings"
+	"testing"
+)
+
+func TestRandomNumber(t *testing.T) {
+	callback := RandomNumber.Callback.(func(context.Context, *randomNumberArgs) (string, error))
+	got, err := callback(t.Context(), &randomNumberArgs{Min: -3, Max: 3, Count: 1000})
+	if err != nil {
+		t.Fatal(err)
+	}
+	seen := map[int64]bool{}
+	for _, l := range strings.Split(got, "\n") {
+		v, err := strconv.ParseInt(l, 10, 64)
+		if err != nil {
+			t.Fatal(err)
+		}
+		if v < -3 || v > 3 {
+			t.Fatalf("Value %d out of range [-3, 3]", v)
+		}
+		seen[v] = true
+	}
+	if len(seen) != 7 {
+		t.Fatalf("Expected all 7 values over 1000 draws but got %v", seen)
+	}
+	tests := []struct {
+		name      string
+		args      randomNumberArgs
+		expected  string
+		errSubstr string
+	}{
+		{"equal", randomNumberArgs{Min: 42, Max: 42}, "42", ""},
+		{"equal_count", randomNumberArgs{Min: -1, Max: -1, Count: 3}, "-1\n-1\n-1", ""},
+		{"reversed", randomNumberArgs{Min: 2, Max: 1}, "", "min 2 is greater than max 1"},
+		{"bad_count", randomNumberArgs{Min: 1, Max: 2, Count: 1001}, "", "invalid count 1001"},
+		{"negative_count", randomNumberArgs{Min: 1, Max: 2, Count: -1}, "", "invalid count -1"},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := callback(t.Context(), &tt.args)
+			if tt.errSubstr != "" {
+				if err == nil || !strings.Contains(err.Error(), tt.errSubstr) {
+					t.Fatalf("Expected error containing %q but got %v", tt.errSubstr, err)
+				}
+				return
+			}
+			if err != nil {
+				t.Fatalf("Unexpected error: %v", err)
+			}
+			if got != tt.expected {
+				t.Fatalf("Expected %q but got %q", tt.expected, got)
+			}
+		})
+	}
+	// The full int64 range doesn't overflow.
+	got, err = callback(t.Context(), &randomNumberArgs{Min: math.MinInt64, Max: math.MaxInt64, Count: 10})
+	if err != nil {
+		t.Fatal(err)
+	}
+	for _, l := range strings.Split(got, "\n") {
+		if _, err := strconv.ParseInt(l, 10, 64); err != nil {
+			t.Fatal(err)
+		}
+	}
+}
@@ -0,0 +1,98 @@
+// Copyright 2025 Marc-Antoine Ruel. All rights reserved.
+// Use of this source code is governed under the Apache License, Version 2.0
+// that can be found in the LICENSE file.
+
+package genaitools
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"net"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/maruel/genai"
+)
+
+// reachableTimeout is the timeout of the TCP connection of NewReachable.
+const reachableTimeout = 5 * time.Second
+
+// NewReachable returns a tool that checks whether a TCP port is reachable by
+// connecting to it.
+//
+// A TCP connection is used instead of ICMP ping since it needs no privilege
+// and firewalls often block ICMP. The connection is closed right away. The
+// connection times out after 5 seconds.
+func NewReachable() genai.ToolDef {
+	return genai.ToolDef{
+		Name:        "reachable",
+		Description: "Checks whether a host accepts TCP connections on a port and measures the connection latency. Returns JSON.",
+		Callback:    doReachable,
+	}
+}
+
+type reachableArgs struct {
+	Host string `json:"host" jsonschema:"description=Host name or IP address"`
+	Port int    `json:"port" jsonschema:"minimum=1,maximum=65535,description=TCP port like 443"`
+}
+
+type reachableResult struct {
+	Reachable bool    `json:"reachable"`
+	Address   string  `json:"address,omitempty"`
+	LatencyMS float64 `json:"latency_ms,omitempty"`
+	Error     string  `json:"error,omitempty"`
+}
+
+func doReachable(ctx context.Context, args *reachableArgs) (string, error) {
+	host := strings.TrimSpace(args.Host)
+	// Accept the bracketed IPv6 form.
+	if strings.HasPrefix(host, "[") && strings.HasSuffix(host, "]") {
+		host = host[1 : len(host)-1]
+	}
+	if host == "" {
+		return "", errors.New("missing host")
+	}
+	if args.Port < 1 || args.Port > 65535 {
+		return "", fmt.Errorf("invalid port %d; it must be between 1 and 65535", args.Port)
+	}
+	d := net.Dialer{Timeout: reachableTimeout}
+	start := time.Now()
+	conn, err := d.DialContext(ctx, "tcp", net.JoinHostPort(host, strconv.Itoa(args.Port)))
+	elapsed := time.Since(start)
+	var res reachableResult
+	if err != nil {
+		if ctx.Err() != nil {
+			return "", ctx.Err()
+		}
+		res.Error = reachableError(err)
+	} else {
+		res.Reachable = true
+		res.Address = conn.RemoteAddr().String()
+		res.LatencyMS = float64(elapsed.Microseconds()) / 1000
+		_ = conn.Close()
+	}
+	return marshalJSON(res)
+}
+
+// reachableError describes a dial error without the redundant details.
+func reachableError(err error) string {
+	var dnsErr *net.DNSError
+	var netErr net.Error
+	switch {
+	case errors.As(err, &dnsErr):
+		if dnsErr.IsNotFound {
+			return "host not found"
+		}
+		return "DNS lookup failed: " + dnsErr.Err
+	case errors.As(err, &netErr) && netErr.Timeout():
+		return "timed out after " + reachableTimeout.String()
+	default:
+		var opErr *net.OpError
+		if errors.As(err, &opErr) && opErr.Err != nil {
+			return opErr.Err.Error()
+		}
+		return err.Error()
+	}
+}
@@ -0,0 +1,82 @@
+// Copyright 2025 Marc-Antoine Ruel. All rights reserved.
+// Use of this source code is governed under the Apache License, Version 2.0
+// that can be found in the LICENSE file.
+
+package genaitools
+
+import (
+	"context"
+	"encoding/json"
+	"net"
+	"strings"
+	"testing"
+)
+
+func TestReachable(t *testing.T) {
+	callback := NewReachable().Callback.(func(context.Context, *reachableArgs) (string, error))
+	l, err := net.Listen("tcp", "127.0.0.1:0")
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer l.Close()
+	go func() {
+		for {
+			c, err := l.Accept()
+			if err != nil {
+				return
+			}
+			_ = c.Close()
+		}
+	}()
+	open := l.Addr().(*net.TCPAddr).Port
+	// Find a closed port by closing a listener.
+	l2, err := net.Listen("tcp", "127.0.0.1:0")
+	if err != nil {
+		t.Fatal(err)
+	}
+	closed := l2.Addr().(*net.TCPAddr).Port
+	_ = l2.Close()
+
+	tests := []struct {
+		name      string
+		host      string
+		port      int
+		reachable bool
+		address   string
+		errSubstr string
+	}{
+		{"open", "127.0.0.1", open, true, l.Addr().String(), ""},
+		{"bracketed", "[127.0.0.1]", open, true, l.Addr().String(), ""},
+		{"closed", "127.0.0.1", closed, false, "", ""},
+		{"empty_host", " ", 80, false, "", "missing host"},
+		{"port_zero", "127.0.0.1", 0, false, "", "invalid port 0"},
+		{"port_high", "127.0.0.1", 65536, false, "", "invalid port 65536"},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := callback(t.Context(), &reachableArgs{Host: tt.host, Port: tt.port})
+			if tt.errSubstr != "" {
+				if err == nil || !strings.Contains(err.Error(), tt.errSubstr) {
+					t.Fatalf("Expected error containing %q but got %v", tt.errSubstr, err)
+				}
+				return
+			}
+			if err != nil {
+				t.Fatalf("Unexpected error: %v", err)
+			}
+			var res reachableResult
+			if err = json.Unmarshal([]byte(got), &res); err != nil {
+				t.Fatal(err)
+			}
+			if res.Reachable != tt.reachable || res.Address != tt.address {
+				t.Fatalf("Unexpected result %s", got)
+			}
+			if tt.reachable && (res.LatencyMS < 0 || res.Error != "") {
+				t.Fatalf("Unexpected result %s", got)
+			}
+			if !tt.reachable && !strings.Contains(res.Error, "refused") {
+				t.Fatalf("Expected connection refused but got %s", got)
+			}
+		})
+	}
+}
@@ -0,0 +1,104 @@
+// Copyright 2025 Marc-Antoine Ruel. All rights reserved.
+// Use of this source code is governed under the Apache License, Version 2.0
+// that can be found in the LICENSE file.
+
+package genaitools
+
+import (
+	"bytes"
+	"context"
+	"errors"
+	"fmt"
+	"io"
+	"os"
+	"path/filepath"
+	"strings"
+	"unicode/utf8"
+
+	"github.com/maruel/genai"
+)
+
+// maxReadFile is the maximum number of bytes returned by the read file tool.
+const maxReadFile = 256 << 10
+
+// NewReadFile returns a tool that reads a text file inside root.
+//
+// Paths passed by the LLM are relative to root and cannot escape it, either
+// via ".." or via symlinks. The content is truncated after 256KiB. Binary
+// files are reported as an error instead of being returned.
+func NewReadFile(root string) genai.ToolDef {
+	return genai.ToolDef{
+		Name:        "read_file",
+		Description: "Reads a text file and returns its content.",
+		Callback: func(ctx context.Context, args *readFileArgs) (string, error) {
+			return doReadFile(root, args)
+		},
+	}
+}
+
+type readFileArgs struct {
+	Path string `json:"path" jsonschema:"description=Relative path of the file to read"`
+}
+
+func doReadFile(root string, args *readFileArgs) (string, error) {
+	// statInRoot gives clear errors; the read itself goes through os.Root so a
+	// symlink swapped in after the check still cannot escape root.
+	full, fi, err := statInRoot(root, args.Path)
+	if err != nil {
+		return "", err
+	}
+	if fi.IsDir() {
+		return "", fmt.Errorf("path %q is a directory", args.Path)
+	}
+	if !fi.Mode().IsRegular() {
+		return "", fmt.Errorf("path %q is not a regular file", args.Path)
+	}
+	// Open the resolved path since os.Root refuses absolute symlinks, even when
+	// they point inside root.
+	rootAbs, err := filepath.Abs(root)
+	if err != nil {
+		return "", err
+	}
+	rootReal, err := filepath.EvalSymlinks(rootAbs)
+	if err != nil {
+		return "", fmt.Errorf("invalid root directory: %w", err)
+	}
+	rel, err := filepath.Rel(rootReal, full)
+	if err != nil {
+		return "", err
+	}
+	r, err := os.OpenRoot(rootReal)
+	if err != nil {
+		return "", fmt.Errorf("invalid root directory: %w", err)
+	}
+	defer r.Close()
+	f, err := r.Open(rel)
+	if err != nil {
+		return "", fmt.Errorf("path %q: %w", args.Path, errors.Unwrap(err))
+	}
+	defer f.Close()
+	if fi, err = f.Stat(); err != nil {
+		return "", err
+	}
+	if !fi.Mode().IsRegular() {
+		return "", fmt.Errorf("path %q is not a regular file", args.Path)
+	}
+	b, err := io.ReadAll(io.LimitReader(f, maxReadFile+1))
+	if err != nil {
+		return "", fmt.Errorf("failed to read %q: %w", args.Path, err)
+	}
+	truncated := len(b) > maxReadFile
+	if truncated {
+		b = trimPartialRune(b[:maxReadFile])
+	}
+	if bytes.IndexByte(b, 0) >= 0 || !utf8.Valid(b) {
+		return "", fmt.Errorf("path %q is a binary file of %d bytes", args.Path, fi.Size())
+	}
+	if !truncated {
+		return string(b), nil
+	}
+	var out strings.Builder
+	out.Write(b)
+	fmt.Fprintf(&out, "\n... [truncated after %d bytes of %d]", len(b), fi.Size())
+	return out.String(), nil
+}
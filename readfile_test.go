@@ -0,0 +1,89 @@
+// Copyright 2025 Marc-Antoine Ruel. All rights reserved.
+// Use of this source code is governed under the Apache License, Version 2.0
+// that can be found in the LICENSE file.
+
+package genaitools
+
+import (
+	"context"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+)
+
+func TestReadFile(t *testing.T) {
+	root := t.TempDir()
+	files := map[string]string{
+		"a.txt":       "hello\nwörld\n",
+		"sub/b.txt":   "nested",
+		"empty.txt":   "",
+		"bin.dat":     "PK\x03\x04\x00\x00",
+		"latin1.txt":  "caf\xe9",
+		"large.txt":   "x" + strings.Repeat("é", maxReadFile/2) + "tail",
+		"../outside":  "secret",
+		"sub/c/d.txt": "deep",
+	}
+	for name, content := range files {
+		p := filepath.Join(root, name)
+		if err := os.MkdirAll(filepath.Dir(p), 0o755); err != nil {
+			t.Fatal(err)
+		}
+		if err := os.WriteFile(p, []byte(content), 0o644); err != nil {
+			t.Fatal(err)
+		}
+	}
+	if err := os.Symlink(filepath.Join(root, "..", "outside"), filepath.Join(root, "link")); err != nil {
+		t.Fatal(err)
+	}
+	if err := os.Symlink(filepath.Join(root, "a.txt"), filepath.Join(root, "sub", "inside")); err != nil {
+		t.Fatal(err)
+	}
+	callback := NewReadFile(root).Callback.(func(context.Context, *readFileArgs) (string, error))
+	tests := []struct {
+		name      string
+		path      string
+		expected  string
+		errSubstr string
+	}{
+		{"file", "a.txt", "hello\nwörld\n", ""},
+		{"nested", "sub/c/d.txt", "deep", ""},
+		{"clean", "sub/../sub/./b.txt", "nested", ""},
+		{"empty", "empty.txt", "", ""},
+		{"symlink_inside", "sub/inside", "hello\nwörld\n", ""},
+		{"binary", "bin.dat", "", `path "bin.dat" is a binary file of 6 bytes`},
+		{"invalid_utf8", "latin1.txt", "", "is a binary file"},
+		{"dir", "sub", "", `path "sub" is a directory`},
+		{"missing", "missing.txt", "", `path "missing.txt"`},
+		{"dotdot", "../outside", "", "escapes the root directory"},
+		{"dotdot_nested", "sub/../../outside", "", "escapes the root directory"},
+		{"absolute", filepath.Join(root, "a.txt"), "", "must be relative"},
+		{"symlink_outside", "link", "", "escapes the root directory via a symlink"},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := callback(t.Context(), &readFileArgs{Path: tt.path})
+			if tt.errSubstr != "" {
+				if err == nil || !strings.Contains(err.Error(), tt.errSubstr) {
+					t.Fatalf("Expected error containing %q but got %v", tt.errSubstr, err)
+				}
+				return
+			}
+			if err != nil {
+				t.Fatalf("Unexpected error: %v", err)
+			}
+			if got != tt.expected {
+				t.Fatalf("Expected %q but got %q", tt.expected, got)
+			}
+		})
+	}
+	got, err := callback(t.Context(), &readFileArgs{Path: "large.txt"})
+	if err != nil {
+		t.Fatal(err)
+	}
+	// The last rune is not cut in half.
+	want := "x" + strings.Repeat("é", maxReadFile/2-1) + "\n... [truncated after 262143 bytes of 262149]"
+	if got != want {
+		t.Fatalf("Unexpected truncation: %q", got[len(got)-60:])
+	}
+}
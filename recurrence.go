@@ -0,0 +1,372 @@
+// Copyright 2025 Marc-Antoine Ruel. All rights reserved.
+// Use of this source code is governed under the Apache License, Version 2.0
+// that can be found in the LICENSE file.
+
+package genaitools
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"slices"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/maruel/genai"
+)
+
+// Recurrence expands an iCalendar (RFC 5545) RRULE into its next occurrences.
+//
+// FREQ (DAILY, WEEKLY, MONTHLY, YEARLY), INTERVAL, COUNT, UNTIL, BYDAY,
+// BYMONTHDAY, BYMONTH and WKST are supported. The start date is always the
+// reference of the rule; only occurrences at or after it are returned.
+var Recurrence = genai.ToolDef{
+	Name:        "recurrence",
+	Description: "Expands an iCalendar RRULE (e.g. FREQ=WEEKLY;BYDAY=MO,WE) from a start date and returns the next occurrences in RFC3339 format, one per line.",
+	Callback:    doRecurrence,
+}
+
+type recurrenceArgs struct {
+	Rule  string `json:"rule" jsonschema:"description=iCalendar RRULE\\, e.g. FREQ=MONTHLY;BYDAY=-1FR"`
+	Start string `json:"start" jsonschema:"description=Start date in RFC3339 or YYYY-MM-DD format"`
+	Count int    `json:"count,omitempty" jsonschema:"description=Maximum number of occurrences to return. Defaults to 10,minimum=1,maximum=1000"`
+}
+
+// rruleMaxPeriods bounds the number of periods scanned so a rule that never
+// matches, like BYMONTH=2;BYMONTHDAY=30, terminates.
+const rruleMaxPeriods = 100000
+
+func doRecurrence(ctx context.Context, args *recurrenceArgs) (string, error) {
+	n := args.Count
+	if n == 0 {
+		n = 10
+	}
+	if n < 1 || n > 1000 {
+		return "", fmt.Errorf("invalid count %d; must be between 1 and 1000", args.Count)
+	}
+	start, err := parseTimestamp("start", args.Start)
+	if err != nil {
+		return "", err
+	}
+	r, err := parseRRule(args.Rule, start.Location())
+	if err != nil {
+		return "", err
+	}
+	out := r.expand(start, n)
+	lines := make([]string, len(out))
+	for i, t := range out {
+		lines[i] = t.Format(time.RFC3339)
+	}
+	return strings.Join(lines, "\n"), nil
+}
+
+// parseTimestamp parses s as RFC3339 or as a date. name is the argument name
+// used in the error message.
+func parseTimestamp(name, s string) (time.Time, error) {
+	if t, err := time.Parse(time.RFC3339, s); err == nil {
+		return t, nil
+	}
+	if t, err := time.Parse(time.DateOnly, s); err == nil {
+		return t, nil
+	}
+	return time.Time{}, fmt.Errorf("invalid %s %q; expected RFC3339 or YYYY-MM-DD", name, s)
+}
+
+// weekdayNum is a BYDAY entry like "-1FR". n is 0 when no ordinal is given.
+type weekdayNum struct {
+	n   int
+	day time.Weekday
+}
+
+type rrule struct {
+	freq       string
+	interval   int
+	count      int
+	until      time.Time
+	byDay      []weekdayNum
+	byMonthDay []int
+	byMonth    []time.Month
+	wkst       time.Weekday
+}
+
+var rruleWeekdays = map[string]time.Weekday{
+	"SU": time.Sunday, "MO": time.Monday, "TU": time.Tuesday, "WE": time.Wednesday,
+	"TH": time.Thursday, "FR": time.Friday, "SA": time.Saturday,
+}
+
+func parseRRule(s string, loc *time.Location) (*rrule, error) {
+	s = strings.TrimSpace(s)
+	if len(s) >= 6 && strings.EqualFold(s[:6], "RRULE:") {
+		s = s[6:]
+	}
+	r := &rrule{interval: 1, wkst: time.Monday}
+	seen := map[string]bool{}
+	for part := range strings.SplitSeq(s, ";") {
+		if part == "" {
+			continue
+		}
+		k, v, ok := strings.Cut(part, "=")
+		k = strings.ToUpper(strings.TrimSpace(k))
+		v = strings.ToUpper(strings.TrimSpace(v))
+		if !ok || v == "" {
+			return nil, fmt.Errorf("invalid rule part %q; expected KEY=VALUE", part)
+		}
+		if seen[k] {
+			return nil, fmt.Errorf("duplicate rule part %s", k)
+		}
+		seen[k] = true
+		var err error
+		switch k {
+		case "FREQ":
+			switch v {
+			case "DAILY", "WEEKLY", "MONTHLY", "YEARLY":
+				r.freq = v
+			default:
+				return nil, fmt.Errorf("unsupported FREQ %q; must be DAILY, WEEKLY, MONTHLY or YEARLY", v)
+			}
+		case "INTERVAL":
+			r.interval, err = parseRRuleInt(k, v, 1, 1000)
+		case "COUNT":
+			r.count, err = parseRRuleInt(k, v, 1, 100000)
+		case "UNTIL":
+			r.until, err = parseRRuleUntil(v, loc)
+		case "BYDAY":
+			for d := range strings.SplitSeq(v, ",") {
+				if len(d) < 2 {
+					return nil, fmt.Errorf("invalid BYDAY value %q", d)
+				}
+				wd, ok := rruleWeekdays[d[len(d)-2:]]
+				if !ok {
+					return nil, fmt.Errorf("invalid BYDAY value %q", d)
+				}
+				w := weekdayNum{day: wd}
+				if o := d[:len(d)-2]; o != "" {
+					if w.n, err = strconv.Atoi(o); err != nil || w.n == 0 || w.n < -53 || w.n > 53 {
+						return nil, fmt.Errorf("invalid BYDAY value %q", d)
+					}
+				}
+				r.byDay = append(r.byDay, w)
+			}
+		case "BYMONTHDAY":
+			for d := range strings.SplitSeq(v, ",") {
+				i, err := parseRRuleInt(k, d, -31, 31)
+				if err != nil {
+					return nil, err
+				}
+				if i == 0 {
+					return nil, errors.New("invalid BYMONTHDAY 0")
+				}
+				r.byMonthDay = append(r.byMonthDay, i)
+			}
+		case "BYMONTH":
+			for m := range strings.SplitSeq(v, ",") {
+				i, err := parseRRuleInt(k, m, 1, 12)
+				if err != nil {
+					return nil, err
+				}
+				r.byMonth = append(r.byMonth, time.Month(i))
+			}
+		case "WKST":
+			wd, ok := rruleWeekdays[v]
+			if !ok {
+				return nil, fmt.Errorf("invalid WKST %q", v)
+			}
+			r.wkst = wd
+		default:
+			return nil, fmt.Errorf("unsupported rule part %s", k)
+		}
+		if err != nil {
+			return nil, err
+		}
+	}
+	if r.freq == "" {
+		return nil, errors.New("missing FREQ in rule")
+	}
+	if r.count != 0 && !r.until.IsZero() {
+		return nil, errors.New("COUNT and UNTIL cannot both be set")
+	}
+	for _, w := range r.byDay {
+		if w.n != 0 && r.freq != "MONTHLY" && r.freq != "YEARLY" {
+			return nil, errors.New("BYDAY with an ordinal is only valid with FREQ=MONTHLY or FREQ=YEARLY")
+		}
+	}
+	return r, nil
+}
+
+func parseRRuleInt(k, v string, lo, hi int) (int, error) {
+	i, err := strconv.Atoi(v)
+	if err != nil || i < lo || i > hi {
+		return 0, fmt.Errorf("invalid %s %q; must be an integer between %d and %d", k, v, lo, hi)
+	}
+	return i, nil
+}
+
+func parseRRuleUntil(v string, loc *time.Location) (time.Time, error) {
+	if t, err := time.Parse("20060102T150405Z", v); err == nil {
+		return t, nil
+	}
+	if t, err := time.ParseInLocation("20060102T150405", v, loc); err == nil {
+		return t, nil
+	}
+	if t, err := time.ParseInLocation("20060102", v, loc); err == nil {
+		// A date-only UNTIL includes the whole day.
+		return t.AddDate(0, 0, 1).Add(-time.Nanosecond), nil
+	}
+	return time.Time{}, fmt.Errorf("invalid UNTIL %q; expected YYYYMMDD or YYYYMMDDTHHMMSSZ", v)
+}
+
+// expand returns up to n occurrences at or after start.
+func (r *rrule) expand(start time.Time, n int) []time.Time {
+	if r.count != 0 {
+		// COUNT includes occurrences that are not returned.
+		n = min(n, r.count)
+	}
+	var out []time.Time
+	for p := range rruleMaxPeriods {
+		for _, t := range r.period(start, p) {
+			if t.Before(start) {
+				continue
+			}
+			if !r.until.IsZero() && t.After(r.until) {
+				return out
+			}
+			out = append(out, t)
+			if len(out) == n {
+				return out
+			}
+		}
+	}
+	return out
+}
+
+// period returns the sorted candidates of the p-th period after start.
+func (r *rrule) period(start time.Time, p int) []time.Time {
+	y, m, d := start.Date()
+	hh, mm, ss := start.Clock()
+	loc := start.Location()
+	at := func(y int, m time.Month, d int) time.Time {
+		return time.Date(y, m, d, hh, mm, ss, start.Nanosecond(), loc)
+	}
+	var out []time.Time
+	switch r.freq {
+	case "DAILY":
+		t := at(y, m, d+p*r.interval)
+		if r.matchMonth(t) && r.matchMonthDay(t) && r.matchWeekday(t) {
+			out = append(out, t)
+		}
+	case "WEEKLY":
+		offset := (int(start.Weekday()) - int(r.wkst) + 7) % 7
+		weekStart := at(y, m, d-offset+7*p*r.interval)
+		for i := range 7 {
+			t := weekStart.AddDate(0, 0, i)
+			if len(r.byDay) == 0 && t.Weekday() != start.Weekday() {
+				continue
+			}
+			if r.matchMonth(t) && r.matchWeekday(t) {
+				out = append(out, t)
+			}
+		}
+	case "MONTHLY":
+		first := at(y, m+time.Month(p*r.interval), 1)
+		if r.matchMonth(first) {
+			out = r.monthDays(first, d)
+		}
+	case "YEARLY":
+		yy := y + p*r.interval
+		if len(r.byDay) != 0 && len(r.byMonth) == 0 && len(r.byMonthDay) == 0 {
+			// Ordinals are relative to the year.
+			first := at(yy, time.January, 1)
+			return expandByDay(first, first.AddDate(1, 0, 0), r.byDay)
+		}
+		months := r.byMonth
+		if len(months) == 0 {
+			months = []time.Month{m}
+		}
+		for _, mo := range months {
+			out = append(out, r.monthDays(at(yy, mo, 1), d)...)
+		}
+	}
+	slices.SortFunc(out, func(a, b time.Time) int { return a.Compare(b) })
+	return out
+}
+
+// monthDays returns the candidates in the month starting at first. d is the
+// day of the month of the start, used when neither BYDAY nor BYMONTHDAY is set.
+func (r *rrule) monthDays(first time.Time, d int) []time.Time {
+	next := first.AddDate(0, 1, 0)
+	days := next.AddDate(0, 0, -1).Day()
+	var out []time.Time
+	switch {
+	case len(r.byMonthDay) != 0:
+		for _, md := range r.byMonthDay {
+			if md < 0 {
+				md += days + 1
+			}
+			if md < 1 || md > days {
+				continue
+			}
+			t := first.AddDate(0, 0, md-1)
+			if r.matchWeekday(t) && !slices.ContainsFunc(out, t.Equal) {
+				out = append(out, t)
+			}
+		}
+	case len(r.byDay) != 0:
+		out = expandByDay(first, next, r.byDay)
+	case d <= days:
+		// Months without this day, like February 30th, are skipped.
+		out = append(out, first.AddDate(0, 0, d-1))
+	}
+	return out
+}
+
+// expandByDay returns the days in [first, end) matching byDay.
+func expandByDay(first, end time.Time, byDay []weekdayNum) []time.Time {
+	var out []time.Time
+	for _, w := range byDay {
+		var matches []time.Time
+		for t := first.AddDate(0, 0, (int(w.day)-int(first.Weekday())+7)%7); t.Before(end); t = t.AddDate(0, 0, 7) {
+			matches = append(matches, t)
+		}
+		switch {
+		case w.n == 0:
+			out = append(out, matches...)
+		case w.n > 0 && w.n <= len(matches):
+			out = append(out, matches[w.n-1])
+		case w.n < 0 && -w.n <= len(matches):
+			out = append(out, matches[len(matches)+w.n])
+		}
+	}
+	slices.SortFunc(out, func(a, b time.Time) int { return a.Compare(b) })
+	return slices.CompactFunc(out, time.Time.Equal)
+}
+
+func (r *rrule) matchMonth(t time.Time) bool {
+	return len(r.byMonth) == 0 || slices.Contains(r.byMonth, t.Month())
+}
+
+func (r *rrule) matchMonthDay(t time.Time) bool {
+	if len(r.byMonthDay) == 0 {
+		return true
+	}
+	days := time.Date(t.Year(), t.Month()+1, 0, 0, 0, 0, 0, time.UTC).Day()
+	for _, md := range r.byMonthDay {
+		if md == t.Day() || md+days+1 == t.Day() {
+			return true
+		}
+	}
+	return false
+}
+
+// matchWeekday matches the weekday, ignoring ordinals.
+func (r *rrule) matchWeekday(t time.Time) bool {
+	if len(r.byDay) == 0 {
+		return true
+	}
+	for _, w := range r.byDay {
+		if w.day == t.Weekday() {
+			return true
+		}
+	}
+	return false
+}
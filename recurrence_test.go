@@ -0,0 +1,86 @@
+// Copyright 2025 Marc-Antoine Ruel. All rights reserved.
+// Use of this source code is governed under the Apache License, Version 2.0
+// that can be found in the LICENSE file.
+
+package genaitools
+
+import (
+	"context"
+	"strings"
+	"testing"
+)
+
+func TestRecurrence(t *testing.T) {
+	callback := Recurrence.Callback.(func(context.Context, *recurrenceArgs) (string, error))
+	tests := []struct {
+		name      string
+		rule      string
+		start     string
+		count     int
+		expected  []string
+		errSubstr string
+	}{
+		{
+			"daily", "FREQ=DAILY;INTERVAL=2", "2024-02-27T09:30:00Z", 3,
+			[]string{"2024-02-27T09:30:00Z", "2024-02-29T09:30:00Z", "2024-03-02T09:30:00Z"}, "",
+		},
+		{
+			"weekly_byday", "RRULE:FREQ=WEEKLY;BYDAY=MO,WE", "2024-03-06", 4,
+			[]string{"2024-03-06T00:00:00Z", "2024-03-11T00:00:00Z", "2024-03-13T00:00:00Z", "2024-03-18T00:00:00Z"}, "",
+		},
+		{
+			"biweekly", "FREQ=WEEKLY;INTERVAL=2;COUNT=3", "2024-01-05T10:00:00+01:00", 10,
+			[]string{"2024-01-05T10:00:00+01:00", "2024-01-19T10:00:00+01:00", "2024-02-02T10:00:00+01:00"}, "",
+		},
+		{
+			"monthly_last_friday", "FREQ=MONTHLY;BYDAY=-1FR", "2024-01-01", 3,
+			[]string{"2024-01-26T00:00:00Z", "2024-02-23T00:00:00Z", "2024-03-29T00:00:00Z"}, "",
+		},
+		{
+			"monthly_31st", "FREQ=MONTHLY", "2024-01-31", 3,
+			[]string{"2024-01-31T00:00:00Z", "2024-03-31T00:00:00Z", "2024-05-31T00:00:00Z"}, "",
+		},
+		{
+			"monthly_last_day", "FREQ=MONTHLY;BYMONTHDAY=-1", "2024-01-15", 2,
+			[]string{"2024-01-31T00:00:00Z", "2024-02-29T00:00:00Z"}, "",
+		},
+		{
+			"yearly_thanksgiving", "FREQ=YEARLY;BYMONTH=11;BYDAY=4TH", "2024-01-01", 2,
+			[]string{"2024-11-28T00:00:00Z", "2025-11-27T00:00:00Z"}, "",
+		},
+		{
+			"yearly_leap", "FREQ=YEARLY", "2024-02-29", 2,
+			[]string{"2024-02-29T00:00:00Z", "2028-02-29T00:00:00Z"}, "",
+		},
+		{
+			"until", "FREQ=DAILY;UNTIL=20240103", "2024-01-01T12:00:00Z", 10,
+			[]string{"2024-01-01T12:00:00Z", "2024-01-02T12:00:00Z", "2024-01-03T12:00:00Z"}, "",
+		},
+		{"never", "FREQ=YEARLY;BYMONTH=2;BYMONTHDAY=30", "2024-01-01", 1, []string{""}, ""},
+		{"missing_freq", "INTERVAL=2", "2024-01-01", 0, nil, "missing FREQ"},
+		{"bad_freq", "FREQ=HOURLY", "2024-01-01", 0, nil, "unsupported FREQ"},
+		{"bad_part", "FREQ=DAILY;BYSETPOS=1", "2024-01-01", 0, nil, "unsupported rule part BYSETPOS"},
+		{"bad_byday", "FREQ=WEEKLY;BYDAY=XX", "2024-01-01", 0, nil, "invalid BYDAY"},
+		{"bad_ordinal", "FREQ=WEEKLY;BYDAY=1MO", "2024-01-01", 0, nil, "ordinal"},
+		{"count_until", "FREQ=DAILY;COUNT=2;UNTIL=20240103", "2024-01-01", 0, nil, "cannot both"},
+		{"bad_start", "FREQ=DAILY", "tomorrow", 0, nil, "invalid start"},
+		{"bad_count", "FREQ=DAILY", "2024-01-01", 1001, nil, "invalid count"},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := callback(t.Context(), &recurrenceArgs{Rule: tt.rule, Start: tt.start, Count: tt.count})
+			if tt.errSubstr != "" {
+				if err == nil || !strings.Contains(err.Error(), tt.errSubstr) {
+					t.Fatalf("Expected error containing %q but got %v", tt.errSubstr, err)
+				}
+				return
+			}
+			if err != nil {
+				t.Fatalf("Unexpected error: %v", err)
+			}
+			if expected := strings.Join(tt.expected, "\n"); got != expected {
+				t.Fatalf("Expected %q but got %q", expected, got)
+			}
+		})
+	}
+}
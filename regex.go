@@ -0,0 +1,72 @@
+// Copyright 2025 Marc-Antoine Ruel. All rights reserved.
+// Use of this source code is governed under the Apache License, Version 2.0
+// that can be found in the LICENSE file.
+
+package genaitools
+
+import (
+	"context"
+	"fmt"
+	"regexp"
+	"strings"
+
+	"github.com/maruel/genai"
+)
+
+const (
+	// maxRegexPattern is the maximum size of the pattern Regex compiles.
+	maxRegexPattern = 4096
+	// maxRegexInput is the maximum size of the input Regex processes.
+	maxRegexInput = 1 << 20
+)
+
+// Regex finds or replaces the matches of a regular expression.
+//
+// The pattern uses the Go regexp syntax (RE2), which runs in linear time so
+// there is no catastrophic backtracking; the pattern and input sizes are
+// still limited. find returns the first match and findall returns each match
+// on its own line; both return an error when nothing matches. replace
+// replaces all the matches and refers to groups with $1 or ${name}.
+var Regex = genai.ToolDef{
+	Name:        "regex",
+	Description: "Applies a regular expression (Go RE2 syntax) to text: find returns the first match, findall returns each match on its own line and replace replaces all the matches, with $1 or ${name} referring to groups in the replacement.",
+	Callback:    doRegex,
+}
+
+type regexArgs struct {
+	Operation   string `json:"operation" jsonschema:"enum=find,enum=findall,enum=replace"`
+	Pattern     string `json:"pattern" jsonschema:"description=Regular expression like (\\w+)@example\\.com. Use (?i) for case insensitive matching"`
+	Input       string `json:"input" jsonschema:"description=Text to search"`
+	Replacement string `json:"replacement,omitempty" jsonschema:"description=Replacement for replace. Use $1 or ${name} to refer to groups"`
+}
+
+func doRegex(ctx context.Context, args *regexArgs) (string, error) {
+	switch args.Operation {
+	case "find", "findall", "replace":
+	default:
+		return "", fmt.Errorf("unknown operation %q; supported operations are find, findall and replace", args.Operation)
+	}
+	if len(args.Pattern) > maxRegexPattern {
+		return "", fmt.Errorf("pattern is %d bytes; the maximum is %d bytes", len(args.Pattern), maxRegexPattern)
+	}
+	if len(args.Input) > maxRegexInput {
+		return "", fmt.Errorf("input is %d bytes; the maximum is %d bytes", len(args.Input), maxRegexInput)
+	}
+	re, err := regexp.Compile(args.Pattern)
+	if err != nil {
+		return "", fmt.Errorf("invalid pattern: %w", err)
+	}
+	switch args.Operation {
+	case "find":
+		if m := re.FindStringIndex(args.Input); m != nil {
+			return args.Input[m[0]:m[1]], nil
+		}
+	case "findall":
+		if m := re.FindAllString(args.Input, -1); m != nil {
+			return strings.Join(m, "\n"), nil
+		}
+	default:
+		return re.ReplaceAllString(args.Input, args.Replacement), nil
+	}
+	return "", fmt.Errorf("pattern %q does not match", args.Pattern)
+}
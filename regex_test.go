@@ -0,0 +1,54 @@
+// Copyright 2025 Marc-Antoine Ruel. All rights reserved.
+// Use of this source code is governed under the Apache License, Version 2.0
+// that can be found in the LICENSE file.
+
+package genaitools
+
+import (
+	"context"
+	"strings"
+	"testing"
+)
+
+func TestRegex(t *testing.T) {
+	callback := Regex.Callback.(func(context.Context, *regexArgs) (string, error))
+	const input = "alice@example.com, bob@example.org and carol@example.com"
+	tests := []struct {
+		name      string
+		args      regexArgs
+		expected  string
+		errSubstr string
+	}{
+		{"find", regexArgs{Operation: "find", Pattern: `\w+@example\.com`, Input: input}, "alice@example.com", ""},
+		{"find_empty_match", regexArgs{Operation: "find", Pattern: `x*`, Input: "abc"}, "", ""},
+		{"findall", regexArgs{Operation: "findall", Pattern: `\w+@example\.com`, Input: input}, "alice@example.com\ncarol@example.com", ""},
+		{"findall_case_insensitive", regexArgs{Operation: "findall", Pattern: `(?i)B\w+`, Input: input}, "bob", ""},
+		{"replace_groups", regexArgs{Operation: "replace", Pattern: `(\w+)@example\.(\w+)`, Input: input, Replacement: "$1 at $2"}, "alice at com, bob at org and carol at com", ""},
+		{"replace_named", regexArgs{Operation: "replace", Pattern: `(?P<user>\w+)@`, Input: "x@y", Replacement: "${user}_"}, "x_y", ""},
+		{"replace_no_match", regexArgs{Operation: "replace", Pattern: `\d+`, Input: "abc", Replacement: "#"}, "abc", ""},
+		{"find_no_match", regexArgs{Operation: "find", Pattern: `\d+`, Input: "abc"}, "", `pattern "\\d+" does not match`},
+		{"findall_no_match", regexArgs{Operation: "findall", Pattern: `\d+`, Input: "abc"}, "", "does not match"},
+		{"invalid", regexArgs{Operation: "find", Pattern: `(a`, Input: "a"}, "", "invalid pattern: error parsing regexp: missing closing )"},
+		{"backreference", regexArgs{Operation: "find", Pattern: `(a)\1`, Input: "aa"}, "", "invalid pattern"},
+		{"long_pattern", regexArgs{Operation: "find", Pattern: strings.Repeat("a", maxRegexPattern+1)}, "", "the maximum is 4096 bytes"},
+		{"long_input", regexArgs{Operation: "find", Pattern: "a", Input: strings.Repeat("a", maxRegexInput+1)}, "", "input is 1048577 bytes"},
+		{"bad_operation", regexArgs{Operation: "match", Pattern: "a", Input: "a"}, "", "unknown operation"},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := callback(t.Context(), &tt.args)
+			if tt.errSubstr != "" {
+				if err == nil || !strings.Contains(err.Error(), tt.errSubstr) {
+					t.Fatalf("Expected error containing %q but got %v", tt.errSubstr, err)
+				}
+				return
+			}
+			if err != nil {
+				t.Fatalf("Unexpected error: %v", err)
+			}
+			if got != tt.expected {
+				t.Fatalf("Expected %q but got %q", tt.expected, got)
+			}
+		})
+	}
+}
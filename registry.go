@@ -0,0 +1,109 @@
+// Copyright 2025 Marc-Antoine Ruel. All rights reserved.
+// Use of this source code is governed under the Apache License, Version 2.0
+// that can be found in the LICENSE file.
+
+package genaitools
+
+import (
+	"cmp"
+	"slices"
+
+	"github.com/maruel/genai"
+)
+
+// registry is the list of tools returned by All, sorted by name.
+var registry []genai.ToolDef
+
+func init() {
+	registry = []genai.ToolDef{
+		AccurateSum,
+		Arithmetic,
+		AvailabilityIntersect,
+		BarChart,
+		Barcode,
+		CIDR,
+		Canonicalize,
+		ChangeCase,
+		ConvertBase,
+		ConvertCurrency,
+		CRC,
+		DateRange,
+		DecodeJWT,
+		Diff,
+		EnvDiff,
+		EstimateCost,
+		EstimateTokens,
+		ExplainCron,
+		Expression,
+		ExtractNumbers,
+		ExtractText,
+		FetchURL,
+		FormData,
+		FormatCurrency,
+		FormatDuration,
+		FormatJSON,
+		FormatMarkdownTable,
+		GapAnalysis,
+		GeoBearing,
+		GeoDistance,
+		GetTodayClockTime,
+		Hash,
+		HighlightCode,
+		HTTPStatus,
+		IPInfo,
+		JSONToYAML,
+		MonthCalendar,
+		NormalizeURL,
+		ParseFrontmatter,
+		Percentage,
+		QuantityMath,
+		QueryCSV,
+		QueryJSON,
+		RandomNumber,
+		Recurrence,
+		Regex,
+		RenderTemplate,
+		RequireKeys,
+		RollingStats,
+		ShellEscape,
+		SortLines,
+		SpreadsheetColumn,
+		SpreadsheetFormula,
+		Statistics,
+		StringSimilarity,
+		Substitute,
+		TextStats,
+		TimeAgo,
+		TOTP,
+		TopoSort,
+		ULID,
+		UnicodeInfo,
+		VersionSort,
+		WeightedAverage,
+		YAMLToJSON,
+	}
+	slices.SortFunc(registry, func(a, b genai.ToolDef) int {
+		return cmp.Compare(a.Name, b.Name)
+	})
+}
+
+// All returns all the tools of this package that do not need configuration,
+// sorted by name.
+//
+// Tools accessing the file system, like NewDirDiff and NewWC, are not included
+// since they need a root directory.
+func All() []genai.ToolDef {
+	return slices.Clone(registry)
+}
+
+// ByName returns the tool in All with the name, as seen by the LLM, e.g.
+// "arithmetic".
+func ByName(name string) (genai.ToolDef, bool) {
+	i, ok := slices.BinarySearchFunc(registry, name, func(t genai.ToolDef, name string) int {
+		return cmp.Compare(t.Name, name)
+	})
+	if !ok {
+		return genai.ToolDef{}, false
+	}
+	return registry[i], true
+}
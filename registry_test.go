@@ -0,0 +1,88 @@
+// Copyright 2025 Marc-Antoine Ruel. All rights reserved.
+// Use of this source code is governed under the Apache License, Version 2.0
+// that can be found in the LICENSE file.
+
+package genaitools
+
+import (
+	"go/ast"
+	"go/parser"
+	gotoken "go/token"
+	"io/fs"
+	"strings"
+	"testing"
+
+	"github.com/maruel/genai"
+)
+
+func TestAll(t *testing.T) {
+	all := All()
+	if len(all) == 0 {
+		t.Fatal("Expected tools")
+	}
+	seen := map[string]bool{}
+	for _, tool := range all {
+		if tool.Name == "" || tool.Description == "" || tool.Callback == nil {
+			t.Fatalf("Incomplete tool %q", tool.Name)
+		}
+		if seen[tool.Name] {
+			t.Fatalf("Duplicate tool name %q", tool.Name)
+		}
+		seen[tool.Name] = true
+		if err := tool.Validate(); err != nil {
+			t.Fatalf("%s: %v", tool.Name, err)
+		}
+		got, ok := ByName(tool.Name)
+		if !ok || got.Name != tool.Name {
+			t.Fatalf("ByName(%q) returned %q, %t", tool.Name, got.Name, ok)
+		}
+	}
+	if _, ok := ByName("unknown"); ok {
+		t.Fatal("Expected unknown tool to not be found")
+	}
+	first := all[0].Name
+	all[0] = genai.ToolDef{}
+	if All()[0].Name != first {
+		t.Fatal("All must return a copy")
+	}
+}
+
+// TestAllComplete makes sure new package-level tools are added to the
+// registry.
+func TestAllComplete(t *testing.T) {
+	pkgs, err := parser.ParseDir(gotoken.NewFileSet(), ".", func(fi fs.FileInfo) bool {
+		return !strings.HasSuffix(fi.Name(), "_test.go")
+	}, 0)
+	if err != nil {
+		t.Fatal(err)
+	}
+	var names []string
+	for _, f := range pkgs["genaitools"].Files {
+		for _, d := range f.Decls {
+			g, ok := d.(*ast.GenDecl)
+			if !ok || g.Tok != gotoken.VAR {
+				continue
+			}
+			for _, s := range g.Specs {
+				v := s.(*ast.ValueSpec)
+				if len(v.Values) != 1 || !v.Names[0].IsExported() {
+					continue
+				}
+				switch x := v.Values[0].(type) {
+				case *ast.CompositeLit:
+					if sel, ok := x.Type.(*ast.SelectorExpr); ok && sel.Sel.Name == "ToolDef" {
+						names = append(names, v.Names[0].Name)
+					}
+				case *ast.CallExpr:
+					// E.g. FetchURL = NewFetchURL(nil).
+					if id, ok := x.Fun.(*ast.Ident); ok && strings.HasPrefix(id.Name, "New") {
+						names = append(names, v.Names[0].Name)
+					}
+				}
+			}
+		}
+	}
+	if len(names) != len(All()) {
+		t.Fatalf("Expected %d tools in All() but got %d; add the new tools to the registry: %s", len(names), len(All()), strings.Join(names, ", "))
+	}
+}
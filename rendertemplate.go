@@ -0,0 +1,197 @@
+// Copyright 2025 Marc-Antoine Ruel. All rights reserved.
+// Use of this source code is governed under the Apache License, Version 2.0
+// that can be found in the LICENSE file.
+
+package genaitools
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"regexp"
+	"strconv"
+	"strings"
+	"text/template"
+	"text/template/parse"
+
+	"github.com/maruel/genai"
+)
+
+const (
+	// maxTemplate is the maximum size of a template.
+	maxTemplate = 64 << 10
+	// maxTemplateOutput is the maximum size of the rendered template.
+	maxTemplateOutput = 1 << 20
+	// maxTemplateStrings is the maximum total size of the strings returned by
+	// the functions like print, so variables cannot grow unbounded.
+	maxTemplateStrings = 16 << 20
+	// maxTemplateSteps is the maximum number of range iterations and template
+	// invocations.
+	maxTemplateSteps = 100000
+	// maxPrintfWidth is the maximum width or precision in a printf verb.
+	maxPrintfWidth = 1000
+	// templateStepFunc is the function injected to count the steps.
+	templateStepFunc = "genaitoolsStep"
+)
+
+// RenderTemplate renders a Go text/template with JSON data.
+//
+// A missing map key is an error; use {{index . "key"}} to test for an optional
+// key. Numbers in the data are integers when they have no fraction, so they
+// can be compared with eq, lt, etc.
+//
+// The execution is capped: the output is limited to 1MiB, the number of range
+// iterations and template invocations to 100000, the total size of the strings
+// returned by print, printf, println, html, js and urlquery to 16MiB, and the
+// printf width and precision to 1000. The call function is disabled.
+var RenderTemplate = genai.ToolDef{
+	Name:        "render_template",
+	Description: "Renders a Go text/template with JSON data, e.g. to generate a config file. Use {{.key}} to insert values, {{range .items}}...{{end}} to loop and {{if .flag}}...{{else}}...{{end}} for conditionals.",
+	Callback:    doRenderTemplate,
+}
+
+type renderTemplateArgs struct {
+	Template string         `json:"template" jsonschema:"description=Go text/template like: Hello {{.name}}!"`
+	Data     map[string]any `json:"data,omitempty" jsonschema:"description=JSON object used as the template data"`
+}
+
+// rePrintfVerb matches the width and precision of a printf verb, including
+// after an explicit argument index like %[2]*[1]d.
+var rePrintfVerb = regexp.MustCompile(`%[-+# 0]*(?:\[\d+\])?(\*|\d*)(?:\.(?:\[\d+\])?(\*|\d*))?`)
+
+// limitedWriter is a strings.Builder that fails once n bytes are written.
+type limitedWriter struct {
+	b strings.Builder
+	n int
+}
+
+func (l *limitedWriter) Write(p []byte) (int, error) {
+	if l.b.Len()+len(p) > l.n {
+		return 0, fmt.Errorf("output exceeds %d bytes", l.n)
+	}
+	return l.b.Write(p)
+}
+
+func doRenderTemplate(ctx context.Context, args *renderTemplateArgs) (string, error) {
+	if len(args.Template) > maxTemplate {
+		return "", fmt.Errorf("template is %d bytes; the maximum is %d", len(args.Template), maxTemplate)
+	}
+	steps := 0
+	produced := 0
+	// charge accounts for the strings built by the functions, since they can be
+	// assigned to variables without ever being written to the output.
+	charge := func(s string) (string, error) {
+		if produced += len(s); produced > maxTemplateStrings {
+			return "", fmt.Errorf("functions returned more than %d bytes", maxTemplateStrings)
+		}
+		return s, nil
+	}
+	funcs := template.FuncMap{
+		"call": func(...any) (any, error) {
+			return nil, errors.New("call is disabled")
+		},
+		"html": func(a ...any) (string, error) {
+			return charge(template.HTMLEscaper(a...))
+		},
+		"js": func(a ...any) (string, error) {
+			return charge(template.JSEscaper(a...))
+		},
+		"print": func(a ...any) (string, error) {
+			return charge(fmt.Sprint(a...))
+		},
+		"printf": func(format string, a ...any) (string, error) {
+			for _, m := range rePrintfVerb.FindAllStringSubmatch(format, -1) {
+				for _, w := range m[1:] {
+					if n, err := strconv.Atoi(w); w == "*" || err == nil && n > maxPrintfWidth {
+						return "", fmt.Errorf("printf width and precision are limited to %d", maxPrintfWidth)
+					}
+				}
+			}
+			return charge(fmt.Sprintf(format, a...))
+		},
+		"println": func(a ...any) (string, error) {
+			return charge(fmt.Sprintln(a...))
+		},
+		"urlquery": func(a ...any) (string, error) {
+			return charge(template.URLQueryEscaper(a...))
+		},
+		templateStepFunc: func() (string, error) {
+			if steps++; steps > maxTemplateSteps {
+				return "", fmt.Errorf("more than %d loop iterations or template invocations", maxTemplateSteps)
+			}
+			return "", ctx.Err()
+		},
+	}
+	t, err := template.New("template").Funcs(funcs).Option("missingkey=error").Parse(args.Template)
+	if err != nil {
+		return "", err
+	}
+	for _, s := range t.Templates() {
+		if s.Tree != nil {
+			addTemplateSteps(s.Tree.Root)
+		}
+	}
+	w := limitedWriter{n: maxTemplateOutput}
+	if err = t.Execute(&w, normalizeTemplateData(args.Data)); err != nil {
+		return "", err
+	}
+	return w.b.String(), nil
+}
+
+// addTemplateSteps injects a call to templateStepFunc at the start of the
+// template and of every range body, so the execution can be capped.
+func addTemplateSteps(root *parse.ListNode) {
+	prependTemplateStep(root)
+	walkTemplateRanges(root)
+}
+
+func walkTemplateRanges(l *parse.ListNode) {
+	if l == nil {
+		return
+	}
+	for _, n := range l.Nodes {
+		switch n := n.(type) {
+		case *parse.RangeNode:
+			walkTemplateRanges(n.List)
+			walkTemplateRanges(n.ElseList)
+			prependTemplateStep(n.List)
+		case *parse.IfNode:
+			walkTemplateRanges(n.List)
+			walkTemplateRanges(n.ElseList)
+		case *parse.WithNode:
+			walkTemplateRanges(n.List)
+			walkTemplateRanges(n.ElseList)
+		}
+	}
+}
+
+func prependTemplateStep(l *parse.ListNode) {
+	cmd := &parse.CommandNode{NodeType: parse.NodeCommand, Pos: l.Pos, Args: []parse.Node{parse.NewIdentifier(templateStepFunc).SetPos(l.Pos)}}
+	pipe := &parse.PipeNode{NodeType: parse.NodePipe, Pos: l.Pos, Cmds: []*parse.CommandNode{cmd}}
+	l.Nodes = append([]parse.Node{&parse.ActionNode{NodeType: parse.NodeAction, Pos: l.Pos, Pipe: pipe}}, l.Nodes...)
+}
+
+// normalizeTemplateData converts the json.Number values to int64 or float64 so
+// they can be compared.
+func normalizeTemplateData(v any) any {
+	switch t := v.(type) {
+	case map[string]any:
+		for k, x := range t {
+			t[k] = normalizeTemplateData(x)
+		}
+	case []any:
+		for i, x := range t {
+			t[i] = normalizeTemplateData(x)
+		}
+	case json.Number:
+		if i, err := t.Int64(); err == nil {
+			return i
+		}
+		if f, err := t.Float64(); err == nil {
+			return f
+		}
+		return t.String()
+	}
+	return v
+}
@@ -0,0 +1,74 @@
+// Copyright 2025 Marc-Antoine Ruel. All rights reserved.
+// Use of this source code is governed under the Apache License, Version 2.0
+// that can be found in the LICENSE file.
+
+package genaitools
+
+import (
+	"context"
+	"encoding/json"
+	"strings"
+	"testing"
+)
+
+func TestRenderTemplate(t *testing.T) {
+	callback := RenderTemplate.Callback.(func(context.Context, *renderTemplateArgs) (string, error))
+	const data = `{"name": "web", "port": 8080, "ratio": 0.5, "tls": true, "hosts": ["a", "b"], "env": {"B": "2", "A": "1"}, "n": 1000}`
+	tests := []struct {
+		name      string
+		template  string
+		expected  string
+		errSubstr string
+	}{
+		{"field", "server {{.name}}:{{.port}}", "server web:8080", ""},
+		{"range", "{{range $i, $h := .hosts}}{{$i}}={{$h}}\n{{end}}", "0=a\n1=b\n", ""},
+		{"range_map", "{{range $k, $v := .env}}{{$k}}={{$v}};{{end}}", "A=1;B=2;", ""},
+		{"range_else", "{{range .missing_ok}}x{{else}}none{{end}}", "", `map has no entry for key "missing_ok"`},
+		{"if", "{{if .tls}}https{{else}}http{{end}}", "https", ""},
+		{"compare", "{{if gt .port 1024}}high{{end}} {{if lt .ratio 1.0}}low{{end}}", "high low", ""},
+		{"optional_key", `{{if index . "debug"}}debug{{else}}quiet{{end}}`, "quiet", ""},
+		{"printf", `{{printf "%05d|%.2f" .port .ratio}}`, "08080|0.50", ""},
+		{"define", `{{define "h"}}<{{.}}>{{end}}{{range .hosts}}{{template "h" .}}{{end}}`, "<a><b>", ""},
+		{"missing_key", "Hello {{.nme}}", "", `template: template:1:8: executing "template" at <.nme>: map has no entry for key "nme"`},
+		{"parse_error", "{{if .tls}}x", "", "template: template:1: unexpected EOF"},
+		{"unknown_function", "{{exec .name}}", "", `function "exec" not defined`},
+		{"call", "{{call .name}}", "", "call is disabled"},
+		{"printf_width", `{{printf "%999999999d" 1}}`, "", "printf width and precision are limited to 1000"},
+		{"printf_star", `{{printf "%*d" 5 1}}`, "", "printf width and precision are limited to 1000"},
+		{"printf_indexed_star", `{{printf "%[2]*[1]d" 1 5}}`, "", "printf width and precision are limited to 1000"},
+		{"printf_indexed_precision", `{{printf "%.[2]*[1]f" 1.5 2}}`, "", "printf width and precision are limited to 1000"},
+		{"printf_indexed_width", `{{printf "%[1]5000d" 1}}`, "", "printf width and precision are limited to 1000"},
+		{"printf_indexed", `{{printf "%[2]d-%[1]d" 1 2}}`, "2-1", ""},
+		{"range_int", "{{range 1000000000}}{{end}}", "", "more than 100000 loop iterations or template invocations"},
+		{"nested_ranges", "{{range .n}}{{range $.n}}{{end}}{{end}}", "", "more than 100000 loop iterations or template invocations"},
+		{"recursion", `{{define "r"}}{{template "r" .}}{{template "r" .}}{{end}}{{template "r" .}}`, "", "more than 100000 loop iterations or template invocations"},
+		{"output_cap", `{{range .n}}{{printf "%1000d" 1}}{{printf "%1000d" 1}}{{end}}`, "", "output exceeds 1048576 bytes"},
+		{"doubling", `{{$x := "ab"}}{{range 30}}{{$x = print $x $x}}{{end}}{{len $x}}`, "", "functions returned more than 16777216 bytes"},
+		{"escapers", `{{html "<a>"}} {{js "'"}} {{urlquery "a b"}} {{println 1 2}}`, "&lt;a&gt; \\' a+b 1 2\n", ""},
+		{"too_large", strings.Repeat("x", maxTemplate+1), "", "template is 65537 bytes; the maximum is 65536"},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			// Decode like the tool arguments.
+			args := renderTemplateArgs{Template: tt.template}
+			d := json.NewDecoder(strings.NewReader(data))
+			d.UseNumber()
+			if err := d.Decode(&args.Data); err != nil {
+				t.Fatal(err)
+			}
+			got, err := callback(t.Context(), &args)
+			if tt.errSubstr != "" {
+				if err == nil || !strings.Contains(err.Error(), tt.errSubstr) {
+					t.Fatalf("Expected error containing %q but got %v", tt.errSubstr, err)
+				}
+				return
+			}
+			if err != nil {
+				t.Fatalf("Unexpected error: %v", err)
+			}
+			if got != tt.expected {
+				t.Fatalf("Expected %q but got %q", tt.expected, got)
+			}
+		})
+	}
+}
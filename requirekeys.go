@@ -0,0 +1,171 @@
+// Copyright 2025 Marc-Antoine Ruel. All rights reserved.
+// Use of this source code is governed under the Apache License, Version 2.0
+// that can be found in the LICENSE file.
+
+package genaitools
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"math"
+	"strconv"
+	"strings"
+
+	"github.com/maruel/genai"
+	"gopkg.in/yaml.v3"
+)
+
+// RequireKeys verifies that a JSON or YAML document contains required keys,
+// optionally of a given type.
+//
+// Key paths are dot separated; a numeric segment indexes an array, e.g.
+// "servers.0.port". The report lists the keys present, missing and with an
+// unexpected type, as JSON.
+var RequireKeys = genai.ToolDef{
+	Name:        "require_keys",
+	Description: "Checks that a JSON or YAML document contains required keys (dot separated paths like server.port or servers.0.name) optionally with a type, and returns a JSON report of the keys present, missing and of invalid type.",
+	Callback:    doRequireKeys,
+}
+
+type requireKeysArgs struct {
+	Document string        `json:"document" jsonschema:"description=JSON or YAML document"`
+	Format   string        `json:"format,omitempty" jsonschema:"enum=json,enum=yaml"`
+	Keys     []requiredKey `json:"keys" jsonschema:"description=Keys that must be present"`
+}
+
+type requiredKey struct {
+	Path string `json:"path" jsonschema:"description=Dot separated path like server.port"`
+	Type string `json:"type,omitempty" jsonschema:"enum=string,enum=number,enum=integer,enum=boolean,enum=object,enum=array,enum=null"`
+}
+
+type invalidKey struct {
+	Path     string `json:"path"`
+	Expected string `json:"expected"`
+	Actual   string `json:"actual"`
+}
+
+type requireKeysResult struct {
+	Valid   bool         `json:"valid"`
+	Present []string     `json:"present"`
+	Missing []string     `json:"missing"`
+	Invalid []invalidKey `json:"invalid"`
+}
+
+func doRequireKeys(ctx context.Context, args *requireKeysArgs) (string, error) {
+	doc, err := parseDocument(args.Document, args.Format)
+	if err != nil {
+		return "", err
+	}
+	res := requireKeysResult{Present: []string{}, Missing: []string{}, Invalid: []invalidKey{}}
+	for _, k := range args.Keys {
+		if k.Path == "" {
+			return "", errors.New("empty key path")
+		}
+		v, ok := lookupPath(doc, k.Path)
+		if !ok {
+			res.Missing = append(res.Missing, k.Path)
+			continue
+		}
+		if k.Type != "" {
+			if !isValueType(v, k.Type) {
+				res.Invalid = append(res.Invalid, invalidKey{Path: k.Path, Expected: k.Type, Actual: valueType(v)})
+				continue
+			}
+		}
+		res.Present = append(res.Present, k.Path)
+	}
+	res.Valid = len(res.Missing) == 0 && len(res.Invalid) == 0
+	b, err := json.Marshal(res)
+	return string(b), err
+}
+
+// parseDocument parses a JSON or YAML document. An empty format detects JSON
+// by its first character and otherwise uses YAML.
+func parseDocument(s, format string) (any, error) {
+	if format == "" {
+		format = "yaml"
+		if t := strings.TrimSpace(s); strings.HasPrefix(t, "{") || strings.HasPrefix(t, "[") {
+			format = "json"
+		}
+	}
+	var doc any
+	switch format {
+	case "json":
+		d := json.NewDecoder(strings.NewReader(s))
+		d.UseNumber()
+		if err := d.Decode(&doc); err != nil {
+			return nil, fmt.Errorf("invalid JSON: %w", err)
+		}
+		if d.More() {
+			return nil, errors.New("invalid JSON: trailing data after the document")
+		}
+	case "yaml":
+		if err := yaml.NewDecoder(bytes.NewReader([]byte(s))).Decode(&doc); err != nil {
+			return nil, fmt.Errorf("invalid YAML: %w", err)
+		}
+	default:
+		return nil, fmt.Errorf("unknown format %q; must be json or yaml", format)
+	}
+	return doc, nil
+}
+
+// lookupPath returns the value at the dot separated path.
+func lookupPath(doc any, path string) (any, bool) {
+	v := doc
+	for seg := range strings.SplitSeq(path, ".") {
+		switch t := v.(type) {
+		case map[string]any:
+			var ok bool
+			if v, ok = t[seg]; !ok {
+				return nil, false
+			}
+		case []any:
+			i, err := strconv.Atoi(seg)
+			if err != nil || i < 0 || i >= len(t) {
+				return nil, false
+			}
+			v = t[i]
+		default:
+			return nil, false
+		}
+	}
+	return v, true
+}
+
+// valueType returns the JSON type of a decoded JSON or YAML value.
+func valueType(v any) string {
+	switch t := v.(type) {
+	case nil:
+		return "null"
+	case string:
+		return "string"
+	case bool:
+		return "boolean"
+	case map[string]any:
+		return "object"
+	case []any:
+		return "array"
+	case json.Number:
+		if _, err := t.Int64(); err == nil {
+			return "integer"
+		}
+		return "number"
+	case int, int64, uint64:
+		return "integer"
+	case float64:
+		if t == math.Trunc(t) && !math.IsInf(t, 0) {
+			return "integer"
+		}
+		return "number"
+	default:
+		return fmt.Sprintf("%T", v)
+	}
+}
+
+func isValueType(v any, typ string) bool {
+	actual := valueType(v)
+	return actual == typ || (typ == "number" && actual == "integer")
+}
@@ -0,0 +1,72 @@
+// Copyright 2025 Marc-Antoine Ruel. All rights reserved.
+// Use of this source code is governed under the Apache License, Version 2.0
+// that can be found in the LICENSE file.
+
+package genaitools
+
+import (
+	"context"
+	"strings"
+	"testing"
+)
+
+func TestRequireKeys(t *testing.T) {
+	callback := RequireKeys.Callback.(func(context.Context, *requireKeysArgs) (string, error))
+	keys := []requiredKey{
+		{Path: "server.host", Type: "string"},
+		{Path: "server.port", Type: "number"},
+		{Path: "server.tls"},
+		{Path: "servers.1.name"},
+		{Path: "debug", Type: "boolean"},
+		{Path: "ratio", Type: "integer"},
+	}
+	tests := []struct {
+		name      string
+		doc       string
+		format    string
+		expected  string
+		errSubstr string
+	}{
+		{
+			"json",
+			`{"server": {"host": "localhost", "port": 8080}, "servers": [{"name": "a"}, {"name": "b"}], "debug": true, "ratio": 1.5}`,
+			"",
+			`{"valid":false,"present":["server.host","server.port","servers.1.name","debug"],"missing":["server.tls"],"invalid":[{"path":"ratio","expected":"integer","actual":"number"}]}`,
+			"",
+		},
+		{
+			"yaml",
+			"server:\n  host: localhost\n  port: \"8080\"\n  tls: null\nservers:\n  - name: a\ndebug: false\nratio: 2\n",
+			"",
+			`{"valid":false,"present":["server.host","server.tls","debug","ratio"],"missing":["servers.1.name"],"invalid":[{"path":"server.port","expected":"number","actual":"string"}]}`,
+			"",
+		},
+		{
+			"valid",
+			"server: {host: h, port: 1, tls: {}}\nservers: [x, {name: y}]\ndebug: true\nratio: 3\n",
+			"yaml",
+			`{"valid":true,"present":["server.host","server.port","server.tls","servers.1.name","debug","ratio"],"missing":[],"invalid":[]}`,
+			"",
+		},
+		{"bad_json", `{"a":`, "json", "", "invalid JSON"},
+		{"bad_yaml", "a: [", "", "", "invalid YAML"},
+		{"bad_format", "a: 1", "toml", "", "unknown format"},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := callback(t.Context(), &requireKeysArgs{Document: tt.doc, Format: tt.format, Keys: keys})
+			if tt.errSubstr != "" {
+				if err == nil || !strings.Contains(err.Error(), tt.errSubstr) {
+					t.Fatalf("Expected error containing %q but got %v", tt.errSubstr, err)
+				}
+				return
+			}
+			if err != nil {
+				t.Fatalf("Unexpected error: %v", err)
+			}
+			if got != tt.expected {
+				t.Fatalf("Expected %s\ngot      %s", tt.expected, got)
+			}
+		})
+	}
+}
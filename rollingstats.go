@@ -0,0 +1,72 @@
+// Copyright 2025 Marc-Antoine Ruel. All rights reserved.
+// Use of this source code is governed under the Apache License, Version 2.0
+// that can be found in the LICENSE file.
+
+package genaitools
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"math"
+	"slices"
+
+	"github.com/maruel/genai"
+)
+
+// RollingStats computes a statistic over a sliding window of a series.
+//
+// It returns one value per full window, so the result has len(values) -
+// window + 1 items; the first one covers values[0:window].
+var RollingStats = genai.ToolDef{
+	Name:        "rolling_stats",
+	Description: "Computes a moving (rolling) mean, sum, min or max over a series of numbers with a sliding window and returns the resulting series as a JSON array with one value per full window.",
+	Callback:    doRollingStats,
+}
+
+type rollingStatsArgs struct {
+	Values    []json.Number `json:"values" jsonschema:"description=Series of numbers in order"`
+	Window    int           `json:"window" jsonschema:"description=Number of consecutive values in each window"`
+	Statistic string        `json:"statistic" jsonschema:"enum=mean,enum=sum,enum=min,enum=max"`
+}
+
+func doRollingStats(ctx context.Context, args *rollingStatsArgs) (string, error) {
+	var stat func([]float64) float64
+	switch args.Statistic {
+	case "mean":
+		stat = func(w []float64) float64 { return neumaierSum(w) / float64(len(w)) }
+	case "sum":
+		stat = neumaierSum
+	case "min":
+		stat = slices.Min[[]float64]
+	case "max":
+		stat = slices.Max[[]float64]
+	default:
+		return "", fmt.Errorf("unknown statistic %q; supported statistics are mean, sum, min and max", args.Statistic)
+	}
+	if len(args.Values) == 0 {
+		return "", errors.New("at least one value is required")
+	}
+	if args.Window < 1 || args.Window > len(args.Values) {
+		return "", fmt.Errorf("window must be between 1 and the number of values (%d); got %d", len(args.Values), args.Window)
+	}
+	values := make([]float64, len(args.Values))
+	for i, n := range args.Values {
+		f, err := n.Float64()
+		if err != nil {
+			return "", fmt.Errorf("couldn't understand value %d %q: %w", i, n, err)
+		}
+		values[i] = f
+	}
+	out := make([]json.Number, 0, len(values)-args.Window+1)
+	for i := args.Window; i <= len(values); i++ {
+		v := stat(values[i-args.Window : i])
+		if math.IsInf(v, 0) || math.IsNaN(v) {
+			return "", fmt.Errorf("window ending at value %d overflows: %v", i-1, v)
+		}
+		out = append(out, json.Number(formatFloat(v)))
+	}
+	b, err := json.Marshal(out)
+	return string(b), err
+}
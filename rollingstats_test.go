@@ -0,0 +1,56 @@
+// Copyright 2025 Marc-Antoine Ruel. All rights reserved.
+// Use of this source code is governed under the Apache License, Version 2.0
+// that can be found in the LICENSE file.
+
+package genaitools
+
+import (
+	"context"
+	"encoding/json"
+	"strings"
+	"testing"
+)
+
+func TestRollingStats(t *testing.T) {
+	callback := RollingStats.Callback.(func(context.Context, *rollingStatsArgs) (string, error))
+	series := []json.Number{"1", "3", "2", "8", "-4"}
+	tests := []struct {
+		name      string
+		values    []json.Number
+		window    int
+		statistic string
+		expected  string
+		errSubstr string
+	}{
+		{"mean", series, 2, "mean", "[2,2.500000,5,2]", ""},
+		{"sum", series, 3, "sum", "[6,13,6]", ""},
+		{"min", series, 3, "min", "[1,2,-4]", ""},
+		{"max", series, 3, "max", "[3,8,8]", ""},
+		{"window_one", series, 1, "sum", "[1,3,2,8,-4]", ""},
+		{"window_all", series, 5, "mean", "[2]", ""},
+		{"decimals", []json.Number{"0.1", "0.2", "0.3"}, 3, "sum", "[0.600000]", ""},
+		{"window_too_large", series, 6, "mean", "", "window must be between 1 and the number of values (5); got 6"},
+		{"window_zero", series, 0, "mean", "", "got 0"},
+		{"empty", nil, 1, "mean", "", "at least one value"},
+		{"unknown_statistic", series, 2, "median", "", `unknown statistic "median"`},
+		{"invalid_value", []json.Number{"1", "x"}, 1, "sum", "", `couldn't understand value 1 "x"`},
+		{"overflow", []json.Number{"1e308", "1e308"}, 2, "sum", "", "overflows"},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := callback(t.Context(), &rollingStatsArgs{Values: tt.values, Window: tt.window, Statistic: tt.statistic})
+			if tt.errSubstr != "" {
+				if err == nil || !strings.Contains(err.Error(), tt.errSubstr) {
+					t.Fatalf("Expected error containing %q but got %v", tt.errSubstr, err)
+				}
+				return
+			}
+			if err != nil {
+				t.Fatalf("Unexpected error: %v", err)
+			}
+			if got != tt.expected {
+				t.Fatalf("Expected %q but got %q", tt.expected, got)
+			}
+		})
+	}
+}
@@ -0,0 +1,217 @@
+// Copyright 2025 Marc-Antoine Ruel. All rights reserved.
+// Use of this source code is governed under the Apache License, Version 2.0
+// that can be found in the LICENSE file.
+
+package genaitools
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"strings"
+
+	"github.com/maruel/genai"
+)
+
+// ShellEscape quotes a string for safe use as a single shell argument, or
+// unquotes it.
+//
+// The posix style uses single quotes, inside which nothing is interpreted. The
+// powershell style uses single quotes with embedded quotes doubled. The cmd
+// style follows the Windows command line parsing rules of
+// CommandLineToArgvW; strings containing characters cmd.exe expands even
+// inside double quotes (% and !) or a newline are rejected.
+var ShellEscape = genai.ToolDef{
+	Name:        "shell_escape",
+	Description: "Quotes a string so it is passed as exactly one argument to a shell command (posix sh/bash/zsh, powershell or cmd), or unquotes a quoted argument. Use it to build shell scripts without quoting bugs.",
+	Callback:    doShellEscape,
+}
+
+type shellEscapeArgs struct {
+	Value     string `json:"value" jsonschema:"description=String to quote or quoted argument to unquote"`
+	Operation string `json:"operation,omitempty" jsonschema:"enum=escape,enum=unescape"`
+	Style     string `json:"style,omitempty" jsonschema:"enum=posix,enum=powershell,enum=cmd"`
+}
+
+func doShellEscape(ctx context.Context, args *shellEscapeArgs) (string, error) {
+	unescape := false
+	switch args.Operation {
+	case "", "escape":
+	case "unescape":
+		unescape = true
+	default:
+		return "", fmt.Errorf("unknown operation %q; must be escape or unescape", args.Operation)
+	}
+	switch args.Style {
+	case "", "posix":
+		if unescape {
+			return unquotePOSIX(args.Value)
+		}
+		return quotePOSIX(args.Value), nil
+	case "powershell":
+		if unescape {
+			return unquotePowerShell(args.Value)
+		}
+		return "'" + strings.ReplaceAll(args.Value, "'", "''") + "'", nil
+	case "cmd":
+		if unescape {
+			return unquoteCmd(args.Value)
+		}
+		return quoteCmd(args.Value)
+	default:
+		return "", fmt.Errorf("unknown style %q; must be posix, powershell or cmd", args.Style)
+	}
+}
+
+// quotePOSIX returns s unchanged when it only contains characters that are
+// never special to a POSIX shell, otherwise single quoted.
+func quotePOSIX(s string) string {
+	if s != "" && strings.Trim(s, "abcdefghijklmnopqrstuvwxyzABCDEFGHIJKLMNOPQRSTUVWXYZ0123456789_@%+=:,./-") == "" {
+		return s
+	}
+	// A single quote cannot appear inside single quotes; close the quote, add
+	// an escaped quote and reopen.
+	return "'" + strings.ReplaceAll(s, "'", `'\''`) + "'"
+}
+
+// unquotePOSIX parses s as a single POSIX shell word. Expansions ($, `) and
+// unquoted special characters are rejected since their value depends on the
+// shell state.
+func unquotePOSIX(s string) (string, error) {
+	var b strings.Builder
+	for i := 0; i < len(s); i++ {
+		c := s[i]
+		switch c {
+		case '\'':
+			end := strings.IndexByte(s[i+1:], '\'')
+			if end < 0 {
+				return "", fmt.Errorf("unterminated single quote at position %d", i)
+			}
+			b.WriteString(s[i+1 : i+1+end])
+			i += end + 1
+		case '"':
+			start := i
+			for i++; ; i++ {
+				if i >= len(s) {
+					return "", fmt.Errorf("unterminated double quote at position %d", start)
+				}
+				c := s[i]
+				if c == '"' {
+					break
+				}
+				if c == '$' || c == '`' {
+					return "", fmt.Errorf("expansion %q at position %d is not supported", c, i)
+				}
+				if c == '\\' && i+1 < len(s) && strings.IndexByte("$`\"\\\n", s[i+1]) >= 0 {
+					i++
+					if s[i] == '\n' {
+						continue
+					}
+					c = s[i]
+				}
+				b.WriteByte(c)
+			}
+		case '\\':
+			if i+1 >= len(s) {
+				return "", errors.New("trailing backslash")
+			}
+			i++
+			if s[i] != '\n' {
+				b.WriteByte(s[i])
+			}
+		case ' ', '\t', '\n':
+			return "", fmt.Errorf("unquoted whitespace at position %d; the value is more than one argument", i)
+		case '$', '`':
+			return "", fmt.Errorf("expansion %q at position %d is not supported", c, i)
+		case '|', '&', ';', '<', '>', '(', ')', '*', '?', '[', '#', '~':
+			return "", fmt.Errorf("unquoted special character %q at position %d", c, i)
+		default:
+			b.WriteByte(c)
+		}
+	}
+	return b.String(), nil
+}
+
+func unquotePowerShell(s string) (string, error) {
+	if len(s) < 2 || s[0] != '\'' || s[len(s)-1] != '\'' {
+		return "", errors.New("expected a single quoted powershell string")
+	}
+	inner := s[1 : len(s)-1]
+	if strings.Contains(strings.ReplaceAll(inner, "''", ""), "'") {
+		return "", errors.New("unescaped single quote in powershell string")
+	}
+	return strings.ReplaceAll(inner, "''", "'"), nil
+}
+
+// quoteCmd quotes s following the CommandLineToArgvW rules: backslashes are
+// only special before a double quote.
+func quoteCmd(s string) (string, error) {
+	if i := strings.IndexAny(s, "%!\r\n"); i >= 0 {
+		return "", fmt.Errorf("character %q at position %d cannot be safely quoted for cmd", s[i], i)
+	}
+	var b strings.Builder
+	b.WriteByte('"')
+	slashes := 0
+	for i := 0; i < len(s); i++ {
+		switch c := s[i]; c {
+		case '\\':
+			slashes++
+		case '"':
+			b.WriteString(strings.Repeat(`\`, slashes+1))
+			slashes = 0
+		default:
+			slashes = 0
+		}
+		b.WriteByte(s[i])
+	}
+	// Backslashes before the closing quote must be doubled.
+	b.WriteString(strings.Repeat(`\`, slashes))
+	b.WriteByte('"')
+	return b.String(), nil
+}
+
+// unquoteCmd parses s as a single argument following the CommandLineToArgvW
+// rules.
+func unquoteCmd(s string) (string, error) {
+	var b strings.Builder
+	inQuote := false
+	for i := 0; i < len(s); i++ {
+		c := s[i]
+		switch {
+		case c == '\\':
+			n := 1
+			for i+n < len(s) && s[i+n] == '\\' {
+				n++
+			}
+			if i+n < len(s) && s[i+n] == '"' {
+				b.WriteString(strings.Repeat(`\`, n/2))
+				i += n - 1
+				if n%2 == 1 {
+					// The quote is escaped.
+					b.WriteByte('"')
+					i++
+				}
+				// Otherwise the quote toggles quoting in the next iteration.
+				continue
+			}
+			b.WriteString(s[i : i+n])
+			i += n - 1
+		case c == '"':
+			if inQuote && i+1 < len(s) && s[i+1] == '"' {
+				// "" inside quotes is a literal quote.
+				b.WriteByte('"')
+				i++
+				continue
+			}
+			inQuote = !inQuote
+		case !inQuote && (c == ' ' || c == '\t'):
+			return "", fmt.Errorf("unquoted whitespace at position %d; the value is more than one argument", i)
+		default:
+			b.WriteByte(c)
+		}
+	}
+	if inQuote {
+		return "", errors.New("unterminated double quote")
+	}
+	return b.String(), nil
+}
@@ -0,0 +1,61 @@
+// Copyright 2025 Marc-Antoine Ruel. All rights reserved.
+// Use of this source code is governed under the Apache License, Version 2.0
+// that can be found in the LICENSE file.
+
+package genaitools
+
+import (
+	"context"
+	"strings"
+	"testing"
+)
+
+func TestShellEscape(t *testing.T) {
+	callback := ShellEscape.Callback.(func(context.Context, *shellEscapeArgs) (string, error))
+	tests := []struct {
+		name      string
+		args      shellEscapeArgs
+		expected  string
+		errSubstr string
+	}{
+		{"posix_safe", shellEscapeArgs{Value: "file.txt"}, "file.txt", ""},
+		{"posix_empty", shellEscapeArgs{Value: ""}, "''", ""},
+		{"posix_space", shellEscapeArgs{Value: "a b"}, "'a b'", ""},
+		{"posix_quote", shellEscapeArgs{Value: "it's $HOME"}, `'it'\''s $HOME'`, ""},
+		{"posix_unescape_single", shellEscapeArgs{Value: `'it'\''s $HOME'`, Operation: "unescape"}, "it's $HOME", ""},
+		{"posix_unescape_double", shellEscapeArgs{Value: `"a \"b\" \\c"d`, Operation: "unescape"}, `a "b" \cd`, ""},
+		{"posix_unescape_backslash", shellEscapeArgs{Value: `a\ b`, Operation: "unescape"}, "a b", ""},
+		{"posix_unescape_two_words", shellEscapeArgs{Value: "a b", Operation: "unescape"}, "", "more than one argument"},
+		{"posix_unescape_expansion", shellEscapeArgs{Value: `"$HOME"`, Operation: "unescape"}, "", "expansion"},
+		{"posix_unescape_unterminated", shellEscapeArgs{Value: "'abc", Operation: "unescape"}, "", "unterminated single quote"},
+		{"posix_unescape_special", shellEscapeArgs{Value: "a;b", Operation: "unescape"}, "", "special character"},
+		{"powershell", shellEscapeArgs{Value: "it's $env:PATH", Style: "powershell"}, "'it''s $env:PATH'", ""},
+		{"powershell_unescape", shellEscapeArgs{Value: "'it''s'", Style: "powershell", Operation: "unescape"}, "it's", ""},
+		{"powershell_unescape_bad", shellEscapeArgs{Value: "'it's'", Style: "powershell", Operation: "unescape"}, "", "unescaped single quote"},
+		{"cmd", shellEscapeArgs{Value: `C:\Program Files\`, Style: "cmd"}, `"C:\Program Files\\"`, ""},
+		{"cmd_quote", shellEscapeArgs{Value: `say \"hi"`, Style: "cmd"}, `"say \\\"hi\""`, ""},
+		{"cmd_percent", shellEscapeArgs{Value: "100%", Style: "cmd"}, "", "cannot be safely quoted"},
+		{"cmd_unescape", shellEscapeArgs{Value: `"say \\\"hi\""`, Style: "cmd", Operation: "unescape"}, `say \"hi"`, ""},
+		{"cmd_unescape_trailing", shellEscapeArgs{Value: `"C:\Program Files\\"`, Style: "cmd", Operation: "unescape"}, `C:\Program Files\`, ""},
+		{"cmd_unescape_two_words", shellEscapeArgs{Value: `a b`, Style: "cmd", Operation: "unescape"}, "", "more than one argument"},
+		{"bad_style", shellEscapeArgs{Value: "a", Style: "fish"}, "", "unknown style"},
+		{"bad_operation", shellEscapeArgs{Value: "a", Operation: "reverse"}, "", "unknown operation"},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := callback(t.Context(), &tt.args)
+			if tt.errSubstr != "" {
+				if err == nil || !strings.Contains(err.Error(), tt.errSubstr) {
+					t.Fatalf("Expected error containing %q but got %v", tt.errSubstr, err)
+				}
+				return
+			}
+			if err != nil {
+				t.Fatalf("Unexpected error: %v", err)
+			}
+			if got != tt.expected {
+				t.Fatalf("Expected %q but got %q", tt.expected, got)
+			}
+		})
+	}
+}
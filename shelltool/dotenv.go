@@ -0,0 +1,125 @@
+// Copyright 2025 Marc-Antoine Ruel. All rights reserved.
+// Use of this source code is governed under the Apache License, Version 2.0
+// that can be found in the LICENSE file.
+
+package shelltool
+
+import (
+	"errors"
+	"fmt"
+	"os"
+	"strings"
+)
+
+// loadDotenv reads a dotenv file and returns its variables as KEY=VALUE.
+func loadDotenv(path string) ([]string, error) {
+	fi, err := os.Stat(path)
+	if err != nil {
+		return nil, err
+	}
+	if !fi.Mode().IsRegular() {
+		return nil, fmt.Errorf("%s is not a regular file", path)
+	}
+	b, err := os.ReadFile(path)
+	if err != nil {
+		return nil, err
+	}
+	env, err := parseDotenv(string(b))
+	if err != nil {
+		return nil, fmt.Errorf("%s:%w", path, err)
+	}
+	return env, nil
+}
+
+// parseDotenv parses the content of a dotenv file.
+//
+// Each line is KEY=VALUE, optionally prefixed with "export ". Blank lines and
+// lines starting with # are ignored. Values can be single quoted (literal),
+// double quoted (with \n, \t, \" and \\ escapes) or unquoted, in which case
+// surrounding whitespace and a trailing " # comment" are removed. Variables
+// are not expanded.
+func parseDotenv(s string) ([]string, error) {
+	var env []string
+	for i, line := range strings.Split(s, "\n") {
+		line = strings.TrimSpace(strings.TrimSuffix(line, "\r"))
+		if line == "" || line[0] == '#' {
+			continue
+		}
+		line = strings.TrimPrefix(line, "export ")
+		k, v, ok := strings.Cut(line, "=")
+		if !ok {
+			return nil, fmt.Errorf("%d: expected KEY=VALUE", i+1)
+		}
+		k = strings.TrimSpace(k)
+		if !isEnvName(k) {
+			return nil, fmt.Errorf("%d: invalid variable name %q", i+1, k)
+		}
+		v, err := parseDotenvValue(strings.TrimSpace(v))
+		if err != nil {
+			return nil, fmt.Errorf("%d: %w", i+1, err)
+		}
+		env = append(env, k+"="+v)
+	}
+	return env, nil
+}
+
+func parseDotenvValue(v string) (string, error) {
+	if v == "" {
+		return "", nil
+	}
+	switch v[0] {
+	case '\'':
+		end := strings.IndexByte(v[1:], '\'')
+		if end < 0 {
+			return "", errors.New("unterminated single quote")
+		}
+		if rest := strings.TrimSpace(v[end+2:]); rest != "" && rest[0] != '#' {
+			return "", fmt.Errorf("unexpected %q after the quoted value", rest)
+		}
+		return v[1 : end+1], nil
+	case '"':
+		var b strings.Builder
+		for i := 1; i < len(v); i++ {
+			switch c := v[i]; c {
+			case '"':
+				if rest := strings.TrimSpace(v[i+1:]); rest != "" && rest[0] != '#' {
+					return "", fmt.Errorf("unexpected %q after the quoted value", rest)
+				}
+				return b.String(), nil
+			case '\\':
+				if i++; i == len(v) {
+					return "", errors.New("unterminated double quote")
+				}
+				switch e := v[i]; e {
+				case 'n':
+					b.WriteByte('\n')
+				case 't':
+					b.WriteByte('\t')
+				case 'r':
+					b.WriteByte('\r')
+				default:
+					b.WriteByte(e)
+				}
+			default:
+				b.WriteByte(c)
+			}
+		}
+		return "", errors.New("unterminated double quote")
+	}
+	if i := strings.Index(v, " #"); i >= 0 {
+		v = strings.TrimSpace(v[:i])
+	}
+	return v, nil
+}
+
+func isEnvName(s string) bool {
+	if s == "" || (s[0] >= '0' && s[0] <= '9') {
+		return false
+	}
+	for _, c := range []byte(s) {
+		if !(c == '_' || (c >= 'a' && c <= 'z') || (c >= 'A' && c <= 'Z') || (c >= '0' && c <= '9')) {
+			return false
+		}
+	}
+	return true
+}
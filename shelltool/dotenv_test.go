@@ -0,0 +1,74 @@
+// Copyright 2025 Marc-Antoine Ruel. All rights reserved.
+// Use of this source code is governed under the Apache License, Version 2.0
+// that can be found in the LICENSE file.
+
+package shelltool
+
+import (
+	"os"
+	"path/filepath"
+	"slices"
+	"strings"
+	"testing"
+)
+
+func TestParseDotenv(t *testing.T) {
+	content := `# Comment
+FOO=bar
+export BAZ = qux # trailing comment
+EMPTY=
+SINGLE='lit $eral \n'
+DOUBLE="line1\nline2 \"quoted\"" # comment
+URL=http://example.com/#anchor
+
+WIN=value` + "\r\n"
+	got, err := parseDotenv(content)
+	if err != nil {
+		t.Fatal(err)
+	}
+	want := []string{
+		"FOO=bar",
+		"BAZ=qux",
+		"EMPTY=",
+		`SINGLE=lit $eral \n`,
+		"DOUBLE=line1\nline2 \"quoted\"",
+		"URL=http://example.com/#anchor",
+		"WIN=value",
+	}
+	if !slices.Equal(got, want) {
+		t.Fatalf("unexpected result\nwant: %q\ngot:  %q", want, got)
+	}
+	data := []struct {
+		in  string
+		err string
+	}{
+		{"FOO", "1: expected KEY=VALUE"},
+		{"\n1FOO=bar", "2: invalid variable name \"1FOO\""},
+		{"FOO-BAR=x", "1: invalid variable name \"FOO-BAR\""},
+		{"FOO='bar", "1: unterminated single quote"},
+		{"FOO=\"bar", "1: unterminated double quote"},
+		{"FOO=\"bar\" baz", "1: unexpected \"baz\" after the quoted value"},
+	}
+	for _, line := range data {
+		if _, err := parseDotenv(line.in); err == nil || err.Error() != line.err {
+			t.Fatalf("%q: want error %q, got %v", line.in, line.err, err)
+		}
+	}
+}
+
+func TestLoadDotenv(t *testing.T) {
+	dir := t.TempDir()
+	p := filepath.Join(dir, ".env")
+	if err := os.WriteFile(p, []byte("A=1\nB\n"), 0o600); err != nil {
+		t.Fatal(err)
+	}
+	if _, err := loadDotenv(p); err == nil || err.Error() != p+":2: expected KEY=VALUE" {
+		t.Fatalf("unexpected error %v", err)
+	}
+	if _, err := loadDotenv(dir); err == nil || !strings.Contains(err.Error(), "is not a regular file") {
+		t.Fatalf("unexpected error %v", err)
+	}
+	if _, err := loadDotenv(filepath.Join(dir, "missing")); err == nil {
+		t.Fatal("expected error")
+	}
+}
@@ -0,0 +1,134 @@
+// Copyright 2025 Marc-Antoine Ruel. All rights reserved.
+// Use of this source code is governed under the Apache License, Version 2.0
+// that can be found in the LICENSE file.
+
+package shelltool
+
+import (
+	"container/list"
+	"encoding/json"
+	"fmt"
+	"strings"
+	"sync"
+	"unicode/utf8"
+)
+
+// PageToolName is the name of the tool returned by NewWithOptions along the
+// shell tool when Options.PageSize is set.
+const PageToolName = "get_output_page"
+
+// pageArguments is the argument of the PageToolName tool.
+//
+// Every field must have a jsonschema description so the LLM uses it correctly.
+type pageArguments struct {
+	RunID string `json:"run_id" jsonschema:"description=run_id of the run whose output was split in pages"`
+	Page  int    `json:"page" jsonschema:"description=1-based page number"`
+}
+
+// pagedOutput is the output of a run split in pages.
+type pagedOutput struct {
+	exitCode int
+	pages    []string
+}
+
+// outputCache keeps the paged output of the most recent runs.
+type outputCache struct {
+	mu    sync.Mutex
+	max   int
+	order *list.List // Of string run IDs, most recently used first.
+	runs  map[string]*list.Element
+	data  map[string]*pagedOutput
+}
+
+func newOutputCache(max int) *outputCache {
+	return &outputCache{max: max, order: list.New(), runs: map[string]*list.Element{}, data: map[string]*pagedOutput{}}
+}
+
+// add stores p, evicting the least recently used run when full.
+func (c *outputCache) add(runID string, p *pagedOutput) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	c.runs[runID] = c.order.PushFront(runID)
+	c.data[runID] = p
+	for c.order.Len() > c.max {
+		e := c.order.Back()
+		c.order.Remove(e)
+		delete(c.runs, e.Value.(string))
+		delete(c.data, e.Value.(string))
+	}
+}
+
+// get returns the paged output of runID and marks it as recently used.
+func (c *outputCache) get(runID string) *pagedOutput {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	e, ok := c.runs[runID]
+	if !ok {
+		return nil
+	}
+	c.order.MoveToFront(e)
+	return c.data[runID]
+}
+
+// splitPages splits s in pages of at most size bytes. A page ends after the
+// last newline that fits when there is one, otherwise on a rune boundary.
+func splitPages(s string, size int) []string {
+	var pages []string
+	for len(s) > size {
+		n := size
+		if i := strings.LastIndexByte(s[:n], '\n'); i >= 0 {
+			n = i + 1
+		} else {
+			for n > 0 && !utf8.RuneStart(s[n]) {
+				n--
+			}
+			if n == 0 {
+				// A single rune larger than size.
+				_, n = utf8.DecodeRuneInString(s)
+			}
+		}
+		pages = append(pages, s[:n])
+		s = s[n:]
+	}
+	if s != "" || len(pages) == 0 {
+		pages = append(pages, s)
+	}
+	return pages
+}
+
+// pageFooter tells the LLM how to get the next page.
+func pageFooter(runID string, page, pages int) string {
+	if page == pages {
+		return fmt.Sprintf("... [page %d of %d]", page, pages)
+	}
+	return fmt.Sprintf("... [page %d of %d; call %s with run_id %q and page %d for more]", page, pages, PageToolName, runID, page+1)
+}
+
+// formatPage returns the tool result for a page of a run.
+func (o *Options) formatPage(runID string, p *pagedOutput, page int) (string, error) {
+	out := p.pages[page-1]
+	if o.StructuredOutput {
+		b, err := json.Marshal(&result{RunID: runID, ExitCode: p.exitCode, Output: out, Page: page, Pages: len(p.pages)})
+		return string(b), err
+	}
+	if out != "" && !strings.HasSuffix(out, "\n") {
+		out += "\n"
+	}
+	out += pageFooter(runID, page, len(p.pages))
+	if p.exitCode != 0 && page == 1 {
+		out = fmt.Sprintf("EXIT_CODE=%d\n", p.exitCode) + out
+	}
+	return out, nil
+}
+
+// page returns a page requested by the LLM.
+func (o *Options) page(c *outputCache, args *pageArguments) (string, error) {
+	p := c.get(args.RunID)
+	if p == nil {
+		return fmt.Sprintf("unknown run_id %q; only the output of the last %d paged runs is kept", args.RunID, c.max), nil
+	}
+	if args.Page < 1 || args.Page > len(p.pages) {
+		return fmt.Sprintf("invalid page %d; run_id %q has pages 1 to %d", args.Page, args.RunID, len(p.pages)), nil
+	}
+	return o.formatPage(args.RunID, p, args.Page)
+}
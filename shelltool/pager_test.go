@@ -0,0 +1,129 @@
+// Copyright 2025 Marc-Antoine Ruel. All rights reserved.
+// Use of this source code is governed under the Apache License, Version 2.0
+// that can be found in the LICENSE file.
+
+package shelltool
+
+import (
+	"context"
+	"fmt"
+	"maps"
+	"slices"
+	"strings"
+	"testing"
+)
+
+func TestSplitPages(t *testing.T) {
+	data := []struct {
+		in   string
+		size int
+		want []string
+	}{
+		{"", 4, []string{""}},
+		{"abc", 4, []string{"abc"}},
+		{"abcdefghij", 4, []string{"abcd", "efgh", "ij"}},
+		{"ab\ncd\nef\n", 7, []string{"ab\ncd\n", "ef\n"}},
+		{"a\nbcdefgh", 4, []string{"a\n", "bcde", "fgh"}},
+		// Runes are not split.
+		{"aé€", 4, []string{"aé", "€"}},
+		{"€€", 2, []string{"€", "€"}},
+	}
+	for i, line := range data {
+		got := splitPages(line.in, line.size)
+		if !slices.Equal(got, line.want) {
+			t.Fatalf("#%d: Expected %q but got %q", i, line.want, got)
+		}
+		if strings.Join(got, "") != line.in {
+			t.Fatalf("#%d: Pages do not add up to the input", i)
+		}
+	}
+}
+
+func TestOutputCache(t *testing.T) {
+	c := newOutputCache(2)
+	for i := range 3 {
+		c.add(fmt.Sprint(i), &pagedOutput{exitCode: i})
+		if i == 1 {
+			// Mark 0 as recently used so 1 is evicted instead.
+			if c.get("0") == nil {
+				t.Fatal("Expected run 0")
+			}
+		}
+	}
+	if c.get("1") != nil {
+		t.Fatal("Expected run 1 to be evicted")
+	}
+	for _, id := range []string{"0", "2"} {
+		if c.get(id) == nil {
+			t.Fatalf("Expected run %s", id)
+		}
+	}
+}
+
+func TestPaging(t *testing.T) {
+	if err := (&Options{PageSize: -1}).validate(); err == nil {
+		t.Fatal("Expected error for a negative PageSize")
+	}
+	script := "line1\nline2\nline3\nline4\n"
+	for _, structured := range []bool{false, true} {
+		o := Options{PageSize: 12, StructuredOutput: structured}
+		s := fakeSandbox()
+		s.pages = newOutputCache(DefaultMaxPagedRuns)
+		first, err := o.run(t.Context(), s, &arguments{Script: script})
+		if err != nil {
+			t.Fatal(err)
+		}
+		if len(s.pages.data) != 1 {
+			t.Fatalf("Expected one paged run but got %d", len(s.pages.data))
+		}
+		runID := slices.Collect(maps.Keys(s.pages.data))[0]
+		var want []string
+		if structured {
+			want = []string{
+				`{"run_id":"` + runID + `","exit_code":0,"output":"line1\nline2\n","page":1,"pages":2}`,
+				`{"run_id":"` + runID + `","exit_code":0,"output":"line3\nline4\n","page":2,"pages":2}`,
+			}
+		} else {
+			want = []string{
+				"line1\nline2\n... [page 1 of 2; call get_output_page with run_id \"" + runID + "\" and page 2 for more]",
+				"line3\nline4\n... [page 2 of 2]",
+			}
+		}
+		if first != want[0] {
+			t.Fatalf("Expected %q but got %q", want[0], first)
+		}
+		got, err := o.page(s.pages, &pageArguments{RunID: runID, Page: 2})
+		if err != nil {
+			t.Fatal(err)
+		}
+		if got != want[1] {
+			t.Fatalf("Expected %q but got %q", want[1], got)
+		}
+		if got, _ = o.page(s.pages, &pageArguments{RunID: runID, Page: 3}); !strings.HasPrefix(got, "invalid page 3") {
+			t.Fatalf("Unexpected result %q", got)
+		}
+		if got, _ = o.page(s.pages, &pageArguments{RunID: "nope", Page: 1}); !strings.HasPrefix(got, `unknown run_id "nope"`) {
+			t.Fatalf("Unexpected result %q", got)
+		}
+		// Small outputs are not paged.
+		if got, _ = o.run(t.Context(), s, &arguments{Script: "hi\n"}); strings.Contains(got, "page") {
+			t.Fatalf("Unexpected paging %q", got)
+		}
+	}
+}
+
+func TestPagingExitCode(t *testing.T) {
+	s := fakeSandbox()
+	s.exec = func(ctx context.Context, r *execRequest) (string, error) {
+		return "0123456789", &exitError{code: 2}
+	}
+	s.pages = newOutputCache(1)
+	o := Options{PageSize: 6}
+	got, err := o.run(t.Context(), s, &arguments{Script: "x"})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if !strings.HasPrefix(got, "EXIT_CODE=2\n012345\n... [page 1 of 2;") {
+		t.Fatalf("Unexpected result %q", got)
+	}
+}
@@ -6,26 +6,732 @@
 package shelltool
 
 import (
+	"bytes"
+	"context"
+	"crypto/rand"
+	"encoding/hex"
+	"encoding/json"
+	"errors"
 	"fmt"
+	"log/slog"
 	"os"
+	"os/exec"
+	"path"
+	"path/filepath"
+	"runtime"
+	"strings"
+	"time"
 
 	"github.com/maruel/genai"
 )
 
+// Options configures the shell tool.
+type Options struct {
+	// Transform is called with the script submitted by the LLM before it is
+	// written to disk and executed. It can be used to enforce policies or add
+	// instrumentation consistently, e.g. prepend "set -euo pipefail".
+	//
+	// When it returns an error, the script is not run and the error message is
+	// returned to the LLM as the tool result.
+	Transform func(script string) (string, error)
+	// Policy is consulted before each execution, after Transform, e.g. to
+	// delegate the decision to a central policy engine. Defaults to allowing
+	// everything.
+	//
+	// When the decision is to deny, the script is not run and the reason is
+	// returned to the LLM as the tool result. When it returns an error, the
+	// script is not run and the error is returned.
+	Policy func(ctx context.Context, req ScriptRequest) (Decision, error)
+	// WindowsProfileName is the prefix of the AppContainer profile names
+	// created on Windows. A unique suffix is appended for each run so
+	// concurrent runs do not clash. Defaults to a name unique to the process.
+	WindowsProfileName string
+	// StructuredOutput returns a JSON object as the tool result instead of the
+	// plain output. It includes the run ID, which is also logged with each
+	// execution, so a tool result can be correlated with what ran, and the exit
+	// code of the script.
+	//
+	// In both modes, a script that ran and exited with a nonzero code is not an
+	// error: the exit code is returned to the LLM along with the output. Without
+	// StructuredOutput, the output is prefixed with a "EXIT_CODE=N" line.
+	StructuredOutput bool
+	// LinuxProc controls how /proc is exposed to the script on Linux. Defaults
+	// to mounting a new procfs.
+	LinuxProc Mount
+	// LinuxSys controls how /sys is exposed to the script on Linux. Defaults
+	// to leaving it as inherited from the read-only root.
+	LinuxSys Mount
+	// LinuxUID and LinuxGID are the user and group IDs the script runs as on
+	// Linux, via bubblewrap's --uid and --gid in a new user namespace. Zero
+	// keeps the current IDs.
+	//
+	// This requires unprivileged user namespaces to be enabled
+	// (kernel.unprivileged_userns_clone) or a setuid bwrap; NewWithOptions
+	// fails when bwrap cannot switch IDs.
+	LinuxUID int
+	LinuxGID int
+	// WritablePaths are absolute paths to existing files or directories the
+	// script can write to on macOS and Linux, in addition to /tmp.
+	// NewWithOptions fails if a path is relative or does not exist.
+	WritablePaths []string
+	// Interpreters are additional interpreters the LLM can choose to run the
+	// script with, e.g. python3. The default is the sandbox's shell. The script
+	// is always written to a file when one of these is used. NewWithOptions
+	// fails if an interpreter is not an absolute path to an existing file.
+	Interpreters []Interpreter
+	// LinuxSecretFile is the path to a file, e.g. a token, bound read-only into
+	// the sandbox at LinuxSecretPath on Linux. It lets the script read exactly
+	// this secret without passing it through the environment. NewWithOptions
+	// fails if it is not a regular file.
+	LinuxSecretFile string
+	// MaxScriptSize is the maximum size in bytes of the script, after
+	// Transform. Larger scripts are rejected before being written to disk.
+	// Defaults to DefaultMaxScriptSize. Use a negative value to disable the
+	// limit.
+	MaxScriptSize int
+	// ScriptViaStdin pipes the script to the interpreter's stdin (bash -s,
+	// zsh -s, powershell -Command -) instead of writing it to a temporary
+	// file. No file is created and nothing needs to be bound into the sandbox,
+	// but the script cannot read from stdin itself. When the LLM provides
+	// stdin data, the script is written to a file for that run.
+	ScriptViaStdin bool
+	// EnvFile is the path to a dotenv file whose variables are added to the
+	// environment of the script. It is read once by NewWithOptions, which fails
+	// if the file cannot be parsed. Variables are not expanded.
+	EnvFile string
+	// EnvAllowlist restricts the variables inherited from the current process
+	// to the names matching these patterns, e.g. "PATH" or "LC_*". Patterns use
+	// the path.Match syntax. When empty, all the variables are inherited.
+	//
+	// The environment of the script is constructed explicitly the same way on
+	// all platforms: the inherited variables, then LANG=C, then the variables
+	// from EnvFile, with later values overriding earlier ones. Names are case
+	// insensitive on Windows.
+	//
+	// The interpreters need a few variables to work properly:
+	//   - Linux and macOS: PATH, HOME.
+	//   - Windows: PATH, PATHEXT, SystemRoot, windir, TEMP, TMP, USERPROFILE.
+	EnvAllowlist []string
+	// EnvDenylist removes the inherited variables whose names match these
+	// patterns, case insensitively, so credentials like OPENAI_API_KEY do not
+	// leak to the script. It applies after EnvAllowlist and not to EnvFile.
+	// Defaults to DefaultEnvDenylist when nil; use an empty non-nil slice to
+	// not remove anything.
+	EnvDenylist []string
+	// Timeout is the maximum wall-clock duration of a run. When it expires, the
+	// script is killed and the tool returns an error wrapping
+	// context.DeadlineExceeded, so it can be told apart from a nonzero exit
+	// code. Zero means no limit.
+	Timeout time.Duration
+	// MaxOutputSize is the maximum number of bytes of combined output kept
+	// from a run. Once reached, the output is truncated with a trailing
+	// "... [output truncated]" marker and no more output is read. Defaults to
+	// DefaultMaxOutputSize. Use a negative value to disable the limit.
+	MaxOutputSize int
+	// PageSize enables paging of large outputs. When the output of a run is
+	// longer than PageSize bytes, only its first page is returned and
+	// NewWithOptions returns a second tool named PageToolName that the LLM can
+	// call with the run ID to retrieve the following pages. Pages end on a line
+	// boundary when possible. Zero disables paging.
+	//
+	// The output is still capped to MaxOutputSize before being split, so the
+	// memory used is bounded by MaxPagedRuns * MaxOutputSize.
+	PageSize int
+	// MaxPagedRuns is the number of runs whose paged output is kept in memory
+	// when PageSize is set. When full, the least recently used run is evicted
+	// and its pages cannot be retrieved anymore. Defaults to
+	// DefaultMaxPagedRuns.
+	MaxPagedRuns int
+
+	_ struct{}
+}
+
+// ErrSandboxSetup is wrapped by the error returned when the sandbox itself
+// (bwrap or sandbox-exec) failed to initialize before the script ran, e.g. an
+// invalid profile or user namespaces denied by the kernel.
+//
+// On Linux, it is only returned when bwrap did not report starting the
+// sandboxed process. On macOS, it is inferred from the error message of
+// sandbox-exec.
+var ErrSandboxSetup = errors.New("sandbox setup failed")
+
+// DefaultEnvDenylist is the default value of Options.EnvDenylist. It matches
+// common names of variables holding credentials.
+var DefaultEnvDenylist = []string{
+	"*API_KEY*",
+	"*APIKEY*",
+	"*ACCESS_KEY*",
+	"*PRIVATE_KEY*",
+	"*SECRET*",
+	"*TOKEN*",
+	"*PASSWORD*",
+	"*PASSWD*",
+	"*CREDENTIAL*",
+	"*_AUTH",
+	"SSH_AUTH_SOCK",
+}
+
+// ScriptRequest describes a script about to be run, for Options.Policy.
+type ScriptRequest struct {
+	// Script is the content of the script, after Options.Transform.
+	Script string
+	// Interpreter is the Name of the Interpreter selected by the LLM, or empty
+	// for the sandbox's shell.
+	Interpreter string
+	// Stdin is the data piped to the script.
+	Stdin string
+	// Dir is the absolute directory the script runs in, or empty for the
+	// current directory.
+	Dir string
+	// AllowNetwork is the value passed to New or NewWithOptions.
+	AllowNetwork bool
+	// WritablePaths is Options.WritablePaths.
+	WritablePaths []string
+
+	_ struct{}
+}
+
+// Decision is the result of Options.Policy.
+type Decision struct {
+	// Allow lets the script run.
+	Allow bool
+	// Reason explains a denial to the LLM.
+	Reason string
+
+	_ struct{}
+}
+
+// Interpreter is a script interpreter the LLM can select.
+type Interpreter struct {
+	// Name is the value the LLM passes to select it, e.g. "python3".
+	Name string
+	// Path is the absolute path to the executable, e.g. "/usr/bin/python3". It
+	// is called with the path to the script as its only argument.
+	Path string
+	// Ext is the script file extension, e.g. ".py".
+	Ext string
+
+	_ struct{}
+}
+
+// LinuxSecretPath is where Options.LinuxSecretFile is readable inside the
+// sandbox.
+const LinuxSecretPath = "/tmp/secret"
+
+// DefaultMaxScriptSize is the default value of Options.MaxScriptSize.
+const DefaultMaxScriptSize = 1 << 20
+
+// DefaultMaxOutputSize is the default value of Options.MaxOutputSize.
+const DefaultMaxOutputSize = 256 << 10
+
+// DefaultMaxPagedRuns is the default value of Options.MaxPagedRuns.
+const DefaultMaxPagedRuns = 16
+
+// Mount controls how a pseudo filesystem like /proc or /sys is exposed inside
+// the sandbox.
+type Mount int
+
+const (
+	// MountDefault keeps the default behavior for the path.
+	MountDefault Mount = iota
+	// MountNew mounts a new instance of the filesystem. Only supported for
+	// /proc; /sys is bound read-only instead since bubblewrap cannot mount a
+	// new sysfs.
+	MountNew
+	// MountHide hides the path behind an empty tmpfs.
+	MountHide
+	// MountReadOnly binds the host path read-only.
+	MountReadOnly
+)
+
+func (m Mount) validate() error {
+	if m < MountDefault || m > MountReadOnly {
+		return fmt.Errorf("invalid Mount value %d", m)
+	}
+	return nil
+}
+
 // New return a shell tool that works on the current OS.
 //
-// If allowNetwork is false, the script will not have network access.
+// If allowNetwork is false, the script will not have network access. It uses
+// the default options; see NewWithOptions to customize the sandbox.
 //
 //   - On macOS, it runs /bin/zsh under sandbox-exec.
-//   - On Windows, it runs powershell under a restricted user token. It is currently disabled due to a crash in the Go runtime.
+//   - On Windows, it runs powershell under a restricted user token, inside an AppContainer without network capability when allowNetwork is false.
 //   - On other platforms, it runs bash under bubblewrap. bubblewrap must be installed separately.
 func New(allowNetwork bool) (*genai.GenOptionTools, error) {
-	return getShellTool(allowNetwork)
+	return NewWithOptions(allowNetwork, nil)
+}
+
+// NewWithOptions is New with options. opts can be nil.
+func NewWithOptions(allowNetwork bool, opts *Options) (*genai.GenOptionTools, error) {
+	o := Options{}
+	if opts != nil {
+		o = *opts
+	}
+	if err := o.validate(); err != nil {
+		return nil, err
+	}
+	s, err := newSandbox(allowNetwork, &o)
+	if err != nil {
+		return nil, err
+	}
+	s.allowNetwork = allowNetwork
+	if len(o.Interpreters) != 0 {
+		names := []string{s.name + " (default)"}
+		for _, i := range o.Interpreters {
+			names = append(names, i.Name)
+		}
+		s.description += ". Available interpreters: " + strings.Join(names, ", ")
+	}
+	if o.EnvFile != "" {
+		if s.env, err = loadDotenv(o.EnvFile); err != nil {
+			return nil, fmt.Errorf("EnvFile: %w", err)
+		}
+	}
+	tools := []genai.ToolDef{
+		{
+			Name:        s.name,
+			Description: s.description,
+			Callback: func(ctx context.Context, args *arguments) (string, error) {
+				return o.run(ctx, s, args)
+			},
+		},
+	}
+	if o.PageSize > 0 {
+		n := o.MaxPagedRuns
+		if n == 0 {
+			n = DefaultMaxPagedRuns
+		}
+		s.pages = newOutputCache(n)
+		tools = append(tools, genai.ToolDef{
+			Name:        PageToolName,
+			Description: "Returns a page of the output of a previous " + s.name + " run whose output was split in pages",
+			Callback: func(ctx context.Context, args *pageArguments) (string, error) {
+				return o.page(s.pages, args)
+			},
+		})
+	}
+	return &genai.GenOptionTools{Tools: tools}, nil
+}
+
+// PreviewPolicy returns the sandbox policy that NewWithOptions would enforce
+// with the same arguments, without running anything. This is meant to log the
+// policy for auditing or to compare it across releases.
+//
+//   - On macOS, it is the sandbox-exec profile.
+//   - On Windows, it is a summary of the restricted token and the AppContainer.
+//   - On other platforms, it is the bwrap command line with one option per line. The script is shown as <script>.
+func PreviewPolicy(allowNetwork bool, opts *Options) (string, error) {
+	o := Options{}
+	if opts != nil {
+		o = *opts
+	}
+	if err := o.validate(); err != nil {
+		return "", err
+	}
+	return previewPolicy(allowNetwork, &o)
+}
+
+// validate checks the platform independent options.
+func (o *Options) validate() error {
+	if o.PageSize < 0 {
+		return fmt.Errorf("PageSize: invalid value %d", o.PageSize)
+	}
+	if o.MaxPagedRuns < 0 {
+		return fmt.Errorf("MaxPagedRuns: invalid value %d", o.MaxPagedRuns)
+	}
+	for _, p := range o.WritablePaths {
+		if !filepath.IsAbs(p) {
+			return fmt.Errorf("WritablePaths: %q is not an absolute path", p)
+		}
+		if _, err := os.Stat(p); err != nil {
+			return fmt.Errorf("WritablePaths: %w", err)
+		}
+	}
+	for i := range o.Interpreters {
+		if err := o.Interpreters[i].validate(); err != nil {
+			return fmt.Errorf("Interpreters: %w", err)
+		}
+		for j := range i {
+			if o.Interpreters[j].Name == o.Interpreters[i].Name {
+				return fmt.Errorf("Interpreters: duplicate name %q", o.Interpreters[i].Name)
+			}
+		}
+	}
+	return nil
 }
 
 // arguments is the shell tool argument.
+//
+// Every field must have a jsonschema description so the LLM uses it correctly.
 type arguments struct {
-	Script string `json:"script"`
+	Script string `json:"script" jsonschema:"description=Content of the script to run"`
+	Stdin  string `json:"stdin,omitempty" jsonschema:"description=Data piped to the standard input of the script"`
+	Dir    string `json:"dir,omitempty" jsonschema:"description=Existing directory to run the script in"`
+	// Interpreter is the Name of one of Options.Interpreters.
+	Interpreter string `json:"interpreter,omitempty" jsonschema:"description=Interpreter to run the script with. Defaults to the shell"`
+}
+
+func (i *Interpreter) validate() error {
+	if i.Name == "" {
+		return errors.New("empty name")
+	}
+	if !filepath.IsAbs(i.Path) {
+		return fmt.Errorf("%s: %q is not an absolute path", i.Name, i.Path)
+	}
+	if fi, err := os.Stat(i.Path); err != nil {
+		return fmt.Errorf("%s: %w", i.Name, err)
+	} else if fi.IsDir() {
+		return fmt.Errorf("%s: %q is a directory", i.Name, i.Path)
+	}
+	if i.Ext != "" && !strings.HasPrefix(i.Ext, ".") {
+		return fmt.Errorf("%s: extension %q must start with a dot", i.Name, i.Ext)
+	}
+	return nil
+}
+
+// result is the tool result when Options.StructuredOutput is true.
+type result struct {
+	RunID    string `json:"run_id"`
+	ExitCode int    `json:"exit_code"`
+	Output   string `json:"output"`
+	// Page and Pages are set when the output is split in pages.
+	Page  int `json:"page,omitempty"`
+	Pages int `json:"pages,omitempty"`
+}
+
+// sandbox is the OS specific implementation.
+type sandbox struct {
+	name        string
+	description string
+	// ext is the script file extension.
+	ext string
+	// env is added to the environment of the script.
+	env []string
+	// allowNetwork is the value passed to New or NewWithOptions.
+	allowNetwork bool
+	// pages keeps the output of the runs split in pages when Options.PageSize
+	// is set.
+	pages *outputCache
+	// exec runs the script in the sandbox and returns the combined output.
+	exec func(ctx context.Context, r *execRequest) (string, error)
+}
+
+// execRequest is a script to run in the sandbox.
+type execRequest struct {
+	// path is the script file. When empty, content is piped to the
+	// interpreter's stdin.
+	path    string
+	content string
+	// stdin is piped to the script's stdin when path is set.
+	stdin string
+	// dir is the absolute path of the working directory. When empty, it is
+	// left to the sandbox.
+	dir string
+	// interpreter is the path to the executable to run the script file with.
+	// When empty, the sandbox's shell is used.
+	interpreter string
+	// env is the environment of the process.
+	env []string
+	// maxOutput is the maximum number of bytes of output to keep. Zero or less
+	// means no limit.
+	maxOutput int
+}
+
+// runCmd runs cmd and returns its combined output, capped to r.maxOutput
+// bytes.
+//
+// When the output is truncated, the pipe is closed so the script gets EPIPE
+// on its next write; the resulting exit error is ignored.
+func (r *execRequest) runCmd(cmd *exec.Cmd) (string, error) {
+	buf := &cappedBuffer{limit: r.maxOutput}
+	cmd.Stdout = buf
+	cmd.Stderr = buf
+	err := cmd.Run()
+	if buf.truncated {
+		err = nil
+	}
+	var ee *exec.ExitError
+	if errors.As(err, &ee) && ee.ExitCode() > 0 {
+		// Keep the error when the process was killed by a signal.
+		err = &exitError{code: ee.ExitCode()}
+	}
+	return buf.String(), err
+}
+
+// errOutputTruncated stops the copy of the output once the limit is reached.
+var errOutputTruncated = errors.New("output truncated")
+
+// cappedBuffer is an io.Writer that keeps up to limit bytes.
+type cappedBuffer struct {
+	buf       bytes.Buffer
+	limit     int
+	truncated bool
+}
+
+func (c *cappedBuffer) Write(p []byte) (int, error) {
+	if c.limit <= 0 {
+		return c.buf.Write(p)
+	}
+	if c.truncated {
+		return 0, errOutputTruncated
+	}
+	if room := c.limit - c.buf.Len(); len(p) > room {
+		c.buf.Write(p[:room])
+		c.truncated = true
+		return room, errOutputTruncated
+	}
+	return c.buf.Write(p)
+}
+
+// String returns the output, with a marker when it was truncated.
+func (c *cappedBuffer) String() string {
+	if !c.truncated {
+		return c.buf.String()
+	}
+	s := c.buf.String()
+	if s != "" && !strings.HasSuffix(s, "\n") {
+		s += "\n"
+	}
+	return s + "... [output truncated]"
+}
+
+// run runs the script requested by the LLM.
+func (o *Options) run(ctx context.Context, s *sandbox, args *arguments) (string, error) {
+	content, rejected := o.prepareScript(args.Script)
+	if rejected != "" {
+		return rejected, nil
+	}
+	var interp *Interpreter
+	if args.Interpreter != "" && args.Interpreter != s.name {
+		for i := range o.Interpreters {
+			if o.Interpreters[i].Name == args.Interpreter {
+				interp = &o.Interpreters[i]
+				break
+			}
+		}
+		if interp == nil {
+			return fmt.Sprintf("unknown interpreter %q", args.Interpreter), nil
+		}
+	}
+	dir := ""
+	if args.Dir != "" {
+		var err error
+		if dir, err = checkDir(args.Dir); err != nil {
+			return "invalid dir: " + err.Error(), nil
+		}
+	}
+	if o.Policy != nil {
+		req := ScriptRequest{Script: content, Stdin: args.Stdin, Dir: dir, AllowNetwork: s.allowNetwork, WritablePaths: o.WritablePaths}
+		if interp != nil {
+			req.Interpreter = interp.Name
+		}
+		d, err := o.Policy(ctx, req)
+		if err != nil {
+			return "", fmt.Errorf("policy: %w", err)
+		}
+		if !d.Allow {
+			reason := d.Reason
+			if reason == "" {
+				reason = "no reason given"
+			}
+			return "script denied by policy: " + reason, nil
+		}
+	}
+	if o.Timeout > 0 {
+		var cancel context.CancelFunc
+		ctx, cancel = context.WithTimeout(ctx, o.Timeout)
+		defer cancel()
+	}
+	runID := newRunID()
+	script := ""
+	ext, interpreter := s.ext, ""
+	if interp != nil {
+		ext, interpreter = interp.Ext, interp.Path
+	}
+	if !o.ScriptViaStdin || args.Stdin != "" || interp != nil {
+		var err error
+		if script, err = writeTempFile("ask.*"+ext, content); err != nil {
+			return "", err
+		}
+		defer func() {
+			_ = os.Remove(script)
+		}()
+	}
+	deny := o.EnvDenylist
+	if deny == nil {
+		deny = DefaultEnvDenylist
+	}
+	env := buildEnv(os.Environ(), o.EnvAllowlist, deny, s.env, runtime.GOOS == "windows")
+	out, err := s.exec(ctx, &execRequest{path: script, content: content, stdin: args.Stdin, dir: dir, interpreter: interpreter, env: env, maxOutput: o.maxOutput()})
+	if o.Timeout > 0 && errors.Is(ctx.Err(), context.DeadlineExceeded) {
+		err = fmt.Errorf("script timed out after %s: %w", o.Timeout, context.DeadlineExceeded)
+	}
+	slog.DebugContext(ctx, s.name, "run_id", runID, "path", script, "command", args.Script, "output", out, "err", err)
+	exitCode := 0
+	var ee *exitError
+	if errors.As(err, &ee) {
+		// The script ran; its failure is reported as data, not as an error.
+		exitCode, err = ee.code, nil
+	}
+	if s.pages != nil && len(out) > o.PageSize {
+		p := &pagedOutput{exitCode: exitCode, pages: splitPages(out, o.PageSize)}
+		s.pages.add(runID, p)
+		res, err2 := o.formatPage(runID, p, 1)
+		if err2 != nil {
+			return "", err2
+		}
+		return res, err
+	}
+	if o.StructuredOutput {
+		b, err2 := json.Marshal(&result{RunID: runID, ExitCode: exitCode, Output: out})
+		if err2 != nil {
+			return "", err2
+		}
+		return string(b), err
+	}
+	if exitCode != 0 {
+		out = fmt.Sprintf("EXIT_CODE=%d\n", exitCode) + out
+	}
+	return out, err
+}
+
+// exitError is returned by sandbox.exec when the script ran and exited with
+// a nonzero code.
+type exitError struct {
+	code int
+}
+
+func (e *exitError) Error() string {
+	if e.code > 255 || e.code < 0 {
+		return fmt.Sprintf("exit code 0x%08x", uint32(e.code))
+	}
+	return fmt.Sprintf("exit code %d", e.code)
+}
+
+// buildEnv returns the environment of the script: the variables of environ
+// matching allowlist and not matching denylist, LANG=C and extra. Duplicates
+// are removed, keeping the last value.
+func buildEnv(environ, allowlist, denylist, extra []string, caseInsensitive bool) []string {
+	norm := func(k string) string {
+		if caseInsensitive {
+			return strings.ToUpper(k)
+		}
+		return k
+	}
+	var all []string
+	for _, kv := range environ {
+		k := envName(kv)
+		if len(allowlist) != 0 && !envMatch(allowlist, norm(k), norm) {
+			continue
+		}
+		if envMatch(denylist, strings.ToUpper(k), strings.ToUpper) {
+			continue
+		}
+		all = append(all, kv)
+	}
+	// Increases odds of success on non-English installation.
+	all = append(append(all, "LANG=C"), extra...)
+	index := map[string]int{}
+	var out []string
+	for _, kv := range all {
+		k := norm(envName(kv))
+		if i, ok := index[k]; ok {
+			out[i] = kv
+			continue
+		}
+		index[k] = len(out)
+		out = append(out, kv)
+	}
+	return out
+}
+
+// envMatch returns true if name matches one of the patterns after applying
+// norm to them.
+func envMatch(patterns []string, name string, norm func(string) string) bool {
+	for _, p := range patterns {
+		if ok, _ := path.Match(norm(p), name); ok {
+			return true
+		}
+	}
+	return false
+}
+
+// envName returns the name of the "name=value" pair kv. On Windows, the names
+// of hidden variables like "=C:" start with '='.
+func envName(kv string) string {
+	if kv == "" {
+		return ""
+	}
+	if i := strings.IndexByte(kv[1:], '='); i >= 0 {
+		return kv[:i+1]
+	}
+	return kv
+}
+
+// checkDir returns the absolute path of dir after verifying it is an existing
+// directory.
+func checkDir(dir string) (string, error) {
+	p, err := filepath.Abs(dir)
+	if err != nil {
+		return "", err
+	}
+	fi, err := os.Stat(p)
+	if err != nil {
+		return "", err
+	}
+	if !fi.IsDir() {
+		return "", fmt.Errorf("%q is not a directory", dir)
+	}
+	return p, nil
+}
+
+// newRunID returns a random identifier for an execution.
+func newRunID() string {
+	var b [8]byte
+	_, _ = rand.Read(b[:])
+	return hex.EncodeToString(b[:])
+}
+
+// prepareScript returns the script to run.
+//
+// When the script is rejected, it returns a non-empty message to return to
+// the LLM.
+func (o *Options) prepareScript(script string) (string, string) {
+	if err := o.checkScriptSize(script); err != "" {
+		return "", err
+	}
+	if o.Transform != nil {
+		s, err := o.Transform(script)
+		if err != nil {
+			return "", "script rejected: " + err.Error()
+		}
+		script = s
+		if err := o.checkScriptSize(script); err != "" {
+			return "", err
+		}
+	}
+	return script, ""
+}
+
+// maxOutput returns the effective output limit, or -1 when unlimited.
+func (o *Options) maxOutput() int {
+	if o.MaxOutputSize == 0 {
+		return DefaultMaxOutputSize
+	}
+	return max(o.MaxOutputSize, -1)
+}
+
+func (o *Options) checkScriptSize(script string) string {
+	limit := o.MaxScriptSize
+	if limit == 0 {
+		limit = DefaultMaxScriptSize
+	}
+	if limit > 0 && len(script) > limit {
+		return fmt.Sprintf("script rejected: script is %d bytes, larger than the maximum of %d bytes", len(script), limit)
+	}
+	return ""
 }
 
 func writeTempFile(g, content string) (string, error) {
@@ -41,3 +747,25 @@ func writeTempFile(g, content string) (string, error) {
 	err = f.Close()
 	return n, err
 }
+
+// sandboxSetupError returns an error wrapping ErrSandboxSetup when out looks
+// like the error message printed by the sandbox tool named tool instead of the
+// output of the script. It returns nil otherwise.
+//
+// sandbox-exec prints a single line prefixed with its name and exits before
+// running the script when it cannot set up the sandbox. This is a heuristic: a
+// failing script could print the same.
+func sandboxSetupError(tool, out string, err error) error {
+	if err == nil {
+		return nil
+	}
+	msg := strings.TrimSpace(out)
+	if !strings.HasPrefix(msg, tool+": ") || strings.Contains(msg, "\n") {
+		return nil
+	}
+	return fmt.Errorf("%w: %s", ErrSandboxSetup, msg)
+}
+
+// waitDelay bounds how long to wait for the output pipes to close after the
+// process was killed, in case a grandchild process still holds them open.
+const waitDelay = 5 * time.Second
@@ -7,11 +7,10 @@ package shelltool
 import (
 	"context"
 	"fmt"
-	"log/slog"
 	"os"
 	"os/exec"
-
-	"github.com/maruel/genai"
+	"path/filepath"
+	"strings"
 )
 
 const sbAllowNetwork = `(version 1)
@@ -74,45 +73,76 @@ const sbNoNetwork = `(version 1)
 (allow file-write* (subpath "/tmp"))
 `
 
-func getShellTool(allowNetwork bool) (*genai.GenOptionTools, error) {
+func newSandbox(allowNetwork bool, opts *Options) (*sandbox, error) {
 	if _, err := exec.LookPath("/usr/bin/sandbox-exec"); err != nil {
 		return nil, fmt.Errorf("sandbox-exec not found: %w", err)
 	}
 	if _, err := exec.LookPath("/bin/zsh"); err != nil {
 		return nil, fmt.Errorf("zsh not found: %w", err)
 	}
-	return &genai.GenOptionTools{
-		Tools: []genai.ToolDef{
-			{
-				Name:        "zsh",
-				Description: "Writes the script to a file, executes it via zsh on the macOS computer, and returns the output",
-				Callback: func(ctx context.Context, args *arguments) (string, error) {
-					sandbox := sbNoNetwork
-					if allowNetwork {
-						sandbox = sbAllowNetwork
-					}
-					askSB, err := writeTempFile("ask.*.sb", sandbox)
-					if err != nil {
-						return "", err
-					}
-					defer func() {
-						_ = os.Remove(askSB)
-					}()
-					script, err := writeTempFile("ask.*.sh", args.Script)
-					if err != nil {
-						return "", err
-					}
-					defer func() {
-						_ = os.Remove(script)
-					}()
-					cmd := exec.CommandContext(ctx, "/usr/bin/sandbox-exec", "-f", askSB, "/bin/zsh", script)
-					// Increases odds of success on non-English installation.
-					cmd.Env = append(os.Environ(), "LANG=C")
-					out, err2 := cmd.CombinedOutput()
-					slog.DebugContext(ctx, "bash", "command", args.Script, "output", string(out), "err", err2)
-					return string(out), err2
-				},
-			},
+	sb, err := sbProfile(allowNetwork, opts.WritablePaths)
+	if err != nil {
+		return nil, err
+	}
+	return &sandbox{
+		name:        "zsh",
+		description: "Writes the script to a file, executes it via zsh on the macOS computer, and returns the output",
+		ext:         ".sh",
+		exec: func(ctx context.Context, r *execRequest) (string, error) {
+			askSB, err := writeTempFile("ask.*.sb", sb)
+			if err != nil {
+				return "", err
+			}
+			defer func() {
+				_ = os.Remove(askSB)
+			}()
+			arg := r.path
+			if r.path == "" {
+				arg = "-s"
+			}
+			interpreter := r.interpreter
+			if interpreter == "" {
+				interpreter = "/bin/zsh"
+			}
+			cmd := exec.CommandContext(ctx, "/usr/bin/sandbox-exec", "-f", askSB, interpreter, arg)
+			if r.path == "" {
+				cmd.Stdin = strings.NewReader(r.content)
+			} else if r.stdin != "" {
+				cmd.Stdin = strings.NewReader(r.stdin)
+			}
+			// The profile allows reading any file, including dir.
+			cmd.Dir = r.dir
+			cmd.Env = r.env
+			cmd.WaitDelay = waitDelay
+			out, err := r.runCmd(cmd)
+			if err2 := sandboxSetupError("sandbox-exec", out, err); err2 != nil {
+				return err2.Error(), err2
+			}
+			return out, err
 		},
 	}, nil
 }
+
+// previewPolicy returns the sandbox-exec profile that NewWithOptions would use.
+func previewPolicy(allowNetwork bool, opts *Options) (string, error) {
+	return sbProfile(allowNetwork, opts.WritablePaths)
+}
+
+// sbProfile returns the sandbox profile, allowing writes to writablePaths in
+// addition to /tmp.
+func sbProfile(allowNetwork bool, writablePaths []string) (string, error) {
+	sb := sbNoNetwork
+	if allowNetwork {
+		sb = sbAllowNetwork
+	}
+	for _, p := range writablePaths {
+		// The sandbox matches the resolved path, e.g. /private/var instead of
+		// /var.
+		r, err := filepath.EvalSymlinks(p)
+		if err != nil {
+			return "", fmt.Errorf("WritablePaths: %w", err)
+		}
+		sb += fmt.Sprintf("(allow file-write* (subpath %q))\n", r)
+	}
+	return sb, nil
+}
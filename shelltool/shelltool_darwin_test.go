@@ -0,0 +1,45 @@
+// Copyright 2025 Marc-Antoine Ruel. All rights reserved.
+// Use of this source code is governed under the Apache License, Version 2.0
+// that can be found in the LICENSE file.
+
+package shelltool
+
+import (
+	"path/filepath"
+	"strings"
+	"testing"
+)
+
+func TestSBProfile(t *testing.T) {
+	dir, err := filepath.EvalSymlinks(t.TempDir())
+	if err != nil {
+		t.Fatal(err)
+	}
+	sb, err := sbProfile(false, []string{dir})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if want := "(allow file-write* (subpath \"" + dir + "\"))\n"; !strings.HasSuffix(sb, want) {
+		t.Fatalf("Expected %q at the end of the profile:\n%s", want, sb)
+	}
+	if !strings.Contains(sb, "(deny network*)") {
+		t.Fatal("Expected the network to be denied")
+	}
+}
+
+func TestPreviewPolicy(t *testing.T) {
+	withNet, err := PreviewPolicy(true, nil)
+	if err != nil {
+		t.Fatal(err)
+	}
+	noNet, err := PreviewPolicy(false, nil)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if !strings.Contains(withNet, "\n(allow network*)\n") || strings.Contains(withNet, "(deny network*)") {
+		t.Fatalf("Expected the network to be allowed:\n%s", withNet)
+	}
+	if !strings.Contains(noNet, "\n(deny network*)\n") || strings.Contains(noNet, "(allow network") {
+		t.Fatalf("Expected the network to be denied:\n%s", noNet)
+	}
+}
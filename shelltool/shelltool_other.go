@@ -8,15 +8,21 @@ package shelltool
 
 import (
 	"context"
+	"encoding/json"
 	"fmt"
-	"log/slog"
+	"io"
 	"os"
 	"os/exec"
-
-	"github.com/maruel/genai"
+	"path/filepath"
+	"strconv"
+	"strings"
+	"time"
 )
 
-func getShellTool(allowNetwork bool) (*genai.GenOptionTools, error) {
+func newSandbox(allowNetwork bool, opts *Options) (*sandbox, error) {
+	if err := validateLinux(opts); err != nil {
+		return nil, err
+	}
 	bwrapPath, err := exec.LookPath("bwrap")
 	if err != nil {
 		return nil, fmt.Errorf("bwrap not found (install with sudo apt install bubblewrap): %w", err)
@@ -24,38 +30,196 @@ func getShellTool(allowNetwork bool) (*genai.GenOptionTools, error) {
 	if _, err := exec.LookPath("/bin/bash"); err != nil {
 		return nil, fmt.Errorf("bash not found: %w", err)
 	}
-	return &genai.GenOptionTools{
-		Tools: []genai.ToolDef{
-			{
-				Name:        "bash",
-				Description: "Writes the script to a file, executes it via bash on the macOS computer, and returns the output",
-				Callback: func(ctx context.Context, args *arguments) (string, error) {
-					script, err := writeTempFile("ask.*.sh", args.Script)
-					if err != nil {
-						return "", err
-					}
-					defer func() {
-						_ = os.Remove(script)
-					}()
-					v := []string{
-						"--ro-bind", "/", "/",
-						"--tmpfs", "/tmp",
-						"--dev", "/dev",
-						"--proc", "/proc",
-						"--bind", script, script,
-					}
-					if !allowNetwork {
-						v = append(v, "--unshare-net")
-					}
-					v = append(v, "--", "/bin/bash", script)
-					cmd := exec.CommandContext(ctx, bwrapPath, v...)
-					// Increases odds of success on non-English installation.
-					cmd.Env = append(os.Environ(), "LANG=C")
-					out, err2 := cmd.CombinedOutput()
-					slog.DebugContext(ctx, "bash", "command", args.Script, "output", string(out), "err", err2)
-					return string(out), err2
-				},
-			},
+	if opts.LinuxUID != 0 || opts.LinuxGID != 0 {
+		if err := checkUserNamespace(bwrapPath, opts); err != nil {
+			return nil, err
+		}
+	}
+	desc := "Writes the script to a file, executes it via bash on the macOS computer, and returns the output"
+	if opts.LinuxSecretFile != "" {
+		desc += ". A secret is readable at " + LinuxSecretPath
+	}
+	return &sandbox{
+		name:        "bash",
+		description: desc,
+		ext:         ".sh",
+		exec: func(ctx context.Context, r *execRequest) (string, error) {
+			// bwrap writes the PID of the sandboxed process to --info-fd once it
+			// started it, which tells a setup failure apart from the script
+			// failing.
+			info, infoW, err := os.Pipe()
+			if err != nil {
+				return "", err
+			}
+			defer info.Close()
+			cmd := exec.CommandContext(ctx, bwrapPath, append([]string{"--info-fd", "3"}, bwrapArgs(r, allowNetwork, opts)...)...)
+			cmd.ExtraFiles = []*os.File{infoW}
+			if r.path == "" {
+				cmd.Stdin = strings.NewReader(r.content)
+			} else if r.stdin != "" {
+				cmd.Stdin = strings.NewReader(r.stdin)
+			}
+			cmd.Env = r.env
+			cmd.WaitDelay = waitDelay
+			out, err := r.runCmd(cmd)
+			_ = infoW.Close()
+			if err != nil && !bwrapStarted(info) {
+				msg := strings.TrimSpace(out)
+				if msg == "" {
+					msg = err.Error()
+				}
+				err = fmt.Errorf("%w: %s", ErrSandboxSetup, msg)
+				return err.Error(), err
+			}
+			return out, err
 		},
 	}, nil
 }
+
+// bwrapStarted returns true if bwrap reported the PID of the sandboxed process
+// on info.
+func bwrapStarted(info *os.File) bool {
+	// The write end may still be held open by a process that escaped, so do not
+	// wait for EOF forever.
+	_ = info.SetReadDeadline(time.Now().Add(waitDelay))
+	b, _ := io.ReadAll(io.LimitReader(info, 4096))
+	var v struct {
+		ChildPID int `json:"child-pid"`
+	}
+	return json.Unmarshal(b, &v) == nil && v.ChildPID != 0
+}
+
+func previewPolicy(allowNetwork bool, opts *Options) (string, error) {
+	if err := validateLinux(opts); err != nil {
+		return "", err
+	}
+	bwrapPath, err := exec.LookPath("bwrap")
+	if err != nil {
+		bwrapPath = "bwrap"
+	}
+	var b strings.Builder
+	b.WriteString(bwrapPath)
+	for _, a := range bwrapArgs(&execRequest{path: "<script>"}, allowNetwork, opts) {
+		if strings.HasPrefix(a, "--") {
+			b.WriteString("\n")
+		} else {
+			b.WriteString(" ")
+		}
+		if strings.ContainsAny(a, " \t\n\"'\\$") {
+			a = strconv.Quote(a)
+		}
+		b.WriteString(a)
+	}
+	b.WriteString("\n")
+	return b.String(), nil
+}
+
+// validateLinux checks the Linux specific options. It makes
+// opts.LinuxSecretFile absolute.
+func validateLinux(opts *Options) error {
+	if opts.LinuxUID < 0 || opts.LinuxGID < 0 {
+		return fmt.Errorf("invalid LinuxUID %d or LinuxGID %d", opts.LinuxUID, opts.LinuxGID)
+	}
+	if err := opts.LinuxProc.validate(); err != nil {
+		return fmt.Errorf("LinuxProc: %w", err)
+	}
+	if err := opts.LinuxSys.validate(); err != nil {
+		return fmt.Errorf("LinuxSys: %w", err)
+	}
+	if opts.LinuxSecretFile != "" {
+		p, err := filepath.Abs(opts.LinuxSecretFile)
+		if err != nil {
+			return fmt.Errorf("LinuxSecretFile: %w", err)
+		}
+		if fi, err := os.Stat(p); err != nil {
+			return fmt.Errorf("LinuxSecretFile: %w", err)
+		} else if !fi.Mode().IsRegular() {
+			return fmt.Errorf("LinuxSecretFile: %q is not a regular file", opts.LinuxSecretFile)
+		}
+		// opts is a private copy.
+		opts.LinuxSecretFile = p
+	}
+	return nil
+}
+
+// bwrapArgs returns the bubblewrap arguments to run the script of r. When
+// r.path is empty, bash reads the script from stdin. When r.dir is empty, the
+// working directory is inherited.
+func bwrapArgs(r *execRequest, allowNetwork bool, opts *Options) []string {
+	script, dir := r.path, r.dir
+	v := []string{
+		// Kill the sandboxed processes when bwrap is killed, e.g. on timeout.
+		"--die-with-parent",
+		"--ro-bind", "/", "/",
+		"--tmpfs", "/tmp",
+		"--dev", "/dev",
+	}
+	switch opts.LinuxProc {
+	case MountDefault, MountNew:
+		v = append(v, "--proc", "/proc")
+	case MountHide:
+		v = append(v, "--tmpfs", "/proc")
+	case MountReadOnly:
+		v = append(v, "--ro-bind", "/proc", "/proc")
+	}
+	switch opts.LinuxSys {
+	case MountDefault:
+	case MountHide:
+		v = append(v, "--tmpfs", "/sys")
+	case MountNew, MountReadOnly:
+		v = append(v, "--ro-bind", "/sys", "/sys")
+	}
+	if script != "" {
+		v = append(v, "--bind", script, script)
+	}
+	if opts.LinuxSecretFile != "" {
+		v = append(v, "--ro-bind", opts.LinuxSecretFile, LinuxSecretPath)
+	}
+	if dir != "" {
+		// Bind it explicitly in case it is under a path replaced above, like
+		// /tmp.
+		v = append(v, "--ro-bind", dir, dir, "--chdir", dir)
+	}
+	// Bind the writable paths last so they are writable even when they contain
+	// dir.
+	for _, p := range opts.WritablePaths {
+		v = append(v, "--bind", p, p)
+	}
+	if !allowNetwork {
+		v = append(v, "--unshare-net")
+	}
+	interpreter := r.interpreter
+	if interpreter == "" {
+		interpreter = "/bin/bash"
+	}
+	v = append(append(v, userArgs(opts)...), "--", interpreter)
+	if script == "" {
+		return append(v, "-s")
+	}
+	return append(v, script)
+}
+
+// userArgs returns the bubblewrap arguments to switch user and group IDs.
+func userArgs(opts *Options) []string {
+	if opts.LinuxUID == 0 && opts.LinuxGID == 0 {
+		return nil
+	}
+	v := []string{"--unshare-user"}
+	if opts.LinuxUID != 0 {
+		v = append(v, "--uid", strconv.Itoa(opts.LinuxUID))
+	}
+	if opts.LinuxGID != 0 {
+		v = append(v, "--gid", strconv.Itoa(opts.LinuxGID))
+	}
+	return v
+}
+
+// checkUserNamespace verifies that bwrap can switch to the requested IDs.
+func checkUserNamespace(bwrapPath string, opts *Options) error {
+	v := append([]string{"--ro-bind", "/", "/"}, userArgs(opts)...)
+	out, err := exec.Command(bwrapPath, append(v, "--", "/bin/true")...).CombinedOutput()
+	if err != nil {
+		return fmt.Errorf("bwrap cannot run as uid %d gid %d; user namespaces may be disabled or bwrap may lack privileges: %w: %s", opts.LinuxUID, opts.LinuxGID, err, strings.TrimSpace(string(out)))
+	}
+	return nil
+}
@@ -0,0 +1,161 @@
+// Copyright 2025 Marc-Antoine Ruel. All rights reserved.
+// Use of this source code is governed under the Apache License, Version 2.0
+// that can be found in the LICENSE file.
+
+//go:build !windows && !darwin
+
+package shelltool
+
+import (
+	"encoding/json"
+	"errors"
+	"os"
+	"path/filepath"
+	"slices"
+	"strings"
+	"testing"
+
+	"github.com/maruel/genai"
+)
+
+func TestBwrapArgs(t *testing.T) {
+	tests := []struct {
+		name     string
+		opts     Options
+		network  bool
+		expected string
+	}{
+		{"default", Options{}, true, "--proc /proc"},
+		{"no_network", Options{}, false, "--bind s.sh s.sh --unshare-net -- /bin/bash s.sh"},
+		{"proc_hide", Options{LinuxProc: MountHide}, true, "--tmpfs /proc"},
+		{"proc_ro", Options{LinuxProc: MountReadOnly}, true, "--ro-bind /proc /proc"},
+		{"sys_hide", Options{LinuxSys: MountHide}, true, "--proc /proc --tmpfs /sys"},
+		{"sys_ro", Options{LinuxSys: MountReadOnly}, true, "--proc /proc --ro-bind /sys /sys"},
+		{"uid", Options{LinuxUID: 1000}, true, "--unshare-user --uid 1000 -- /bin/bash s.sh"},
+		{"uid_gid", Options{LinuxUID: 1000, LinuxGID: 100}, true, "--unshare-user --uid 1000 --gid 100 --"},
+		{"secret", Options{LinuxSecretFile: "/home/u/token"}, true, "--bind s.sh s.sh --ro-bind /home/u/token /tmp/secret --"},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got := strings.Join(bwrapArgs(&execRequest{path: "s.sh"}, tt.network, &tt.opts), " ")
+			if !strings.Contains(got, tt.expected) {
+				t.Fatalf("Expected %q in %q", tt.expected, got)
+			}
+		})
+	}
+	if got := bwrapArgs(&execRequest{path: "s.sh"}, true, &Options{}); slices.Contains(got, "/sys") || slices.Contains(got, "--unshare-user") {
+		t.Fatalf("Expected /sys to not be mounted and the user to not change by default: %q", got)
+	}
+	want := "--bind s.sh s.sh --ro-bind /tmp/work /tmp/work --chdir /tmp/work -- /bin/bash s.sh"
+	if got := strings.Join(bwrapArgs(&execRequest{path: "s.sh", dir: "/tmp/work"}, true, &Options{}), " "); !strings.Contains(got, want) {
+		t.Fatalf("Expected %q in %q", want, got)
+	}
+	want = "--ro-bind /tmp/work /tmp/work --chdir /tmp/work --bind /tmp/work /tmp/work --bind /out /out --"
+	if got := strings.Join(bwrapArgs(&execRequest{path: "s.sh", dir: "/tmp/work"}, true, &Options{WritablePaths: []string{"/tmp/work", "/out"}}), " "); !strings.Contains(got, want) {
+		t.Fatalf("Expected %q in %q", want, got)
+	}
+	want = "--unshare-net -- /usr/bin/python3 s.py"
+	if got := strings.Join(bwrapArgs(&execRequest{path: "s.py", interpreter: "/usr/bin/python3"}, false, &Options{}), " "); !strings.HasSuffix(got, want) {
+		t.Fatalf("Expected %q at the end of %q", want, got)
+	}
+	want = "--die-with-parent --ro-bind / / --tmpfs /tmp --dev /dev --proc /proc --unshare-net -- /bin/bash -s"
+	if got := strings.Join(bwrapArgs(&execRequest{}, false, &Options{}), " "); got != want {
+		t.Fatalf("Expected %q but got %q", want, got)
+	}
+}
+
+func TestMountValidate(t *testing.T) {
+	if _, err := NewWithOptions(true, &Options{LinuxSys: Mount(42)}); err == nil || !strings.Contains(err.Error(), "LinuxSys: invalid Mount value 42") {
+		t.Fatalf("Expected invalid Mount error but got %v", err)
+	}
+}
+
+func TestUIDValidate(t *testing.T) {
+	if _, err := NewWithOptions(true, &Options{LinuxUID: -1}); err == nil || !strings.Contains(err.Error(), "invalid LinuxUID -1") {
+		t.Fatalf("Expected invalid LinuxUID error but got %v", err)
+	}
+}
+
+func TestBwrapSetupError(t *testing.T) {
+	// Simulate bwrap refusing to create the sandbox.
+	dir := t.TempDir()
+	fake := "#!/bin/sh\necho 'bwrap: Creating new namespace failed: Operation not permitted' >&2\nexit 1\n"
+	if err := os.WriteFile(filepath.Join(dir, "bwrap"), []byte(fake), 0o755); err != nil {
+		t.Fatal(err)
+	}
+	t.Setenv("PATH", dir)
+	opts, err := New(true)
+	if err != nil {
+		t.Fatal(err)
+	}
+	b, _ := json.Marshal(&arguments{Script: "echo hi\n"})
+	msg := genai.Message{Replies: []genai.Reply{{ToolCall: genai.ToolCall{Name: opts.Tools[0].Name, Arguments: string(b)}}}}
+	_, err = msg.DoToolCalls(t.Context(), opts.Tools)
+	if !errors.Is(err, ErrSandboxSetup) {
+		t.Fatalf("Expected ErrSandboxSetup but got %v", err)
+	}
+	if !strings.Contains(err.Error(), "Creating new namespace failed") {
+		t.Fatalf("Expected the bwrap message in %q", err.Error())
+	}
+}
+
+func TestBwrapScriptFailure(t *testing.T) {
+	// The script ran, as reported on --info-fd, and printed a line that looks
+	// like a bwrap error.
+	dir := t.TempDir()
+	fake := "#!/bin/sh\necho '{\"child-pid\": 42}' >&3\necho 'bwrap: not really' >&2\nexit 1\n"
+	if err := os.WriteFile(filepath.Join(dir, "bwrap"), []byte(fake), 0o755); err != nil {
+		t.Fatal(err)
+	}
+	t.Setenv("PATH", dir)
+	opts, err := New(true)
+	if err != nil {
+		t.Fatal(err)
+	}
+	b, _ := json.Marshal(&arguments{Script: "echo hi\n"})
+	msg := genai.Message{Replies: []genai.Reply{{ToolCall: genai.ToolCall{Name: opts.Tools[0].Name, Arguments: string(b)}}}}
+	res, err := msg.DoToolCalls(t.Context(), opts.Tools)
+	if errors.Is(err, ErrSandboxSetup) {
+		t.Fatalf("Unexpected ErrSandboxSetup: %v", err)
+	}
+	if got := res.ToolCallResults[0].Result; !strings.Contains(got, "bwrap: not really") {
+		t.Fatalf("Expected the script output but got %q", got)
+	}
+}
+
+func TestSecretFileValidate(t *testing.T) {
+	dir := t.TempDir()
+	if _, err := NewWithOptions(true, &Options{LinuxSecretFile: dir}); err == nil || !strings.Contains(err.Error(), "is not a regular file") {
+		t.Fatalf("Expected not a regular file error but got %v", err)
+	}
+	if _, err := NewWithOptions(true, &Options{LinuxSecretFile: filepath.Join(dir, "missing")}); err == nil || !errors.Is(err, os.ErrNotExist) {
+		t.Fatalf("Expected not exist error but got %v", err)
+	}
+}
+
+func TestPreviewPolicy(t *testing.T) {
+	dir := t.TempDir()
+	opts := Options{WritablePaths: []string{dir}, LinuxProc: MountHide}
+	withNet, err := PreviewPolicy(true, &opts)
+	if err != nil {
+		t.Fatal(err)
+	}
+	noNet, err := PreviewPolicy(false, &opts)
+	if err != nil {
+		t.Fatal(err)
+	}
+	for _, want := range []string{"\n--die-with-parent\n", "\n--tmpfs /proc\n", "\n--bind <script> <script>\n", "\n--bind " + dir + " " + dir + "\n", "\n-- /bin/bash <script>\n"} {
+		if !strings.Contains(withNet, want) || !strings.Contains(noNet, want) {
+			t.Fatalf("Expected %q in both policies:\n%s\n%s", want, withNet, noNet)
+		}
+	}
+	if strings.Contains(withNet, "\n--unshare-net\n") {
+		t.Fatalf("Expected the network to be allowed:\n%s", withNet)
+	}
+	if !strings.Contains(noNet, "\n--unshare-net\n") {
+		t.Fatalf("Expected the network to be denied:\n%s", noNet)
+	}
+	if _, err := PreviewPolicy(false, &Options{LinuxUID: -1}); err == nil {
+		t.Fatal("Expected an error")
+	}
+}
@@ -5,23 +5,25 @@
 package shelltool
 
 import (
+	"context"
 	"encoding/json"
+	"errors"
 	"os"
+	"os/exec"
+	"path/filepath"
+	"reflect"
 	"regexp"
 	"runtime"
 	"slices"
 	"sort"
 	"strings"
 	"testing"
+	"time"
 
 	"github.com/maruel/genai"
 )
 
 func TestGetSandbox(t *testing.T) {
-	if runtime.GOOS == "windows" {
-		t.Skip("Please send a PR to enable back")
-	}
-
 	ipV4 := regexp.MustCompile(`\d{1,3}\.\d{1,3}\.\d{1,3}\.\d{1,3}`)
 	t.Run("with network access", func(t *testing.T) {
 		opts, err := New(true)
@@ -104,12 +106,7 @@ func TestGetSandbox(t *testing.T) {
 	t.Run("no network access", func(t *testing.T) {
 		opts, err := New(false)
 		if err != nil {
-			if runtime.GOOS == "windows" {
-				t.Skip("please send a RP")
-			}
 			t.Fatal(err)
-		} else if runtime.GOOS == "windows" {
-			t.Fatal("should have failed")
 		}
 		if opts == nil {
 			t.Fatal("excepted opts")
@@ -181,4 +178,678 @@ func TestGetSandbox(t *testing.T) {
 			}
 		})
 	})
+
+	t.Run("interpreter", func(t *testing.T) {
+		python, err := exec.LookPath("python3")
+		if err != nil {
+			t.Skip("python3 not found")
+		}
+		if python, err = filepath.Abs(python); err != nil {
+			t.Fatal(err)
+		}
+		opts, err := NewWithOptions(false, &Options{Interpreters: []Interpreter{{Name: "python3", Path: python, Ext: ".py"}}})
+		if err != nil {
+			t.Fatal(err)
+		}
+		b, _ := json.Marshal(&arguments{Script: "import sys\nprint(sys.version_info[0] * 14)\n", Interpreter: "python3"})
+		msg := genai.Message{Replies: []genai.Reply{{ToolCall: genai.ToolCall{Name: opts.Tools[0].Name, Arguments: string(b)}}}}
+		res, err := msg.DoToolCalls(t.Context(), opts.Tools)
+		if err != nil {
+			t.Fatalf("Got error: %v", err)
+		}
+		if got := strings.TrimSpace(res.ToolCallResults[0].Result); got != "42" {
+			t.Fatalf("unexpected output %q", got)
+		}
+	})
+
+	t.Run("env allowlist", func(t *testing.T) {
+		t.Setenv("SHELLTOOL_ALLOWED", "yes")
+		t.Setenv("SHELLTOOL_SECRET", "leak")
+		opts, err := NewWithOptions(true, &Options{EnvAllowlist: []string{"PATH", "HOME", "PATHEXT", "SystemRoot", "windir", "TEMP", "TMP", "USERPROFILE", "SHELLTOOL_ALLOWED"}})
+		if err != nil {
+			t.Fatal(err)
+		}
+		script := "echo \"[$SHELLTOOL_ALLOWED][$SHELLTOOL_SECRET]\"\n"
+		if runtime.GOOS == "windows" {
+			script = "Write-Output \"[$env:SHELLTOOL_ALLOWED][$env:SHELLTOOL_SECRET]\"\n"
+		}
+		b, _ := json.Marshal(&arguments{Script: script})
+		msg := genai.Message{Replies: []genai.Reply{{ToolCall: genai.ToolCall{Name: opts.Tools[0].Name, Arguments: string(b)}}}}
+		res, err := msg.DoToolCalls(t.Context(), opts.Tools)
+		if err != nil {
+			t.Fatalf("Got error: %v", err)
+		}
+		if got := strings.TrimSpace(res.ToolCallResults[0].Result); got != "[yes][]" {
+			t.Fatalf("unexpected output %q", got)
+		}
+	})
+
+	t.Run("writable paths", func(t *testing.T) {
+		dir := t.TempDir()
+		opts, err := NewWithOptions(false, &Options{WritablePaths: []string{dir}})
+		if err != nil {
+			t.Fatal(err)
+		}
+		p := filepath.Join(dir, "out.txt")
+		b, _ := json.Marshal(&arguments{Script: "echo hello > '" + p + "'\ncat '" + p + "'\n"})
+		msg := genai.Message{Replies: []genai.Reply{{ToolCall: genai.ToolCall{Name: opts.Tools[0].Name, Arguments: string(b)}}}}
+		res, err := msg.DoToolCalls(t.Context(), opts.Tools)
+		if err != nil {
+			t.Fatalf("Got error: %v", err)
+		}
+		if got := res.ToolCallResults[0].Result; got != "hello\n" {
+			t.Fatalf("unexpected output %q", got)
+		}
+		if b, err := os.ReadFile(p); err != nil || string(b) != "hello\n" {
+			t.Fatalf("unexpected file content %q, %v", b, err)
+		}
+	})
+}
+
+func TestWritablePathsValidate(t *testing.T) {
+	data := []struct {
+		paths []string
+		err   string
+	}{
+		{[]string{"relative/dir"}, `WritablePaths: "relative/dir" is not an absolute path`},
+		{[]string{filepath.Join(t.TempDir(), "missing")}, "WritablePaths: "},
+	}
+	for i, line := range data {
+		if _, err := NewWithOptions(true, &Options{WritablePaths: line.paths}); err == nil || !strings.Contains(err.Error(), line.err) {
+			t.Fatalf("#%d: Expected error containing %q but got %v", i, line.err, err)
+		}
+		if _, err := PreviewPolicy(true, &Options{WritablePaths: line.paths}); err == nil || !strings.Contains(err.Error(), line.err) {
+			t.Fatalf("#%d: Expected error containing %q but got %v", i, line.err, err)
+		}
+	}
+}
+
+func TestOptionsTransform(t *testing.T) {
+	opts := Options{
+		Transform: func(script string) (string, error) {
+			if strings.Contains(script, "rm -rf") {
+				return "", errors.New("destructive command")
+			}
+			return "set -euo pipefail\n" + script, nil
+		},
+	}
+	got, rejected := opts.prepareScript("echo hi\n")
+	if rejected != "" {
+		t.Fatalf("unexpected rejection: %q", rejected)
+	}
+	if want := "set -euo pipefail\necho hi\n"; got != want {
+		t.Fatalf("unexpected script\nwant: %q\ngot:  %q", want, got)
+	}
+	if _, rejected = opts.prepareScript("rm -rf /\n"); rejected != "script rejected: destructive command" {
+		t.Fatalf("unexpected rejection: %q", rejected)
+	}
+}
+
+// fakeSandbox returns a sandbox that echoes back the script content instead of
+// running it.
+func fakeSandbox() *sandbox {
+	return &sandbox{
+		name: "fake",
+		ext:  ".sh",
+		exec: func(ctx context.Context, r *execRequest) (string, error) {
+			if r.path == "" {
+				return r.content, nil
+			}
+			b, err := os.ReadFile(r.path)
+			return string(b), err
+		},
+	}
+}
+
+// shSandbox returns a sandbox that runs the script with /bin/sh without any
+// isolation, the way the Unix backends do.
+func shSandbox() *sandbox {
+	return &sandbox{
+		name: "sh",
+		ext:  ".sh",
+		exec: func(ctx context.Context, r *execRequest) (string, error) {
+			arg := r.path
+			if r.path == "" {
+				arg = "-s"
+			}
+			interpreter := r.interpreter
+			if interpreter == "" {
+				interpreter = "/bin/sh"
+			}
+			cmd := exec.CommandContext(ctx, interpreter, arg)
+			cmd.Dir = r.dir
+			cmd.Env = r.env
+			if r.path == "" {
+				cmd.Stdin = strings.NewReader(r.content)
+			} else if r.stdin != "" {
+				cmd.Stdin = strings.NewReader(r.stdin)
+			}
+			return r.runCmd(cmd)
+		},
+	}
+}
+
+func TestOptionsMaxScriptSize(t *testing.T) {
+	data := []struct {
+		opts     Options
+		script   string
+		rejected string
+	}{
+		{Options{}, strings.Repeat("a", DefaultMaxScriptSize), ""},
+		{Options{}, strings.Repeat("a", DefaultMaxScriptSize+1), "script rejected: script is 1048577 bytes, larger than the maximum of 1048576 bytes"},
+		{Options{MaxScriptSize: 4}, "echo", ""},
+		{Options{MaxScriptSize: 4}, "echo hi", "script rejected: script is 7 bytes, larger than the maximum of 4 bytes"},
+		{Options{MaxScriptSize: -1}, strings.Repeat("a", DefaultMaxScriptSize+1), ""},
+		{
+			Options{MaxScriptSize: 8, Transform: func(s string) (string, error) { return "set -e\n" + s, nil }},
+			"echo",
+			"script rejected: script is 11 bytes, larger than the maximum of 8 bytes",
+		},
+	}
+	for i, line := range data {
+		if _, rejected := line.opts.prepareScript(line.script); rejected != line.rejected {
+			t.Fatalf("#%d: unexpected rejection\nwant: %q\ngot:  %q", i, line.rejected, rejected)
+		}
+	}
+}
+
+func TestScriptViaStdin(t *testing.T) {
+	s := &sandbox{
+		name: "fake",
+		ext:  ".sh",
+		exec: func(ctx context.Context, r *execRequest) (string, error) {
+			if r.path != "" {
+				return "", errors.New("unexpected script file " + r.path)
+			}
+			return r.content, nil
+		},
+	}
+	o := Options{ScriptViaStdin: true}
+	got, err := o.run(t.Context(), s, &arguments{Script: "echo hi\n"})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if got != "echo hi\n" {
+		t.Fatalf("unexpected output %q", got)
+	}
+}
+
+func TestSandboxEnv(t *testing.T) {
+	s := &sandbox{
+		name: "fake",
+		ext:  ".sh",
+		env:  []string{"FOO=bar"},
+		exec: func(ctx context.Context, r *execRequest) (string, error) {
+			return strings.Join(r.env, "\n"), nil
+		},
+	}
+	o := Options{}
+	got, err := o.run(t.Context(), s, &arguments{Script: "env"})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if !strings.HasSuffix(got, "\nLANG=C\nFOO=bar") {
+		t.Fatalf("unexpected environment %q", got)
+	}
+}
+
+func TestStructuredOutput(t *testing.T) {
+	s := fakeSandbox()
+	o := Options{}
+	got, err := o.run(t.Context(), s, &arguments{Script: "echo hi\n"})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if got != "echo hi\n" {
+		t.Fatalf("unexpected plain output %q", got)
+	}
+	o.StructuredOutput = true
+	got, err = o.run(t.Context(), s, &arguments{Script: "echo hi\n"})
+	if err != nil {
+		t.Fatal(err)
+	}
+	res := result{}
+	if err := json.Unmarshal([]byte(got), &res); err != nil {
+		t.Fatal(err)
+	}
+	if res.Output != "echo hi\n" || len(res.RunID) != 16 {
+		t.Fatalf("unexpected result %q", got)
+	}
+	got2, _ := o.run(t.Context(), s, &arguments{Script: "echo hi\n"})
+	if got2 == got {
+		t.Fatal("run IDs must be unique")
+	}
+}
+
+func TestOptionsTimeout(t *testing.T) {
+	s := &sandbox{
+		name: "fake",
+		ext:  ".sh",
+		exec: func(ctx context.Context, r *execRequest) (string, error) {
+			<-ctx.Done()
+			return "partial", errors.New("signal: killed")
+		},
+	}
+	o := Options{Timeout: 10 * time.Millisecond}
+	got, err := o.run(t.Context(), s, &arguments{Script: "while true; do :; done"})
+	if !errors.Is(err, context.DeadlineExceeded) {
+		t.Fatalf("Expected a timeout error but got %v", err)
+	}
+	if want := "script timed out after 10ms: context deadline exceeded"; err.Error() != want {
+		t.Fatalf("Expected %q but got %q", want, err.Error())
+	}
+	if got != "partial" {
+		t.Fatalf("Expected the partial output but got %q", got)
+	}
+
+	// A failing script is not a timeout.
+	s.exec = func(ctx context.Context, r *execRequest) (string, error) {
+		return "", errors.New("exit status 1")
+	}
+	if _, err = o.run(t.Context(), s, &arguments{Script: "false"}); err == nil || errors.Is(err, context.DeadlineExceeded) {
+		t.Fatalf("Expected a non-timeout error but got %v", err)
+	}
+}
+
+func TestSandboxSetupError(t *testing.T) {
+	exitErr := errors.New("exit status 65")
+	tests := []struct {
+		name  string
+		tool  string
+		out   string
+		err   error
+		setup bool
+	}{
+		{"malformed_profile", "sandbox-exec", "sandbox-exec: invalid data type of path filter; expected pattern, got boolean\n", exitErr, true},
+		{"apply_denied", "sandbox-exec", "sandbox-exec: sandbox_apply: Operation not permitted\n", exitErr, true},
+		{"script_failure", "sandbox-exec", "ls: cannot access 'foo': No such file or directory\n", exitErr, false},
+		{"script_output_mentions_tool", "sandbox-exec", "hi\nsandbox-exec: fake\n", exitErr, false},
+		{"success", "sandbox-exec", "sandbox-exec: hi\n", nil, false},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			err := sandboxSetupError(tt.tool, tt.out, tt.err)
+			if got := errors.Is(err, ErrSandboxSetup); got != tt.setup {
+				t.Fatalf("Expected setup error %t but got %v", tt.setup, err)
+			}
+			if tt.setup && !strings.Contains(err.Error(), strings.TrimSpace(tt.out)) {
+				t.Fatalf("Expected the sandbox message in %q", err.Error())
+			}
+		})
+	}
+}
+
+func TestCappedBuffer(t *testing.T) {
+	tests := []struct {
+		name     string
+		limit    int
+		writes   []string
+		expected string
+	}{
+		{"under", 10, []string{"hello\n"}, "hello\n"},
+		{"exact", 6, []string{"hello\n"}, "hello\n"},
+		{"over", 4, []string{"hello\n"}, "hell\n... [output truncated]"},
+		{"over_newline", 6, []string{"hello\n", "world\n"}, "hello\n... [output truncated]"},
+		{"unlimited", -1, []string{"hello\n", "world\n"}, "hello\nworld\n"},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			b := cappedBuffer{limit: tt.limit}
+			for _, w := range tt.writes {
+				_, _ = b.Write([]byte(w))
+			}
+			if got := b.String(); got != tt.expected {
+				t.Fatalf("Expected %q but got %q", tt.expected, got)
+			}
+		})
+	}
+}
+
+func TestOptionsMaxOutputSize(t *testing.T) {
+	if runtime.GOOS == "windows" {
+		t.Skip("uses /bin/sh")
+	}
+	const limit = 1000
+	s := shSandbox()
+	o := Options{MaxOutputSize: limit}
+	got, err := o.run(t.Context(), s, &arguments{Script: "yes\n"})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if want := strings.Repeat("y\n", limit/2) + "... [output truncated]"; got != want {
+		t.Fatalf("Expected %d bytes with the marker but got %d bytes: %q", len(want), len(got), got[max(0, len(got)-40):])
+	}
+	if got, err = o.run(t.Context(), s, &arguments{Script: "echo hi\n"}); err != nil || got != "hi\n" {
+		t.Fatalf("unexpected output %q, %v", got, err)
+	}
+	if o.maxOutput() != limit || (&Options{}).maxOutput() != DefaultMaxOutputSize || (&Options{MaxOutputSize: -5}).maxOutput() != -1 {
+		t.Fatal("unexpected effective limit")
+	}
+}
+
+func TestExitCode(t *testing.T) {
+	if runtime.GOOS == "windows" {
+		t.Skip("uses /bin/sh")
+	}
+	s := shSandbox()
+	o := Options{}
+	got, err := o.run(t.Context(), s, &arguments{Script: "echo out\necho err >&2\nexit 3\n"})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if want := "EXIT_CODE=3\nout\nerr\n"; got != want {
+		t.Fatalf("Expected %q but got %q", want, got)
+	}
+	if got, err = o.run(t.Context(), s, &arguments{Script: "echo ok\n"}); err != nil || got != "ok\n" {
+		t.Fatalf("unexpected output %q, %v", got, err)
+	}
+
+	o.StructuredOutput = true
+	got, err = o.run(t.Context(), s, &arguments{Script: "echo out\nexit 3\n"})
+	if err != nil {
+		t.Fatal(err)
+	}
+	res := result{}
+	if err := json.Unmarshal([]byte(got), &res); err != nil {
+		t.Fatal(err)
+	}
+	if res.ExitCode != 3 || res.Output != "out\n" {
+		t.Fatalf("unexpected result %q", got)
+	}
+
+	// Failing to launch is still an error.
+	s.exec = func(ctx context.Context, r *execRequest) (string, error) {
+		return r.runCmd(exec.CommandContext(ctx, "/nonexistent/sh", r.path))
+	}
+	if _, err = o.run(t.Context(), s, &arguments{Script: "exit 3\n"}); err == nil {
+		t.Fatal("Expected an error")
+	}
+}
+
+func TestExitError(t *testing.T) {
+	for _, tt := range []struct {
+		code     int
+		expected string
+	}{
+		{2, "exit code 2"},
+		{-1073741819, "exit code 0xc0000005"},
+	} {
+		if got := (&exitError{code: tt.code}).Error(); got != tt.expected {
+			t.Fatalf("Expected %q but got %q", tt.expected, got)
+		}
+	}
+}
+
+func TestStdin(t *testing.T) {
+	if runtime.GOOS == "windows" {
+		t.Skip("uses /bin/sh")
+	}
+	for _, viaStdin := range []bool{false, true} {
+		o := Options{ScriptViaStdin: viaStdin}
+		got, err := o.run(t.Context(), shSandbox(), &arguments{Script: "sort\n", Stdin: "b\na\nc\n"})
+		if err != nil {
+			t.Fatal(err)
+		}
+		if want := "a\nb\nc\n"; got != want {
+			t.Fatalf("ScriptViaStdin=%t: Expected %q but got %q", viaStdin, want, got)
+		}
+		// Without stdin data, the script reads nothing.
+		if got, err = o.run(t.Context(), shSandbox(), &arguments{Script: "sort\necho done\n"}); err != nil || got != "done\n" {
+			t.Fatalf("ScriptViaStdin=%t: unexpected output %q, %v", viaStdin, got, err)
+		}
+	}
+}
+
+func TestDir(t *testing.T) {
+	if runtime.GOOS == "windows" {
+		t.Skip("uses /bin/sh")
+	}
+	dir, err := filepath.EvalSymlinks(t.TempDir())
+	if err != nil {
+		t.Fatal(err)
+	}
+	if err = os.Mkdir(filepath.Join(dir, "sub"), 0o755); err != nil {
+		t.Fatal(err)
+	}
+	o := Options{}
+	tests := []struct {
+		name     string
+		script   string
+		dir      string
+		expected string
+	}{
+		{"pwd", "pwd\n", dir, dir + "\n"},
+		{"cd", "cd sub\npwd\n", dir, filepath.Join(dir, "sub") + "\n"},
+		{"missing", "pwd\n", filepath.Join(dir, "missing"), "invalid dir: stat " + filepath.Join(dir, "missing") + ": no such file or directory"},
+		{"file", "pwd\n", "/dev/null", `invalid dir: "/dev/null" is not a directory`},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := o.run(t.Context(), shSandbox(), &arguments{Script: tt.script, Dir: tt.dir})
+			if err != nil {
+				t.Fatal(err)
+			}
+			if got != tt.expected {
+				t.Fatalf("Expected %q but got %q", tt.expected, got)
+			}
+		})
+	}
+}
+
+func TestInterpreter(t *testing.T) {
+	if runtime.GOOS == "windows" {
+		t.Skip("uses /bin/sh")
+	}
+	python, err := exec.LookPath("python3")
+	if err != nil {
+		t.Skip("python3 not found")
+	}
+	if python, err = filepath.Abs(python); err != nil {
+		t.Fatal(err)
+	}
+	o := Options{Interpreters: []Interpreter{{Name: "python3", Path: python, Ext: ".py"}}, ScriptViaStdin: true}
+	tests := []struct {
+		name        string
+		interpreter string
+		script      string
+		expected    string
+	}{
+		{"python", "python3", "import sys\nprint(sys.argv[0].endswith('.py'))\n", "True\n"},
+		{"default", "", "echo $((6 * 7))\n", "42\n"},
+		{"default_by_name", "sh", "echo hi\n", "hi\n"},
+		{"unknown", "node", "console.log(1)\n", `unknown interpreter "node"`},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := o.run(t.Context(), shSandbox(), &arguments{Script: tt.script, Interpreter: tt.interpreter})
+			if err != nil {
+				t.Fatal(err)
+			}
+			if got != tt.expected {
+				t.Fatalf("Expected %q but got %q", tt.expected, got)
+			}
+		})
+	}
+}
+
+func TestInterpretersValidate(t *testing.T) {
+	exe, err := os.Executable()
+	if err != nil {
+		t.Fatal(err)
+	}
+	data := []struct {
+		interpreters []Interpreter
+		err          string
+	}{
+		{[]Interpreter{{Path: exe}}, "Interpreters: empty name"},
+		{[]Interpreter{{Name: "py", Path: "python3"}}, `Interpreters: py: "python3" is not an absolute path`},
+		{[]Interpreter{{Name: "py", Path: filepath.Join(t.TempDir(), "missing")}}, "Interpreters: py: "},
+		{[]Interpreter{{Name: "py", Path: t.TempDir()}}, "is a directory"},
+		{[]Interpreter{{Name: "py", Path: exe, Ext: "py"}}, `Interpreters: py: extension "py" must start with a dot`},
+		{[]Interpreter{{Name: "py", Path: exe}, {Name: "py", Path: exe}}, `Interpreters: duplicate name "py"`},
+	}
+	for i, line := range data {
+		if _, err := NewWithOptions(true, &Options{Interpreters: line.interpreters}); err == nil || !strings.Contains(err.Error(), line.err) {
+			t.Fatalf("#%d: Expected error containing %q but got %v", i, line.err, err)
+		}
+	}
+}
+
+func TestBuildEnv(t *testing.T) {
+	environ := []string{"PATH=/bin", "HOME=/home/u", "LC_ALL=fr_FR", "LC_TIME=C", "SECRET=x", "LANG=fr_FR", "=C:=C:\\", "Path=C:\\"}
+	tests := []struct {
+		name            string
+		allowlist       []string
+		denylist        []string
+		extra           []string
+		caseInsensitive bool
+		expected        []string
+	}{
+		{"all", nil, nil, nil, false, []string{"PATH=/bin", "HOME=/home/u", "LC_ALL=fr_FR", "LC_TIME=C", "SECRET=x", "LANG=C", "=C:=C:\\", "Path=C:\\"}},
+		{"allowlist", []string{"PATH", "LC_*"}, nil, nil, false, []string{"PATH=/bin", "LC_ALL=fr_FR", "LC_TIME=C", "LANG=C"}},
+		{"extra_overrides", []string{"HOME"}, nil, []string{"HOME=/sandbox", "FOO=bar", "MY_TOKEN=kept"}, false, []string{"HOME=/sandbox", "LANG=C", "FOO=bar", "MY_TOKEN=kept"}},
+		{"denylist", nil, DefaultEnvDenylist, nil, false, []string{"PATH=/bin", "HOME=/home/u", "LC_ALL=fr_FR", "LC_TIME=C", "LANG=C", "=C:=C:\\", "Path=C:\\"}},
+		{"denylist_case", []string{"PATH", "SECRET"}, []string{"secret"}, nil, false, []string{"PATH=/bin", "LANG=C"}},
+		{"case_insensitive", []string{"path"}, nil, nil, true, []string{"Path=C:\\", "LANG=C"}},
+		{"hidden", []string{"=C:"}, nil, nil, true, []string{"=C:=C:\\", "LANG=C"}},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := buildEnv(environ, tt.allowlist, tt.denylist, tt.extra, tt.caseInsensitive); !slices.Equal(got, tt.expected) {
+				t.Fatalf("Expected %q but got %q", tt.expected, got)
+			}
+		})
+	}
+}
+
+func TestEnvAllowlist(t *testing.T) {
+	if runtime.GOOS == "windows" {
+		t.Skip("uses /bin/sh")
+	}
+	t.Setenv("SHELLTOOL_ALLOWED", "yes")
+	t.Setenv("SHELLTOOL_SECRET", "leak")
+	o := Options{EnvAllowlist: []string{"PATH", "SHELLTOOL_ALLOWED"}}
+	got, err := o.run(t.Context(), shSandbox(), &arguments{Script: "echo \"[$SHELLTOOL_ALLOWED][$SHELLTOOL_SECRET][$LANG]\"\n"})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if want := "[yes][][C]\n"; got != want {
+		t.Fatalf("Expected %q but got %q", want, got)
+	}
+}
+
+func TestEnvDenylist(t *testing.T) {
+	if runtime.GOOS == "windows" {
+		t.Skip("uses /bin/sh")
+	}
+	t.Setenv("FAKE_OPENAI_API_KEY", "sk-fake")
+	t.Setenv("GITHUB_TOKEN", "ghp_fake")
+	t.Setenv("SHELLTOOL_VISIBLE", "visible")
+	data := []struct {
+		deny    []string
+		visible []string
+		hidden  []string
+	}{
+		{nil, []string{"SHELLTOOL_VISIBLE=visible"}, []string{"sk-fake", "ghp_fake"}},
+		{[]string{"SHELLTOOL_*"}, []string{"sk-fake", "ghp_fake"}, []string{"SHELLTOOL_VISIBLE"}},
+		{[]string{}, []string{"sk-fake", "ghp_fake", "SHELLTOOL_VISIBLE=visible"}, nil},
+	}
+	for i, line := range data {
+		o := Options{EnvDenylist: line.deny}
+		got, err := o.run(t.Context(), shSandbox(), &arguments{Script: "env\n"})
+		if err != nil {
+			t.Fatal(err)
+		}
+		for _, v := range line.visible {
+			if !strings.Contains(got, v) {
+				t.Fatalf("#%d: Expected %q in the environment:\n%s", i, v, got)
+			}
+		}
+		for _, v := range line.hidden {
+			if strings.Contains(got, v) {
+				t.Fatalf("#%d: Expected %q to be scrubbed from the environment:\n%s", i, v, got)
+			}
+		}
+	}
+}
+
+func TestOptionsPolicy(t *testing.T) {
+	var got []ScriptRequest
+	data := []struct {
+		decision Decision
+		err      error
+		want     string
+		wantErr  string
+	}{
+		{Decision{Allow: true}, nil, "echo hi\n", ""},
+		{Decision{Reason: "network access is not permitted"}, nil, "script denied by policy: network access is not permitted", ""},
+		{Decision{}, nil, "script denied by policy: no reason given", ""},
+		{Decision{Allow: true}, errors.New("engine unavailable"), "", "policy: engine unavailable"},
+	}
+	for i, line := range data {
+		o := Options{
+			Transform: func(script string) (string, error) {
+				return "#!/bin/sh\n" + script, nil
+			},
+			WritablePaths: []string{"/tmp"},
+			Policy: func(ctx context.Context, req ScriptRequest) (Decision, error) {
+				got = append(got, req)
+				return line.decision, line.err
+			},
+		}
+		s := fakeSandbox()
+		s.allowNetwork = true
+		out, err := o.run(t.Context(), s, &arguments{Script: "echo hi\n", Stdin: "in"})
+		if line.wantErr != "" {
+			if err == nil || err.Error() != line.wantErr {
+				t.Fatalf("#%d: Expected error %q but got %v", i, line.wantErr, err)
+			}
+			continue
+		}
+		if err != nil {
+			t.Fatalf("#%d: %v", i, err)
+		}
+		if !strings.HasSuffix(out, line.want) {
+			t.Fatalf("#%d: Expected %q but got %q", i, line.want, out)
+		}
+	}
+	want := ScriptRequest{Script: "#!/bin/sh\necho hi\n", Stdin: "in", AllowNetwork: true, WritablePaths: []string{"/tmp"}}
+	for i := range got {
+		if !reflect.DeepEqual(got[i], want) {
+			t.Fatalf("#%d: Expected %+v but got %+v", i, want, got[i])
+		}
+	}
+	if len(got) != len(data) {
+		t.Fatalf("Expected %d calls but got %d", len(data), len(got))
+	}
+}
+
+func TestArgumentsSchema(t *testing.T) {
+	data := []struct {
+		callback any
+		typ      reflect.Type
+	}{
+		{func(ctx context.Context, args *arguments) (string, error) { return "", nil }, reflect.TypeFor[arguments]()},
+		{func(ctx context.Context, args *pageArguments) (string, error) { return "", nil }, reflect.TypeFor[pageArguments]()},
+	}
+	for _, line := range data {
+		tool := genai.ToolDef{Name: "shell", Description: "shell", Callback: line.callback}
+		if err := tool.Validate(); err != nil {
+			t.Fatal(err)
+		}
+		schema := tool.GetInputSchema()
+		for i := range line.typ.NumField() {
+			name, _, _ := strings.Cut(line.typ.Field(i).Tag.Get("json"), ",")
+			p, ok := schema.Properties.Get(name)
+			if !ok {
+				t.Fatalf("%s: Expected property %q in the schema", line.typ, name)
+			}
+			if p.Description == "" {
+				t.Fatalf("%s: Expected a description for property %q", line.typ, name)
+			}
+		}
+		if schema.Properties.Len() != line.typ.NumField() {
+			t.Fatalf("%s: Expected %d properties but got %d", line.typ, line.typ.NumField(), schema.Properties.Len())
+		}
+	}
 }
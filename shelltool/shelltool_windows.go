@@ -5,27 +5,33 @@
 package shelltool
 
 import (
-	"bytes"
 	"context"
-	"errors"
 	"fmt"
-	"log/slog"
 	"os"
+	"runtime"
+	"strings"
+	"sync/atomic"
+	"time"
+	"unicode/utf16"
 	"unsafe"
 
-	"github.com/maruel/genai"
 	"golang.org/x/sys/windows"
 )
 
 var (
-	advapi32                                      = windows.NewLazyDLL("advapi32.dll")
-	procCreateRestrictedToken                     = advapi32.NewProc("CreateRestrictedToken")
-	userenv                                       = windows.NewLazyDLL("userenv.dll")
-	procCreateAppContainerProfile                 = userenv.NewProc("CreateAppContainerProfile")
-	procDeleteAppContainerProfile                 = userenv.NewProc("DeleteAppContainerProfile")
-	procDeriveAppContainerSidFromAppContainerName = userenv.NewProc("DeriveAppContainerSidFromAppContainerName")
+	advapi32                      = windows.NewLazyDLL("advapi32.dll")
+	procCreateRestrictedToken     = advapi32.NewProc("CreateRestrictedToken")
+	userenv                       = windows.NewLazyDLL("userenv.dll")
+	procCreateAppContainerProfile = userenv.NewProc("CreateAppContainerProfile")
+	procDeleteAppContainerProfile = userenv.NewProc("DeleteAppContainerProfile")
+	kernel32                      = windows.NewLazyDLL("kernel32.dll")
+	procCancelSynchronousIo       = kernel32.NewProc("CancelSynchronousIo")
 )
 
+// profileSeq makes each AppContainer profile name unique so concurrent calls
+// do not clash.
+var profileSeq atomic.Int64
+
 // Win32 APIs.
 const (
 	ProcThreadAttributeSecurityCapabilities = 0x00020005
@@ -68,41 +74,79 @@ type SecurityCapabilities struct {
 	Reserved        uint32
 }
 
-func getShellTool(allowNetwork bool) (*genai.GenOptionTools, error) {
-	if true {
-		return nil, errors.New("to be finished later")
+func newSandbox(allowNetwork bool, opts *Options) (*sandbox, error) {
+	profile := opts.WindowsProfileName
+	if profile == "" {
+		profile = fmt.Sprintf("genaitools-shelltool-%d", os.Getpid())
 	}
-	if !allowNetwork {
-		// It randomly causes, or fail at attributeList.Update():
-		//   runtime: waitforsingleobject wait_failed; errno=6
-		//   fatal error: runtime.semasleep wait_failed
-		return nil, errors.New("please send a PR to finish the AppContainer code")
-	}
-	return &genai.GenOptionTools{
-		Tools: []genai.ToolDef{
-			{
-				Name:        "powershell",
-				Description: "Writes the script to a file, executes it via PowerShell on the Windows computer, and returns the output",
-				Callback: func(ctx context.Context, args *arguments) (string, error) {
-					scriptPath, err := writeTempFile("ask.*.ps1", args.Script)
-					if err != nil {
-						return "", fmt.Errorf("failed to create temp file: %w", err)
-					}
-					defer func() {
-						_ = os.Remove(scriptPath)
-					}()
-					psCmd := fmt.Sprintf("powershell.exe -ExecutionPolicy Bypass -File %q", scriptPath)
-					out, err := runWithAppContainer(psCmd, allowNetwork)
-					slog.DebugContext(ctx, "bash", "command", args.Script, "output", out, "err", err)
-					_ = os.Remove(scriptPath)
-					return out, err
-				},
-			},
+	return &sandbox{
+		name:        "powershell",
+		description: "Writes the script to a file, executes it via PowerShell on the Windows computer, and returns the output",
+		ext:         ".ps1",
+		exec: func(ctx context.Context, r *execRequest) (string, error) {
+			psCmd := fmt.Sprintf("powershell.exe -ExecutionPolicy Bypass -File %q", r.path)
+			stdin := r.stdin
+			var files []string
+			if r.interpreter != "" {
+				psCmd = fmt.Sprintf("%q %q", r.interpreter, r.path)
+				files = []string{r.path}
+			} else if r.path == "" {
+				psCmd = "powershell.exe -ExecutionPolicy Bypass -Command -"
+				stdin = r.content
+			} else {
+				files = []string{r.path}
+			}
+			return runWithAppContainer(ctx, psCmd, stdin, r.dir, files, r.env, r.maxOutput, fmt.Sprintf("%s-%d", profile, profileSeq.Add(1)), allowNetwork)
 		},
 	}, nil
 }
 
-func runWithAppContainer(cmdLine string, allowNetwork bool) (string, error) {
+// appContainerCapabilities are the capabilities of the AppContainer used when
+// the network is not allowed.
+var appContainerCapabilities = []string{
+	WellKnownSIDCapabilityDocumentsLibrary,
+	WellKnownSIDCapabilityPicturesLibrary,
+	WellKnownSIDCapabilityVideosLibrary,
+	WellKnownSIDCapabilityMusicLibrary,
+	WellKnownSIDCapabilityRemovableStorage,
+	// WellKnownSIDCapabilityInternetClient,
+	// WellKnownSIDCapabilityInternetClientServer,
+	// WellKnownSIDCapabilityPrivateNetworkClientServer,
+}
+
+func previewPolicy(allowNetwork bool, opts *Options) (string, error) {
+	var b strings.Builder
+	b.WriteString("restricted token: DisableMaxPrivilege LUAToken\n")
+	if allowNetwork {
+		b.WriteString("appcontainer: none\nnetwork: allowed\n")
+	} else {
+		profile := opts.WindowsProfileName
+		if profile == "" {
+			profile = "genaitools-shelltool-<pid>"
+		}
+		b.WriteString("appcontainer: " + profile + "-<n>\nnetwork: denied\n")
+		for _, c := range appContainerCapabilities {
+			b.WriteString("capability: " + c + "\n")
+		}
+		b.WriteString("read access: <script>\n")
+	}
+	return b.String(), nil
+}
+
+// runWithAppContainer runs cmdLine under a restricted token with the
+// environment env in the directory dir, or the current directory if empty.
+// When stdin is not empty, it is written to the process' stdin. At most
+// maxOutput bytes of output are kept; the processes are terminated once the
+// limit is reached.
+//
+// When allowNetwork is false, it also runs inside the AppContainer profileName,
+// which is created and deleted for the duration of the call. The AppContainer
+// has no network capability and is granted read access to files, since it
+// cannot read the user's temporary directory otherwise.
+//
+// The process runs in a job object so its child processes are terminated along
+// with it. When ctx is done, the job is terminated and ctx.Err() is returned.
+func runWithAppContainer(ctx context.Context, cmdLine, stdin, dir string, files, env []string, maxOutput int, profileName string, allowNetwork bool) (string, error) {
 	var token windows.Token
 	if err := windows.OpenProcessToken(windows.CurrentProcess(), windows.TOKEN_ALL_ACCESS, &token); err != nil {
 		return "", fmt.Errorf("failed to open process token: %w", err)
@@ -132,36 +176,29 @@ func runWithAppContainer(cmdLine string, allowNetwork bool) (string, error) {
 
 	var attrList *windows.ProcThreadAttributeList
 	if !allowNetwork {
-		caps := []string{
-			WellKnownSIDCapabilityDocumentsLibrary,
-			WellKnownSIDCapabilityPicturesLibrary,
-			WellKnownSIDCapabilityVideosLibrary,
-			WellKnownSIDCapabilityMusicLibrary,
-			WellKnownSIDCapabilityRemovableStorage,
-			// WellKnownSIDCapabilityInternetClient,
-			// WellKnownSIDCapabilityInternetClientServer,
-			// WellKnownSIDCapabilityPrivateNetworkClientServer,
-		}
-		sidAndAttrs, err2 := createCapabilitySIDs(caps)
+		sidAndAttrs, err2 := createCapabilitySIDs(appContainerCapabilities)
 		if err2 != nil {
 			return "", err2
 		}
-		profileName := "genaitools-shelltool-Container"
+		defer func() {
+			for _, c := range sidAndAttrs {
+				_, _ = windows.LocalFree(windows.Handle(unsafe.Pointer(c.Sid)))
+			}
+		}()
 		appContainerSid, err2 := createContainer(profileName, sidAndAttrs)
 		if err2 != nil {
 			return "", err2
 		}
+		// Delete the profile even on error paths so it isn't left over.
+		defer func() {
+			_, _, _ = procDeleteAppContainerProfile.Call(uintptr(unsafe.Pointer(windows.StringToUTF16Ptr(profileName))))
+		}()
 		defer func() {
 			_ = windows.FreeSid(appContainerSid)
 		}()
-		if false {
-			defer func() {
-				_, _, _ = procDeleteAppContainerProfile.Call(uintptr(unsafe.Pointer(windows.StringToUTF16Ptr(profileName))))
-			}()
-			// appContainerSid
-			_, err2 := createAppContainerSid(profileName)
-			if err2 != nil {
-				return "", fmt.Errorf("failed to get AppContainer SID: %w", err2)
+		for _, f := range files {
+			if err2 = grantRead(f, appContainerSid); err2 != nil {
+				return "", err2
 			}
 		}
 		secCaps := SecurityCapabilities{
@@ -178,15 +215,25 @@ func runWithAppContainer(cmdLine string, allowNetwork bool) (string, error) {
 	}
 
 	// There isn't much point into separating stdout and stderr to send it back to the LLM, so merge both.
-	stdoutRead, stdoutWrite, err := createPipe()
+	stdoutRead, stdoutWrite, err := createPipe(true)
 	if err != nil {
 		return "", fmt.Errorf("failed to create stdout pipe: %w", err)
 	}
+	// stdoutRead is owned by the pipeReader once it is started.
+	stdoutReadOpen := true
 	defer func() {
-		_ = windows.CloseHandle(stdoutRead)
+		if stdoutReadOpen {
+			_ = windows.CloseHandle(stdoutRead)
+		}
 	}()
+	// stdoutWrite must be closed exactly once: closing it again after the
+	// handle value was reused, e.g. by the Go runtime for a semaphore, crashes
+	// the process with "runtime.semasleep wait_failed".
+	stdoutWriteOpen := true
 	defer func() {
-		_ = windows.CloseHandle(stdoutWrite)
+		if stdoutWriteOpen {
+			_ = windows.CloseHandle(stdoutWrite)
+		}
 	}()
 
 	si := windows.StartupInfoEx{
@@ -198,62 +245,225 @@ func runWithAppContainer(cmdLine string, allowNetwork bool) (string, error) {
 		},
 		ProcThreadAttributeList: attrList,
 	}
-	pi := windows.ProcessInformation{}
-	var flag uint32 = windows.CREATE_NEW_CONSOLE | windows.EXTENDED_STARTUPINFO_PRESENT
-	if err := windows.CreateProcessAsUser(restrictedToken, nil, windows.StringToUTF16Ptr(cmdLine), nil, nil, true, flag, nil, nil, &si.StartupInfo, &pi); err != nil {
+	var stdinRead, stdinWrite windows.Handle
+	if stdin != "" {
+		if stdinRead, stdinWrite, err = createPipe(false); err != nil {
+			return "", fmt.Errorf("failed to create stdin pipe: %w", err)
+		}
+		defer func() {
+			_ = windows.CloseHandle(stdinRead)
+		}()
+		si.StdInput = stdinRead
+	}
+	job, err := newKillOnCloseJob()
+	if err != nil {
 		return "", err
 	}
+	// Closing the job terminates the processes still running in it.
 	defer func() {
-		_ = windows.CloseHandle(pi.Process)
+		_ = windows.CloseHandle(job)
 	}()
+	pi := windows.ProcessInformation{}
+	// The process is started suspended so it is in the job before it can start
+	// child processes.
+	var flag uint32 = windows.CREATE_NEW_CONSOLE | windows.EXTENDED_STARTUPINFO_PRESENT | windows.CREATE_UNICODE_ENVIRONMENT | windows.CREATE_SUSPENDED
+	var currentDir *uint16
+	if dir != "" {
+		currentDir = windows.StringToUTF16Ptr(dir)
+	}
+	if err := windows.CreateProcessAsUser(restrictedToken, nil, windows.StringToUTF16Ptr(cmdLine), nil, nil, true, flag, envBlock(env), currentDir, &si.StartupInfo, &pi); err != nil {
+		if stdin != "" {
+			_ = windows.CloseHandle(stdinWrite)
+		}
+		return "", err
+	}
 	defer func() {
 		_ = windows.CloseHandle(pi.Thread)
 	}()
+	if err := windows.AssignProcessToJobObject(job, pi.Process); err != nil {
+		_ = windows.TerminateProcess(pi.Process, 1)
+		_ = windows.CloseHandle(pi.Process)
+		if stdin != "" {
+			_ = windows.CloseHandle(stdinWrite)
+		}
+		return "", fmt.Errorf("AssignProcessToJobObject failed: %w", err)
+	}
+	// From now on, closing the job terminates the process.
+	exited := make(chan uint32, 1)
+	go func() {
+		_, _ = windows.WaitForSingleObject(pi.Process, windows.INFINITE)
+		var exitCode uint32
+		_ = windows.GetExitCodeProcess(pi.Process, &exitCode)
+		_ = windows.CloseHandle(pi.Process)
+		exited <- exitCode
+	}()
 	// Close write handles in parent process to avoid blocking.
 	_ = windows.CloseHandle(stdoutWrite)
-	stdout := readFromPipe(stdoutRead)
-	_, _ = windows.WaitForSingleObject(pi.Process, windows.INFINITE)
+	stdoutWriteOpen = false
+	if stdin != "" {
+		// Write concurrently with reading the output so neither pipe fills up.
+		go func() {
+			var written uint32
+			_ = windows.WriteFile(stdinWrite, []byte(stdin), &written, nil)
+			_ = windows.CloseHandle(stdinWrite)
+		}()
+	}
+	buf := &cappedBuffer{limit: maxOutput}
+	stdoutReadOpen = false
+	pr, err := startPipeReader(stdoutRead, buf, func() {
+		_ = windows.TerminateJobObject(job, 1)
+	})
+	if err != nil {
+		return "", err
+	}
+	if _, err := windows.ResumeThread(pi.Thread); err != nil {
+		_ = windows.TerminateJobObject(job, 1)
+		pr.wait()
+		return "", fmt.Errorf("ResumeThread failed: %w", err)
+	}
+	// Every return below waits for the reader since it may terminate job.
 	var exitCode uint32
-	_ = windows.GetExitCodeProcess(pi.Process, &exitCode)
-	err = nil
+	select {
+	case exitCode = <-exited:
+	case <-ctx.Done():
+		_ = windows.TerminateJobObject(job, 1)
+		return pr.wait(), ctx.Err()
+	}
+	stdout := pr.wait()
+	if buf.truncated {
+		return stdout, nil
+	}
 	if exitCode != 0 {
-		if exitCode > 255 {
-			err = fmt.Errorf("exit code 0x%08x", exitCode)
-		} else {
-			err = fmt.Errorf("exit code %d", exitCode)
-		}
+		return stdout, &exitError{code: int(int32(exitCode))}
 	}
-	return stdout, err
+	return stdout, nil
 }
 
-func createPipe() (windows.Handle, windows.Handle, error) {
+// newKillOnCloseJob returns a job object that terminates its processes once
+// its last handle is closed.
+func newKillOnCloseJob() (windows.Handle, error) {
+	job, err := windows.CreateJobObject(nil, nil)
+	if err != nil {
+		return 0, fmt.Errorf("CreateJobObject failed: %w", err)
+	}
+	info := windows.JOBOBJECT_EXTENDED_LIMIT_INFORMATION{
+		BasicLimitInformation: windows.JOBOBJECT_BASIC_LIMIT_INFORMATION{
+			LimitFlags: windows.JOB_OBJECT_LIMIT_KILL_ON_JOB_CLOSE,
+		},
+	}
+	if _, err = windows.SetInformationJobObject(job, windows.JobObjectExtendedLimitInformation, uintptr(unsafe.Pointer(&info)), uint32(unsafe.Sizeof(info))); err != nil {
+		_ = windows.CloseHandle(job)
+		return 0, fmt.Errorf("SetInformationJobObject failed: %w", err)
+	}
+	return job, nil
+}
+
+// envBlock returns env as a Unicode environment block: NUL terminated
+// strings followed by a final NUL.
+func envBlock(env []string) *uint16 {
+	var b []uint16
+	for _, e := range env {
+		b = append(b, utf16.Encode([]rune(e))...)
+		b = append(b, 0)
+	}
+	if len(b) == 0 {
+		b = append(b, 0)
+	}
+	b = append(b, 0)
+	return &b[0]
+}
+
+// createPipe creates a pipe where only the end used by the child process is
+// inheritable: the write end for an output, the read end for an input.
+func createPipe(output bool) (windows.Handle, windows.Handle, error) {
 	sa := windows.SecurityAttributes{Length: uint32(unsafe.Sizeof(windows.SecurityAttributes{})), InheritHandle: 1}
 	var r, w windows.Handle
 	if err := windows.CreatePipe(&r, &w, &sa, 0); err != nil {
 		return 0, 0, fmt.Errorf("CreatePipe failed: %w", err)
 	}
-	// Make sure the read handle is not inherited.
-	_ = windows.SetHandleInformation(r, windows.HANDLE_FLAG_INHERIT, 0)
+	// Make sure the parent's handle is not inherited.
+	if output {
+		_ = windows.SetHandleInformation(r, windows.HANDLE_FLAG_INHERIT, 0)
+	} else {
+		_ = windows.SetHandleInformation(w, windows.HANDLE_FLAG_INHERIT, 0)
+	}
 	return r, w, nil
 }
 
-func readFromPipe(handle windows.Handle) string {
-	buf := bytes.Buffer{}
-	buffer := make([]byte, 4096)
-	var bytesRead uint32
+// pipeReader reads a pipe into a cappedBuffer on a dedicated OS thread, so a
+// read blocked on a pipe held open by a grandchild process can be canceled with
+// CancelSynchronousIo. It owns the pipe handle.
+type pipeReader struct {
+	thread windows.Handle
+	stop   atomic.Bool
+	done   chan string
+}
+
+// startPipeReader starts reading handle into buf. full is called when buf
+// reached its limit.
+func startPipeReader(handle windows.Handle, buf *cappedBuffer, full func()) (*pipeReader, error) {
+	p := &pipeReader{done: make(chan string, 1)}
+	ready := make(chan error, 1)
+	go func() {
+		defer func() {
+			_ = windows.CloseHandle(handle)
+		}()
+		// The thread is never unlocked so it exits with the goroutine. This way
+		// a late CancelSynchronousIo cannot cancel an unrelated operation.
+		runtime.LockOSThread()
+		err := windows.DuplicateHandle(windows.CurrentProcess(), windows.CurrentThread(), windows.CurrentProcess(), &p.thread, 0, false, windows.DUPLICATE_SAME_ACCESS)
+		ready <- err
+		if err != nil {
+			return
+		}
+		buffer := make([]byte, 4096)
+		var bytesRead uint32
+		for !p.stop.Load() {
+			if err := windows.ReadFile(handle, buffer, &bytesRead, nil); err != nil {
+				break
+			}
+			if _, err := buf.Write(buffer[:bytesRead]); err != nil {
+				full()
+				break
+			}
+		}
+		p.done <- buf.String()
+	}()
+	if err := <-ready; err != nil {
+		return nil, fmt.Errorf("DuplicateHandle failed: %w", err)
+	}
+	return p, nil
+}
+
+// wait returns the output once the pipe is closed or the limit is reached.
+//
+// If the pipe is still open waitDelay later, e.g. held by a process that
+// escaped the job, the read is canceled and the output so far is returned.
+func (p *pipeReader) wait() string {
+	defer func() {
+		_ = windows.CloseHandle(p.thread)
+	}()
+	select {
+	case s := <-p.done:
+		return s
+	case <-time.After(waitDelay):
+	}
+	p.stop.Store(true)
 	for {
-		if err := windows.ReadFile(handle, buffer, &bytesRead, nil); err != nil {
-			break
+		// The reader may be between two reads; retry until it notices stop.
+		_, _, _ = procCancelSynchronousIo.Call(uintptr(p.thread))
+		select {
+		case s := <-p.done:
+			return s
+		case <-time.After(10 * time.Millisecond):
 		}
-		buf.Write(buffer[:bytesRead])
 	}
-	return buf.String()
 }
 
 func createContainer(profileName string, sidAndAttrs []windows.SIDAndAttributes) (*windows.SID, error) {
 	profileNamePtr := windows.StringToUTF16Ptr(profileName)
-	displayNamePtr := windows.StringToUTF16Ptr("shelltool App Container")
-	descriptionPtr := windows.StringToUTF16Ptr("Highly restricted shelltool App Container")
+	displayNamePtr := windows.StringToUTF16Ptr("shelltool " + profileName)
+	descriptionPtr := windows.StringToUTF16Ptr("Highly restricted shelltool App Container " + profileName)
 	var appContainerSid *windows.SID
 	// https://learn.microsoft.com/en-us/windows/win32/api/userenv/nf-userenv-createappcontainerprofile
 	ret, _, err := procCreateAppContainerProfile.Call(
@@ -286,22 +496,9 @@ func createContainer(profileName string, sidAndAttrs []windows.SIDAndAttributes)
 	return appContainerSid, nil
 }
 
-func createAppContainerSid(profileName string) (*windows.SID, error) {
-	var sid *windows.SID
-	ret, _, err := procDeriveAppContainerSidFromAppContainerName.Call(
-		uintptr(unsafe.Pointer(windows.StringToUTF16Ptr(profileName))),
-		uintptr(unsafe.Pointer(&sid)),
-	)
-	if ret != 0 {
-		return nil, fmt.Errorf("DeriveAppContainerSidFromAppContainerName failed: %w", err)
-	}
-	return sid, nil
-}
-
 // https://github.com/rancher-sandbox/rancher-desktop/blob/main/src/go/rdctl/pkg/process/process_windows.go shows job object use.
 // https://blahcat.github.io/2020-12-29-cheap-sandboxing-with-appcontainers/
 func setupAppContainerAttributes(secCaps *SecurityCapabilities) (*windows.ProcThreadAttributeListContainer, error) {
-	// TODO: Testing with zero.
 	attributeList, err := windows.NewProcThreadAttributeList(1)
 	if err != nil {
 		return nil, fmt.Errorf("failed to NewProcThreadAttributeList: %w", err)
@@ -313,6 +510,36 @@ func setupAppContainerAttributes(secCaps *SecurityCapabilities) (*windows.ProcTh
 	return attributeList, err
 }
 
+// grantRead adds an access control entry allowing sid to read and execute
+// the file at path.
+func grantRead(path string, sid *windows.SID) error {
+	sd, err := windows.GetNamedSecurityInfo(path, windows.SE_FILE_OBJECT, windows.DACL_SECURITY_INFORMATION)
+	if err != nil {
+		return fmt.Errorf("failed to get the security info of %s: %w", path, err)
+	}
+	dacl, _, err := sd.DACL()
+	if err != nil {
+		return fmt.Errorf("failed to get the DACL of %s: %w", path, err)
+	}
+	acl, err := windows.ACLFromEntries([]windows.EXPLICIT_ACCESS{{
+		AccessPermissions: windows.GENERIC_READ | windows.GENERIC_EXECUTE,
+		AccessMode:        windows.GRANT_ACCESS,
+		Inheritance:       windows.NO_INHERITANCE,
+		Trustee: windows.TRUSTEE{
+			TrusteeForm:  windows.TRUSTEE_IS_SID,
+			TrusteeType:  windows.TRUSTEE_IS_WELL_KNOWN_GROUP,
+			TrusteeValue: windows.TrusteeValueFromSID(sid),
+		},
+	}}, dacl)
+	if err != nil {
+		return fmt.Errorf("failed to build the ACL of %s: %w", path, err)
+	}
+	if err = windows.SetNamedSecurityInfo(path, windows.SE_FILE_OBJECT, windows.DACL_SECURITY_INFORMATION, nil, nil, acl, nil); err != nil {
+		return fmt.Errorf("failed to grant the AppContainer access to %s: %w", path, err)
+	}
+	return nil
+}
+
 func createCapabilitySIDs(sidStrings []string) ([]windows.SIDAndAttributes, error) {
 	if len(sidStrings) == 0 {
 		return nil, nil
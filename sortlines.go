@@ -0,0 +1,102 @@
+// Copyright 2025 Marc-Antoine Ruel. All rights reserved.
+// Use of this source code is governed under the Apache License, Version 2.0
+// that can be found in the LICENSE file.
+
+package genaitools
+
+import (
+	"context"
+	"regexp"
+	"slices"
+	"strconv"
+	"strings"
+
+	"github.com/maruel/genai"
+)
+
+// SortLines sorts the lines of a text, like sort.
+//
+// The lines are compared byte-wise, or by their leading number when numeric
+// is set, like sort -n. In numeric mode, lines without a leading number sort
+// first and ties are broken byte-wise. The sort is stable. unique removes the
+// duplicate lines, like sort -u.
+//
+// The line ending (LF or CRLF) and the presence of a trailing newline are
+// preserved.
+var SortLines = genai.ToolDef{
+	Name:        "sort_lines",
+	Description: "Sorts the lines of a text alphabetically or numerically, optionally in reverse and removing duplicate lines, like sort -n -r -u.",
+	Callback:    doSortLines,
+}
+
+type sortLinesArgs struct {
+	Input   string `json:"input" jsonschema:"description=Text with one item per line"`
+	Unique  bool   `json:"unique,omitempty" jsonschema:"description=Remove duplicate lines"`
+	Numeric bool   `json:"numeric,omitempty" jsonschema:"description=Compare the leading number of each line instead of the text"`
+	Reverse bool   `json:"reverse,omitempty" jsonschema:"description=Sort in descending order"`
+}
+
+var reLeadingNumber = regexp.MustCompile(`^\s*[-+]?(?:\d+(?:\.\d*)?|\.\d+)`)
+
+func doSortLines(ctx context.Context, args *sortLinesArgs) (string, error) {
+	if args.Input == "" {
+		return "", nil
+	}
+	eol := "\n"
+	if strings.Contains(args.Input, "\r\n") {
+		eol = "\r\n"
+	}
+	text, trailing := strings.CutSuffix(args.Input, eol)
+	if !trailing {
+		text, trailing = strings.CutSuffix(args.Input, "\n")
+	}
+	lines := strings.Split(text, "\n")
+	for i := range lines {
+		lines[i] = strings.TrimSuffix(lines[i], "\r")
+	}
+	cmp := strings.Compare
+	if args.Numeric {
+		cmp = compareLeadingNumbers
+	}
+	slices.SortStableFunc(lines, func(a, b string) int {
+		if args.Reverse {
+			return cmp(b, a)
+		}
+		return cmp(a, b)
+	})
+	if args.Unique {
+		lines = slices.Compact(lines)
+	}
+	out := strings.Join(lines, eol)
+	if trailing {
+		out += eol
+	}
+	return out, nil
+}
+
+// compareLeadingNumbers compares a and b by their leading number. Lines
+// without a number sort first.
+func compareLeadingNumbers(a, b string) int {
+	na, oka := leadingNumber(a)
+	nb, okb := leadingNumber(b)
+	switch {
+	case oka && okb && na < nb:
+		return -1
+	case oka && okb && na > nb:
+		return 1
+	case oka && !okb:
+		return 1
+	case !oka && okb:
+		return -1
+	}
+	return strings.Compare(a, b)
+}
+
+func leadingNumber(s string) (float64, bool) {
+	m := reLeadingNumber.FindString(s)
+	if m == "" {
+		return 0, false
+	}
+	f, err := strconv.ParseFloat(strings.TrimSpace(m), 64)
+	return f, err == nil
+}
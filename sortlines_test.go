@@ -0,0 +1,49 @@
+// Copyright 2025 Marc-Antoine Ruel. All rights reserved.
+// Use of this source code is governed under the Apache License, Version 2.0
+// that can be found in the LICENSE file.
+
+package genaitools
+
+import (
+	"context"
+	"testing"
+)
+
+func TestSortLines(t *testing.T) {
+	callback := SortLines.Callback.(func(context.Context, *sortLinesArgs) (string, error))
+	tests := []struct {
+		name     string
+		args     sortLinesArgs
+		expected string
+	}{
+		{"lexical", sortLinesArgs{Input: "10\n9\n100\n-1\n"}, "-1\n10\n100\n9\n"},
+		{"numeric", sortLinesArgs{Input: "10\n9\n100\n-1\n", Numeric: true}, "-1\n9\n10\n100\n"},
+		{"numeric_reverse", sortLinesArgs{Input: "10\n9\n100\n-1\n", Numeric: true, Reverse: true}, "100\n10\n9\n-1\n"},
+		{"numeric_text", sortLinesArgs{Input: "2 apples\nbanana\n 1.5 kg\n.5 cup\n10 eggs", Numeric: true}, "banana\n.5 cup\n 1.5 kg\n2 apples\n10 eggs"},
+		{"numeric_ties", sortLinesArgs{Input: "1 b\n01 a\n1 a\n", Numeric: true}, "01 a\n1 a\n1 b\n"},
+		{"lexical_case", sortLinesArgs{Input: "b\nB\na\nA"}, "A\nB\na\nb"},
+		{"reverse", sortLinesArgs{Input: "a\nc\nb\n", Reverse: true}, "c\nb\na\n"},
+		{"unique", sortLinesArgs{Input: "b\na\nb\na\nc\n", Unique: true}, "a\nb\nc\n"},
+		{"unique_reverse", sortLinesArgs{Input: "b\na\nb\n", Unique: true, Reverse: true}, "b\na\n"},
+		{"not_unique", sortLinesArgs{Input: "b\na\nb\n"}, "a\nb\nb\n"},
+		{"unique_numeric_keeps_distinct", sortLinesArgs{Input: "1\n01\n1\n", Unique: true, Numeric: true}, "01\n1\n"},
+		{"no_trailing_newline", sortLinesArgs{Input: "b\na"}, "a\nb"},
+		{"crlf", sortLinesArgs{Input: "b\r\na\r\nc\r\n"}, "a\r\nb\r\nc\r\n"},
+		{"crlf_mixed", sortLinesArgs{Input: "b\r\na\nc"}, "a\r\nb\r\nc"},
+		{"empty_lines", sortLinesArgs{Input: "b\n\na\n"}, "\na\nb\n"},
+		{"unicode", sortLinesArgs{Input: "é\ne\nz\n"}, "e\nz\né\n"},
+		{"empty", sortLinesArgs{Input: ""}, ""},
+		{"single_newline", sortLinesArgs{Input: "\n"}, "\n"},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := callback(t.Context(), &tt.args)
+			if err != nil {
+				t.Fatalf("Unexpected error: %v", err)
+			}
+			if got != tt.expected {
+				t.Fatalf("Expected %q but got %q", tt.expected, got)
+			}
+		})
+	}
+}
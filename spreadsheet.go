@@ -0,0 +1,50 @@
+// Copyright 2025 Marc-Antoine Ruel. All rights reserved.
+// Use of this source code is governed under the Apache License, Version 2.0
+// that can be found in the LICENSE file.
+
+package genaitools
+
+import (
+	"context"
+	"fmt"
+	"math"
+
+	"github.com/maruel/genai"
+)
+
+// SpreadsheetFormula evaluates a spreadsheet-like formula referencing named
+// values.
+//
+// For example the formula "revenue - cost" with the values {"revenue": 100,
+// "cost": 40} returns "60".
+//
+// It supports + - * / % ^, parentheses, the comparisons < <= > >= = <> and
+// the functions SUM, AVG, MIN and MAX. Comparisons return 1 when true and 0
+// otherwise.
+var SpreadsheetFormula = genai.ToolDef{
+	Name:        "spreadsheet_formula",
+	Description: "Evaluates a spreadsheet-like formula referencing named values and returns the result. Supports + - * / % ^, parentheses, comparisons (< <= > >= = <>) returning 1 or 0, and the functions SUM, AVG, MIN and MAX.",
+	Callback:    doSpreadsheetFormula,
+}
+
+type spreadsheetFormulaArgs struct {
+	Formula string             `json:"formula" jsonschema:"description=Formula to evaluate\\, e.g. SUM(a\\, b) * rate"`
+	Values  map[string]float64 `json:"values,omitempty" jsonschema:"description=Named values referenced by the formula"`
+}
+
+func doSpreadsheetFormula(ctx context.Context, args *spreadsheetFormulaArgs) (string, error) {
+	vars := args.Values
+	if vars == nil {
+		// A non-nil map makes the evaluator report undefined variables instead
+		// of unknown identifiers.
+		vars = map[string]float64{}
+	}
+	r, err := evalExpression(args.Formula, &exprEnv{vars: vars, funcs: true})
+	if err != nil {
+		return "", fmt.Errorf("failed to evaluate formula: %w", err)
+	}
+	if math.IsNaN(r) || math.IsInf(r, 0) {
+		return "", fmt.Errorf("the result of %q is not a finite number", args.Formula)
+	}
+	return formatFloat(r), nil
+}
@@ -0,0 +1,62 @@
+// Copyright 2025 Marc-Antoine Ruel. All rights reserved.
+// Use of this source code is governed under the Apache License, Version 2.0
+// that can be found in the LICENSE file.
+
+package genaitools
+
+import (
+	"context"
+	"strings"
+	"testing"
+)
+
+func TestSpreadsheetFormula(t *testing.T) {
+	values := map[string]float64{"revenue": 100, "cost": 40, "rate": 0.5}
+	tests := []struct {
+		name      string
+		formula   string
+		values    map[string]float64
+		expected  string
+		errSubstr string
+	}{
+		{"subtraction", "revenue - cost", values, "60", ""},
+		{"precedence", "revenue - cost * rate", values, "80", ""},
+		{"parentheses", "(revenue - cost) * rate", values, "30", ""},
+		{"power", "2 ^ 3 ^ 2", nil, "512", ""},
+		{"unary_minus", "-cost + revenue", values, "60", ""},
+		{"sum", "SUM(revenue, cost, 10)", values, "150", ""},
+		{"avg", "avg(revenue, cost)", values, "70", ""},
+		{"min", "MIN(revenue, cost, rate)", values, "0.500000", ""},
+		{"max", "MAX(revenue, cost) / 4", values, "25", ""},
+		{"comparison_true", "revenue > cost", values, "1", ""},
+		{"comparison_false", "revenue <= cost", values, "0", ""},
+		{"not_equal", "revenue <> cost", values, "1", ""},
+		{"undefined", "revenue - tax", values, "", `undefined variable "tax"`},
+		{"undefined_no_values", "revenue", nil, "", `undefined variable "revenue"`},
+		{"unknown_function", "FOO(1)", values, "", `unknown function "FOO"`},
+		{"divide_by_zero", "revenue / (cost - 40)", values, "", "cannot divide by zero"},
+		{"overflow", "10^400", nil, "", `the result of "10^400" is not a finite number`},
+		{"overflow_values", "revenue ^ 1000", values, "", "is not a finite number"},
+		{"unbalanced", "(revenue - cost", values, "", "unbalanced parentheses"},
+		{"unbalanced_close", "revenue - cost)", values, "", "unbalanced parentheses"},
+		{"empty", "", values, "", "unexpected end of expression"},
+	}
+	callback := SpreadsheetFormula.Callback.(func(context.Context, *spreadsheetFormulaArgs) (string, error))
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := callback(t.Context(), &spreadsheetFormulaArgs{Formula: tt.formula, Values: tt.values})
+			if tt.errSubstr != "" {
+				if err == nil || !strings.Contains(err.Error(), tt.errSubstr) {
+					t.Fatalf("Expected error containing %q but got %v", tt.errSubstr, err)
+				}
+				return
+			}
+			if err != nil {
+				t.Fatalf("Unexpected error: %v", err)
+			}
+			if got != tt.expected {
+				t.Fatalf("Expected %q but got %q", tt.expected, got)
+			}
+		})
+	}
+}
@@ -0,0 +1,86 @@
+// Copyright 2025 Marc-Antoine Ruel. All rights reserved.
+// Use of this source code is governed under the Apache License, Version 2.0
+// that can be found in the LICENSE file.
+
+package genaitools
+
+import (
+	"context"
+	"fmt"
+	"math"
+	"strconv"
+	"strings"
+
+	"github.com/maruel/genai"
+)
+
+// SpreadsheetColumn converts between spreadsheet column letters (A, Z, AA,
+// AB, ...) and 1-based column indices.
+//
+// Columns use the bijective base-26 numeration: A is 1, Z is 26, AA is 27 and
+// XFD, the last column of Excel, is 16384. There is no zero digit so the
+// mapping isn't a plain base conversion.
+var SpreadsheetColumn = genai.ToolDef{
+	Name:        "spreadsheet_column",
+	Description: "Converts a spreadsheet column between letters like A, Z, AA or XFD and its 1-based index like 1, 26, 27 or 16384.",
+	Callback:    doSpreadsheetColumn,
+}
+
+type spreadsheetColumnArgs struct {
+	Operation string `json:"operation" jsonschema:"description=to_index converts letters to an index; to_letters converts an index to letters,enum=to_index,enum=to_letters"`
+	Value     string `json:"value" jsonschema:"description=Column letters like AB or 1-based index like 28"`
+}
+
+func doSpreadsheetColumn(ctx context.Context, args *spreadsheetColumnArgs) (string, error) {
+	v := strings.TrimSpace(args.Value)
+	switch args.Operation {
+	case "to_index":
+		n, err := columnIndex(v)
+		if err != nil {
+			return "", err
+		}
+		return strconv.FormatInt(n, 10), nil
+	case "to_letters":
+		n, err := strconv.ParseInt(v, 10, 64)
+		if err != nil || n < 1 {
+			return "", fmt.Errorf("invalid index %q; expected an integer of at least 1", args.Value)
+		}
+		return columnLetters(n), nil
+	default:
+		return "", fmt.Errorf("unknown operation %q; supported operations are to_index and to_letters", args.Operation)
+	}
+}
+
+// columnIndex returns the 1-based index of column letters, case-insensitively.
+func columnIndex(s string) (int64, error) {
+	if s == "" {
+		return 0, fmt.Errorf("invalid column %q; expected letters like A or AB", s)
+	}
+	var n int64
+	for i := range len(s) {
+		c := s[i]
+		if c >= 'a' && c <= 'z' {
+			c -= 'a' - 'A'
+		}
+		if c < 'A' || c > 'Z' {
+			return 0, fmt.Errorf("invalid column %q; expected letters like A or AB", s)
+		}
+		if n > (math.MaxInt64-26)/26 {
+			return 0, fmt.Errorf("column %q is too large", s)
+		}
+		n = n*26 + int64(c-'A'+1)
+	}
+	return n, nil
+}
+
+// columnLetters returns the letters of the 1-based column index n.
+func columnLetters(n int64) string {
+	var b []byte
+	for ; n > 0; n = (n - 1) / 26 {
+		b = append(b, byte('A'+(n-1)%26))
+	}
+	for i, j := 0, len(b)-1; i < j; i, j = i+1, j-1 {
+		b[i], b[j] = b[j], b[i]
+	}
+	return string(b)
+}
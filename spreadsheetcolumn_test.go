@@ -0,0 +1,68 @@
+// Copyright 2025 Marc-Antoine Ruel. All rights reserved.
+// Use of this source code is governed under the Apache License, Version 2.0
+// that can be found in the LICENSE file.
+
+package genaitools
+
+import (
+	"context"
+	"strings"
+	"testing"
+)
+
+func TestSpreadsheetColumn(t *testing.T) {
+	callback := SpreadsheetColumn.Callback.(func(context.Context, *spreadsheetColumnArgs) (string, error))
+	tests := []struct {
+		name      string
+		args      spreadsheetColumnArgs
+		expected  string
+		errSubstr string
+	}{
+		{"a", spreadsheetColumnArgs{Operation: "to_index", Value: "A"}, "1", ""},
+		{"z", spreadsheetColumnArgs{Operation: "to_index", Value: "Z"}, "26", ""},
+		{"aa", spreadsheetColumnArgs{Operation: "to_index", Value: "AA"}, "27", ""},
+		{"az", spreadsheetColumnArgs{Operation: "to_index", Value: "AZ"}, "52", ""},
+		{"zz", spreadsheetColumnArgs{Operation: "to_index", Value: "ZZ"}, "702", ""},
+		{"aaa", spreadsheetColumnArgs{Operation: "to_index", Value: "AAA"}, "703", ""},
+		{"lowercase", spreadsheetColumnArgs{Operation: "to_index", Value: " xfd "}, "16384", ""},
+		{"1", spreadsheetColumnArgs{Operation: "to_letters", Value: "1"}, "A", ""},
+		{"26", spreadsheetColumnArgs{Operation: "to_letters", Value: "26"}, "Z", ""},
+		{"27", spreadsheetColumnArgs{Operation: "to_letters", Value: "27"}, "AA", ""},
+		{"52", spreadsheetColumnArgs{Operation: "to_letters", Value: "52"}, "AZ", ""},
+		{"702", spreadsheetColumnArgs{Operation: "to_letters", Value: "702"}, "ZZ", ""},
+		{"703", spreadsheetColumnArgs{Operation: "to_letters", Value: "703"}, "AAA", ""},
+		{"16384", spreadsheetColumnArgs{Operation: "to_letters", Value: "16384"}, "XFD", ""},
+		{"empty", spreadsheetColumnArgs{Operation: "to_index"}, "", "invalid column"},
+		{"cell", spreadsheetColumnArgs{Operation: "to_index", Value: "A1"}, "", `invalid column "A1"`},
+		{"overflow", spreadsheetColumnArgs{Operation: "to_index", Value: strings.Repeat("Z", 14)}, "", "too large"},
+		{"zero", spreadsheetColumnArgs{Operation: "to_letters", Value: "0"}, "", `invalid index "0"`},
+		{"letters", spreadsheetColumnArgs{Operation: "to_letters", Value: "AB"}, "", `invalid index "AB"`},
+		{"bad_operation", spreadsheetColumnArgs{Operation: "convert", Value: "A"}, "", "unknown operation"},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := callback(t.Context(), &tt.args)
+			if tt.errSubstr != "" {
+				if err == nil || !strings.Contains(err.Error(), tt.errSubstr) {
+					t.Fatalf("Expected error containing %q but got %v", tt.errSubstr, err)
+				}
+				return
+			}
+			if err != nil {
+				t.Fatalf("Unexpected error: %v", err)
+			}
+			if got != tt.expected {
+				t.Fatalf("Expected %q but got %q", tt.expected, got)
+			}
+		})
+	}
+}
+
+func TestColumnRoundTrip(t *testing.T) {
+	for n := int64(1); n < 20000; n++ {
+		got, err := columnIndex(columnLetters(n))
+		if err != nil || got != n {
+			t.Fatalf("Expected %d but got %d (%v) for %q", n, got, err, columnLetters(n))
+		}
+	}
+}
@@ -0,0 +1,116 @@
+// Copyright 2025 Marc-Antoine Ruel. All rights reserved.
+// Use of this source code is governed under the Apache License, Version 2.0
+// that can be found in the LICENSE file.
+
+package genaitools
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"math"
+	"slices"
+
+	"github.com/maruel/genai"
+)
+
+// Statistics computes descriptive statistics of a list of numbers.
+//
+// The variance and standard deviation are the population ones; the sample
+// ones, with Bessel's correction, are included when there are at least two
+// numbers. They are computed with Welford's algorithm, which is stable when
+// the values are large compared to their spread. The mode lists every value
+// tied for the highest count and is omitted when all the values are equally
+// frequent, unless there is only one distinct value.
+var Statistics = genai.ToolDef{
+	Name:        "statistics",
+	Description: "Computes the count, mean, median, mode, min, max, variance and standard deviation (population and sample) of a list of numbers and returns them as JSON.",
+	Callback:    doStatistics,
+}
+
+type statisticsArgs struct {
+	Numbers []json.Number `json:"numbers" jsonschema:"description=Numbers to analyze"`
+}
+
+type statisticsResult struct {
+	Count          int       `json:"count"`
+	Mean           float64   `json:"mean"`
+	Median         float64   `json:"median"`
+	Mode           []float64 `json:"mode,omitempty"`
+	Min            float64   `json:"min"`
+	Max            float64   `json:"max"`
+	Variance       float64   `json:"variance"`
+	StdDev         float64   `json:"stddev"`
+	SampleVariance *float64  `json:"sample_variance,omitempty"`
+	SampleStdDev   *float64  `json:"sample_stddev,omitempty"`
+}
+
+func doStatistics(ctx context.Context, args *statisticsArgs) (string, error) {
+	if len(args.Numbers) == 0 {
+		return "", errors.New("at least one number is required")
+	}
+	values := make([]float64, len(args.Numbers))
+	for i, n := range args.Numbers {
+		f, err := n.Float64()
+		if err != nil {
+			return "", fmt.Errorf("couldn't understand number %d %q: %w", i, n, err)
+		}
+		values[i] = f
+	}
+	// Welford's online algorithm.
+	mean, m2 := 0., 0.
+	for i, v := range values {
+		d := v - mean
+		mean += d / float64(i+1)
+		m2 += d * (v - mean)
+	}
+	if math.IsInf(mean, 0) || math.IsInf(m2, 0) || math.IsNaN(m2) {
+		return "", errors.New("the numbers are too large; the statistics overflow")
+	}
+	slices.Sort(values)
+	n := len(values)
+	res := statisticsResult{
+		Count:    n,
+		Mean:     mean,
+		Median:   values[n/2],
+		Mode:     statisticsMode(values),
+		Min:      values[0],
+		Max:      values[n-1],
+		Variance: m2 / float64(n),
+	}
+	if n%2 == 0 {
+		// Halve first to not overflow.
+		res.Median = values[n/2-1]/2 + values[n/2]/2
+	}
+	res.StdDev = math.Sqrt(res.Variance)
+	if n > 1 {
+		v := m2 / float64(n-1)
+		s := math.Sqrt(v)
+		res.SampleVariance, res.SampleStdDev = &v, &s
+	}
+	return marshalJSON(res)
+}
+
+// statisticsMode returns the most frequent values of the sorted values.
+func statisticsMode(sorted []float64) []float64 {
+	var modes []float64
+	best, distinct := 0, 0
+	for i := 0; i < len(sorted); {
+		j := i + 1
+		for j < len(sorted) && sorted[j] == sorted[i] {
+			j++
+		}
+		distinct++
+		if c := j - i; c > best {
+			best, modes = c, []float64{sorted[i]}
+		} else if c == best {
+			modes = append(modes, sorted[i])
+		}
+		i = j
+	}
+	if distinct > 1 && len(modes) == distinct {
+		return nil
+	}
+	return modes
+}
@@ -0,0 +1,81 @@
+// Copyright 2025 Marc-Antoine Ruel. All rights reserved.
+// Use of this source code is governed under the Apache License, Version 2.0
+// that can be found in the LICENSE file.
+
+package genaitools
+
+import (
+	"context"
+	"encoding/json"
+	"strings"
+	"testing"
+)
+
+func TestStatistics(t *testing.T) {
+	callback := Statistics.Callback.(func(context.Context, *statisticsArgs) (string, error))
+	tests := []struct {
+		name      string
+		numbers   []json.Number
+		expected  string
+		errSubstr string
+	}{
+		{
+			// The classic example with a population standard deviation of 2.
+			"known",
+			[]json.Number{"2", "4", "4", "4", "5", "5", "7", "9"},
+			`{"count":8,"mean":5,"median":4.5,"mode":[4],"min":2,"max":9,"variance":4,"stddev":2,"sample_variance":4.571428571428571,"sample_stddev":2.138089935299395}`,
+			"",
+		},
+		{
+			"odd",
+			[]json.Number{"3", "-1.5", "10"},
+			`{"count":3,"mean":3.8333333333333335,"median":3,"min":-1.5,"max":10,"variance":22.388888888888886,"stddev":4.731689855526129,"sample_variance":33.58333333333333,"sample_stddev":5.795112883571236}`,
+			"",
+		},
+		{
+			"single",
+			[]json.Number{"42"},
+			`{"count":1,"mean":42,"median":42,"mode":[42],"min":42,"max":42,"variance":0,"stddev":0}`,
+			"",
+		},
+		{
+			"multimodal",
+			[]json.Number{"1", "1", "2", "3", "3"},
+			`{"count":5,"mean":2,"median":2,"mode":[1,3],"min":1,"max":3,"variance":0.8,"stddev":0.8944271909999159,"sample_variance":1,"sample_stddev":1}`,
+			"",
+		},
+		{
+			"no_mode",
+			[]json.Number{"1", "1", "2", "2"},
+			`{"count":4,"mean":1.5,"median":1.5,"min":1,"max":2,"variance":0.25,"stddev":0.5,"sample_variance":0.3333333333333333,"sample_stddev":0.5773502691896257}`,
+			"",
+		},
+		{
+			// A naive sum of squares loses all the precision here.
+			"stable",
+			[]json.Number{"1000000004", "1000000007", "1000000013", "1000000016"},
+			`{"count":4,"mean":1000000010,"median":1000000010,"min":1000000004,"max":1000000016,"variance":22.5,"stddev":4.743416490252569,"sample_variance":30,"sample_stddev":5.477225575051661}`,
+			"",
+		},
+		{"empty", nil, "", "at least one number is required"},
+		{"invalid", []json.Number{"1", "x"}, "", `couldn't understand number 1 "x"`},
+		{"overflow", []json.Number{"1e308", "-1e308"}, "", "the statistics overflow"},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := callback(t.Context(), &statisticsArgs{Numbers: tt.numbers})
+			if tt.errSubstr != "" {
+				if err == nil || !strings.Contains(err.Error(), tt.errSubstr) {
+					t.Fatalf("Expected error containing %q but got %v", tt.errSubstr, err)
+				}
+				return
+			}
+			if err != nil {
+				t.Fatalf("Unexpected error: %v", err)
+			}
+			if got != tt.expected {
+				t.Fatalf("Expected %s but got %s", tt.expected, got)
+			}
+		})
+	}
+}
@@ -0,0 +1,78 @@
+// Copyright 2025 Marc-Antoine Ruel. All rights reserved.
+// Use of this source code is governed under the Apache License, Version 2.0
+// that can be found in the LICENSE file.
+
+package genaitools
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"math"
+
+	"github.com/maruel/genai"
+)
+
+// maxSimilarityRunes is the maximum length of each string compared by
+// StringSimilarity, since the comparison is quadratic.
+const maxSimilarityRunes = 10000
+
+// StringSimilarity computes the Levenshtein edit distance between two strings
+// and a similarity score.
+//
+// The distance is the minimum number of runes inserted, deleted or substituted
+// to transform a into b. The similarity is 1 - distance / max(len(a), len(b))
+// in runes, so 1 means identical and 0 means nothing in common.
+var StringSimilarity = genai.ToolDef{
+	Name:        "string_similarity",
+	Description: "Computes the Levenshtein edit distance between two strings and a similarity score between 0 (completely different) and 1 (identical). Returns JSON.",
+	Callback:    doStringSimilarity,
+}
+
+type stringSimilarityArgs struct {
+	A string `json:"a" jsonschema:"description=First string"`
+	B string `json:"b" jsonschema:"description=Second string"`
+}
+
+type stringSimilarityResult struct {
+	Distance   int     `json:"distance"`
+	Similarity float64 `json:"similarity"`
+}
+
+func doStringSimilarity(ctx context.Context, args *stringSimilarityArgs) (string, error) {
+	a, b := []rune(args.A), []rune(args.B)
+	if len(a) > maxSimilarityRunes || len(b) > maxSimilarityRunes {
+		return "", fmt.Errorf("strings are limited to %d characters", maxSimilarityRunes)
+	}
+	res := stringSimilarityResult{Distance: levenshtein(a, b), Similarity: 1}
+	if l := max(len(a), len(b)); l != 0 {
+		res.Similarity = math.Round((1-float64(res.Distance)/float64(l))*1e4) / 1e4
+	}
+	out, err := json.Marshal(res)
+	return string(out), err
+}
+
+// levenshtein returns the edit distance between a and b, keeping only two rows
+// of the dynamic programming matrix.
+func levenshtein(a, b []rune) int {
+	if len(a) < len(b) {
+		a, b = b, a
+	}
+	prev := make([]int, len(b)+1)
+	cur := make([]int, len(b)+1)
+	for j := range prev {
+		prev[j] = j
+	}
+	for i := 1; i <= len(a); i++ {
+		cur[0] = i
+		for j := 1; j <= len(b); j++ {
+			cost := 1
+			if a[i-1] == b[j-1] {
+				cost = 0
+			}
+			cur[j] = min(prev[j]+1, cur[j-1]+1, prev[j-1]+cost)
+		}
+		prev, cur = cur, prev
+	}
+	return prev[len(b)]
+}
@@ -0,0 +1,52 @@
+// Copyright 2025 Marc-Antoine Ruel. All rights reserved.
+// Use of this source code is governed under the Apache License, Version 2.0
+// that can be found in the LICENSE file.
+
+package genaitools
+
+import (
+	"context"
+	"strings"
+	"testing"
+)
+
+func TestStringSimilarity(t *testing.T) {
+	callback := StringSimilarity.Callback.(func(context.Context, *stringSimilarityArgs) (string, error))
+	tests := []struct {
+		name      string
+		a, b      string
+		expected  string
+		errSubstr string
+	}{
+		{"identical", "kitten", "kitten", `{"distance":0,"similarity":1}`, ""},
+		{"both_empty", "", "", `{"distance":0,"similarity":1}`, ""},
+		{"one_empty", "abc", "", `{"distance":3,"similarity":0}`, ""},
+		{"different", "abc", "xyz", `{"distance":3,"similarity":0}`, ""},
+		{"substitution", "cat", "cut", `{"distance":1,"similarity":0.6667}`, ""},
+		{"insertion", "cat", "cats", `{"distance":1,"similarity":0.75}`, ""},
+		{"deletion", "cats", "cat", `{"distance":1,"similarity":0.75}`, ""},
+		{"classic", "kitten", "sitting", `{"distance":3,"similarity":0.5714}`, ""},
+		{"case_sensitive", "Hello", "hello", `{"distance":1,"similarity":0.8}`, ""},
+		{"accent", "café", "cafe", `{"distance":1,"similarity":0.75}`, ""},
+		{"cjk", "東京都", "京都", `{"distance":1,"similarity":0.6667}`, ""},
+		{"emoji", "👍🏽", "👍", `{"distance":1,"similarity":0.5}`, ""},
+		{"too_long", strings.Repeat("a", maxSimilarityRunes+1), "a", "", "strings are limited to 10000 characters"},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := callback(t.Context(), &stringSimilarityArgs{A: tt.a, B: tt.b})
+			if tt.errSubstr != "" {
+				if err == nil || !strings.Contains(err.Error(), tt.errSubstr) {
+					t.Fatalf("Expected error containing %q but got %v", tt.errSubstr, err)
+				}
+				return
+			}
+			if err != nil {
+				t.Fatalf("Unexpected error: %v", err)
+			}
+			if got != tt.expected {
+				t.Fatalf("Expected %q but got %q", tt.expected, got)
+			}
+		})
+	}
+}
@@ -0,0 +1,143 @@
+// Copyright 2025 Marc-Antoine Ruel. All rights reserved.
+// Use of this source code is governed under the Apache License, Version 2.0
+// that can be found in the LICENSE file.
+
+package genaitools
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"regexp"
+	"strings"
+
+	"github.com/maruel/genai"
+)
+
+// maxSubstituteInput is the maximum size of the text Substitute processes.
+const maxSubstituteInput = 1 << 20
+
+// Substitute applies a sed-like "s/pattern/replacement/flags" substitution to
+// text.
+//
+// The pattern uses the Go regexp syntax (RE2). Any punctuation character can
+// be used as the delimiter instead of "/" and is escaped with a backslash. The
+// replacement refers to groups with $1, ${name} or \1. The flags are "g" to
+// replace all the matches instead of the first one, "i" for case insensitive
+// matching and "m" for ^ and $ to match at line boundaries.
+var Substitute = genai.ToolDef{
+	Name:        "substitute",
+	Description: "Applies a sed-like s/pattern/replacement/flags regular expression substitution to text and returns the result. Flags: g (all matches), i (case insensitive), m (multiline). Use $1 for groups.",
+	Callback:    doSubstitute,
+}
+
+type substituteArgs struct {
+	Text       string `json:"text"`
+	Expression string `json:"expression" jsonschema:"description=Substitution like s/foo(\\d+)/bar$1/g"`
+}
+
+func doSubstitute(ctx context.Context, args *substituteArgs) (string, error) {
+	if len(args.Text) > maxSubstituteInput {
+		return "", fmt.Errorf("text is %d bytes; the maximum is %d bytes", len(args.Text), maxSubstituteInput)
+	}
+	re, repl, global, err := parseSubstitution(args.Expression)
+	if err != nil {
+		return "", err
+	}
+	if global {
+		return re.ReplaceAllString(args.Text, repl), nil
+	}
+	m := re.FindStringSubmatchIndex(args.Text)
+	if m == nil {
+		return args.Text, nil
+	}
+	return args.Text[:m[0]] + string(re.ExpandString(nil, repl, args.Text, m)) + args.Text[m[1]:], nil
+}
+
+// parseSubstitution parses a "s/pattern/replacement/flags" expression. The
+// returned replacement uses the regexp.Expand syntax.
+func parseSubstitution(expr string) (*regexp.Regexp, string, bool, error) {
+	if len(expr) < 2 || expr[0] != 's' {
+		return nil, "", false, fmt.Errorf("invalid substitution %q; expected s/pattern/replacement/flags", expr)
+	}
+	delim := expr[1]
+	if strings.IndexByte("!\"#%&'()*+,-./:;<=>?@[]^_`{|}~", delim) < 0 {
+		return nil, "", false, fmt.Errorf("invalid delimiter %q; use a punctuation character like /", delim)
+	}
+	var parts []string
+	var cur strings.Builder
+	rest := expr[2:]
+	for i := 0; i < len(rest); i++ {
+		c := rest[i]
+		switch {
+		case c == '\\' && i+1 < len(rest) && rest[i+1] == delim:
+			cur.WriteByte(delim)
+			i++
+		case c == '\\' && i+1 < len(rest):
+			cur.WriteByte(c)
+			cur.WriteByte(rest[i+1])
+			i++
+		case c == delim && len(parts) < 2:
+			parts = append(parts, cur.String())
+			cur.Reset()
+		default:
+			cur.WriteByte(c)
+		}
+	}
+	if len(parts) != 2 {
+		return nil, "", false, fmt.Errorf("invalid substitution %q; expected 3 %q delimiters", expr, delim)
+	}
+	pattern, flags := parts[0], cur.String()
+	if pattern == "" {
+		return nil, "", false, errors.New("empty pattern")
+	}
+	global := false
+	prefix := ""
+	for _, f := range flags {
+		switch f {
+		case 'g':
+			global = true
+		case 'i', 'm':
+			if !strings.ContainsRune(prefix, f) {
+				prefix += string(f)
+			}
+		default:
+			return nil, "", false, fmt.Errorf("unknown flag %q; supported flags are g, i and m", f)
+		}
+	}
+	if prefix != "" {
+		pattern = "(?" + prefix + ")" + pattern
+	}
+	re, err := regexp.Compile(pattern)
+	if err != nil {
+		return nil, "", false, fmt.Errorf("invalid pattern: %w", err)
+	}
+	return re, sedReplacement(parts[1]), global, nil
+}
+
+// sedReplacement converts the sed escapes \1 to \9 to ${1} to ${9}, and
+// unescapes the other backslash escapes.
+func sedReplacement(s string) string {
+	var b strings.Builder
+	for i := 0; i < len(s); i++ {
+		c := s[i]
+		if c != '\\' || i+1 == len(s) {
+			b.WriteByte(c)
+			continue
+		}
+		i++
+		switch n := s[i]; {
+		case isDigit(n):
+			b.WriteString("${" + string(n) + "}")
+		case n == 'n':
+			b.WriteByte('\n')
+		case n == 't':
+			b.WriteByte('\t')
+		case n == '$':
+			b.WriteString("$$")
+		default:
+			b.WriteByte(n)
+		}
+	}
+	return b.String()
+}
@@ -0,0 +1,58 @@
+// Copyright 2025 Marc-Antoine Ruel. All rights reserved.
+// Use of this source code is governed under the Apache License, Version 2.0
+// that can be found in the LICENSE file.
+
+package genaitools
+
+import (
+	"context"
+	"strings"
+	"testing"
+)
+
+func TestSubstitute(t *testing.T) {
+	callback := Substitute.Callback.(func(context.Context, *substituteArgs) (string, error))
+	tests := []struct {
+		name      string
+		text      string
+		expr      string
+		expected  string
+		errSubstr string
+	}{
+		{"first", "a1 a2 a3", `s/a(\d)/b$1/`, "b1 a2 a3", ""},
+		{"global", "a1 a2 a3", `s/a(\d)/b${1}x/g`, "b1x b2x b3x", ""},
+		{"sed_backref", "john smith", `s/(\w+) (\w+)/\2, \1/`, "smith, john", ""},
+		{"named", "2024-01-02", `s/(?P<y>\d+)-(?P<m>\d+)-(?P<d>\d+)/$d.$m.$y/`, "02.01.2024", ""},
+		{"case_insensitive", "Hello HELLO hello", "s/hello/bye/gi", "bye bye bye", ""},
+		{"multiline", "a\nb", "s/^/> /gm", "> a\n> b", ""},
+		{"other_delimiter", "/usr/local/bin", "s|/usr/local|/opt|", "/opt/bin", ""},
+		{"escaped_delimiter", "a/b", `s/\//-/`, "a-b", ""},
+		{"escapes", "a b", `s/ /\t\$\\/`, "a\t$\\b", ""},
+		{"no_match", "abc", "s/x/y/g", "abc", ""},
+		{"empty_replacement", "a-b-c", "s/-//g", "abc", ""},
+		{"not_s", "abc", "y/a/b/", "", `invalid substitution "y/a/b/"`},
+		{"bad_delimiter", "abc", "sxaxbx", "", "invalid delimiter 'x'"},
+		{"missing_delimiter", "abc", "s/a/b", "", `expected 3 '/' delimiters`},
+		{"empty_pattern", "abc", "s//b/", "", "empty pattern"},
+		{"bad_flag", "abc", "s/a/b/x", "", "unknown flag 'x'"},
+		{"bad_pattern", "abc", "s/(a/b/", "", "invalid pattern: error parsing regexp"},
+		{"too_large", strings.Repeat("a", maxSubstituteInput+1), "s/a/b/", "", "the maximum is 1048576 bytes"},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := callback(t.Context(), &substituteArgs{Text: tt.text, Expression: tt.expr})
+			if tt.errSubstr != "" {
+				if err == nil || !strings.Contains(err.Error(), tt.errSubstr) {
+					t.Fatalf("Expected error containing %q but got %v", tt.errSubstr, err)
+				}
+				return
+			}
+			if err != nil {
+				t.Fatalf("Unexpected error: %v", err)
+			}
+			if got != tt.expected {
+				t.Fatalf("Expected %q but got %q", tt.expected, got)
+			}
+		})
+	}
+}
@@ -0,0 +1,87 @@
+// Copyright 2025 Marc-Antoine Ruel. All rights reserved.
+// Use of this source code is governed under the Apache License, Version 2.0
+// that can be found in the LICENSE file.
+
+package genaitools
+
+import (
+	"context"
+	"encoding/json"
+	"strings"
+	"unicode"
+	"unicode/utf8"
+
+	"github.com/maruel/genai"
+)
+
+// TextStats counts the characters, words, lines and sentences of a text.
+//
+// Characters are Unicode code points. Words are separated by Unicode white
+// space. Sentences end with ".", "!" or "?" when not followed by a letter or
+// digit, so "3.14" doesn't end a sentence, but abbreviations like "e.g." do.
+// They also end with the CJK "。", "！" and "？". A trailing fragment without
+// punctuation counts as a sentence.
+var TextStats = genai.ToolDef{
+	Name:        "text_stats",
+	Description: "Counts the characters, bytes, words, lines and sentences of a text and returns them as JSON. Use it instead of counting yourself.",
+	Callback:    doTextStats,
+}
+
+type textStatsArgs struct {
+	Text string `json:"text" jsonschema:"description=Text to analyze"`
+}
+
+type textStatsResult struct {
+	Characters int `json:"characters"`
+	Bytes      int `json:"bytes"`
+	Words      int `json:"words"`
+	Lines      int `json:"lines"`
+	Sentences  int `json:"sentences"`
+}
+
+func doTextStats(ctx context.Context, args *textStatsArgs) (string, error) {
+	s := args.Text
+	res := textStatsResult{
+		Characters: utf8.RuneCountInString(s),
+		Bytes:      len(s),
+		Words:      len(strings.Fields(s)),
+		Sentences:  countSentences(s),
+	}
+	if s != "" {
+		res.Lines = strings.Count(s, "\n")
+		if !strings.HasSuffix(s, "\n") {
+			res.Lines++
+		}
+	}
+	b, err := json.Marshal(res)
+	return string(b), err
+}
+
+func countSentences(s string) int {
+	n := 0
+	content := false
+	for i, r := range s {
+		switch r {
+		case '.', '!', '?', '…':
+			next, _ := utf8.DecodeRuneInString(s[i+utf8.RuneLen(r):])
+			if content && !unicode.IsLetter(next) && !unicode.IsDigit(next) {
+				n++
+				content = false
+			}
+		case '。', '！', '？':
+			// CJK text has no space between sentences.
+			if content {
+				n++
+				content = false
+			}
+		default:
+			if unicode.IsLetter(r) || unicode.IsDigit(r) {
+				content = true
+			}
+		}
+	}
+	if content {
+		n++
+	}
+	return n
+}
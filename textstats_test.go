@@ -0,0 +1,41 @@
+// Copyright 2025 Marc-Antoine Ruel. All rights reserved.
+// Use of this source code is governed under the Apache License, Version 2.0
+// that can be found in the LICENSE file.
+
+package genaitools
+
+import (
+	"context"
+	"testing"
+)
+
+func TestTextStats(t *testing.T) {
+	callback := TextStats.Callback.(func(context.Context, *textStatsArgs) (string, error))
+	tests := []struct {
+		name     string
+		text     string
+		expected string
+	}{
+		{"empty", "", `{"characters":0,"bytes":0,"words":0,"lines":0,"sentences":0}`},
+		{"whitespace", " \n\t", `{"characters":3,"bytes":3,"words":0,"lines":2,"sentences":0}`},
+		{"sentences", "Hello world. How are you? Fine!", `{"characters":31,"bytes":31,"words":6,"lines":1,"sentences":3}`},
+		{"no_punctuation", "one sentence", `{"characters":12,"bytes":12,"words":2,"lines":1,"sentences":1}`},
+		{"ellipsis_and_numbers", "Pi is 3.14... Really?! Yes", `{"characters":26,"bytes":26,"words":5,"lines":1,"sentences":3}`},
+		{"multibyte", "Café déjà vu.\nÜber alles", `{"characters":24,"bytes":28,"words":5,"lines":2,"sentences":2}`},
+		{"cjk", "你好。世界！", `{"characters":6,"bytes":18,"words":1,"lines":1,"sentences":2}`},
+		{"unicode_space", "a b　c", `{"characters":5,"bytes":8,"words":3,"lines":1,"sentences":1}`},
+		{"trailing_newline", "a\nb\n", `{"characters":4,"bytes":4,"words":2,"lines":2,"sentences":1}`},
+		{"punctuation_only", "... ?!", `{"characters":6,"bytes":6,"words":2,"lines":1,"sentences":0}`},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := callback(t.Context(), &textStatsArgs{Text: tt.text})
+			if err != nil {
+				t.Fatalf("Unexpected error: %v", err)
+			}
+			if got != tt.expected {
+				t.Fatalf("Expected %q but got %q", tt.expected, got)
+			}
+		})
+	}
+}
@@ -0,0 +1,77 @@
+// Copyright 2025 Marc-Antoine Ruel. All rights reserved.
+// Use of this source code is governed under the Apache License, Version 2.0
+// that can be found in the LICENSE file.
+
+package genaitools
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	"github.com/maruel/genai"
+)
+
+// TimeAgo describes a timestamp relative to a reference time, e.g. "3 hours
+// ago" or "in 2 days".
+//
+// It uses the largest unit with a count of at least one, rounding down. A
+// month is 30 days and a year is 365 days. Differences under one second are
+// "now".
+var TimeAgo = genai.ToolDef{
+	Name:        "time_ago",
+	Description: "Describes a timestamp relative to now (or to a reference time) as a human friendly phrase like \"3 hours ago\" or \"in 2 days\".",
+	Callback: func(ctx context.Context, args *timeAgoArgs) (string, error) {
+		return doTimeAgo(args, time.Now())
+	},
+}
+
+type timeAgoArgs struct {
+	Timestamp string `json:"timestamp" jsonschema:"description=Timestamp in RFC3339 format or date as YYYY-MM-DD"`
+	Reference string `json:"reference,omitempty" jsonschema:"description=Reference time in RFC3339 format or date as YYYY-MM-DD. Defaults to now"`
+}
+
+var timeAgoUnits = []struct {
+	name string
+	d    time.Duration
+}{
+	{"year", 365 * 24 * time.Hour},
+	{"month", 30 * 24 * time.Hour},
+	{"week", 7 * 24 * time.Hour},
+	{"day", 24 * time.Hour},
+	{"hour", time.Hour},
+	{"minute", time.Minute},
+	{"second", time.Second},
+}
+
+func doTimeAgo(args *timeAgoArgs, now time.Time) (string, error) {
+	t, err := parseTimestamp("timestamp", args.Timestamp)
+	if err != nil {
+		return "", err
+	}
+	if args.Reference != "" {
+		if now, err = parseTimestamp("reference", args.Reference); err != nil {
+			return "", err
+		}
+	}
+	d := now.Sub(t)
+	future := d < 0
+	if future {
+		d = -d
+	}
+	for _, u := range timeAgoUnits {
+		n := int64(d / u.d)
+		if n < 1 {
+			continue
+		}
+		phrase := fmt.Sprintf("%d %s", n, u.name)
+		if n > 1 {
+			phrase += "s"
+		}
+		if future {
+			return "in " + phrase, nil
+		}
+		return phrase + " ago", nil
+	}
+	return "now", nil
+}
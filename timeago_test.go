@@ -0,0 +1,56 @@
+// Copyright 2025 Marc-Antoine Ruel. All rights reserved.
+// Use of this source code is governed under the Apache License, Version 2.0
+// that can be found in the LICENSE file.
+
+package genaitools
+
+import (
+	"strings"
+	"testing"
+	"time"
+)
+
+func TestTimeAgo(t *testing.T) {
+	now := time.Date(2025, 7, 4, 16, 30, 45, 0, time.UTC)
+	tests := []struct {
+		name      string
+		timestamp string
+		reference string
+		expected  string
+		errSubstr string
+	}{
+		{"now", "2025-07-04T16:30:45.4Z", "", "now", ""},
+		{"one_second", "2025-07-04T16:30:44Z", "", "1 second ago", ""},
+		{"seconds", "2025-07-04T16:30:00Z", "", "45 seconds ago", ""},
+		{"one_minute", "2025-07-04T16:29:00Z", "", "1 minute ago", ""},
+		{"hours", "2025-07-04T13:00:00Z", "", "3 hours ago", ""},
+		{"hours_timezone", "2025-07-04T09:30:45-04:00", "", "3 hours ago", ""},
+		{"days", "2025-07-01", "", "3 days ago", ""},
+		{"weeks", "2025-06-20T16:30:45Z", "", "2 weeks ago", ""},
+		{"months", "2025-05-01", "", "2 months ago", ""},
+		{"one_year", "2024-06-01", "", "1 year ago", ""},
+		{"future_minutes", "2025-07-04T16:35:45Z", "", "in 5 minutes", ""},
+		{"future_days", "2025-07-06T17:00:00Z", "", "in 2 days", ""},
+		{"future_years", "2030-01-01", "", "in 4 years", ""},
+		{"reference", "2020-01-01", "2020-01-02T12:00:00Z", "1 day ago", ""},
+		{"invalid_timestamp", "yesterday", "", "", `invalid timestamp "yesterday"; expected RFC3339 or YYYY-MM-DD`},
+		{"invalid_reference", "2020-01-01", "now", "", `invalid reference "now"`},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := doTimeAgo(&timeAgoArgs{Timestamp: tt.timestamp, Reference: tt.reference}, now)
+			if tt.errSubstr != "" {
+				if err == nil || !strings.Contains(err.Error(), tt.errSubstr) {
+					t.Fatalf("Expected error containing %q but got %v", tt.errSubstr, err)
+				}
+				return
+			}
+			if err != nil {
+				t.Fatalf("Unexpected error: %v", err)
+			}
+			if got != tt.expected {
+				t.Fatalf("Expected %q but got %q", tt.expected, got)
+			}
+		})
+	}
+}
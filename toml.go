@@ -0,0 +1,440 @@
+// Copyright 2025 Marc-Antoine Ruel. All rights reserved.
+// Use of this source code is governed under the Apache License, Version 2.0
+// that can be found in the LICENSE file.
+
+package genaitools
+
+import (
+	"errors"
+	"fmt"
+	"math"
+	"regexp"
+	"strconv"
+	"strings"
+	"unicode/utf8"
+)
+
+// parseTOML parses a TOML document into maps, slices, strings, bools,
+// int64 and float64 values.
+//
+// It supports what is found in practice in frontmatter: tables, arrays of
+// tables, dotted and quoted keys, basic and literal strings (including
+// multi-line), numbers, booleans, arrays and inline tables. Dates and times
+// are returned as strings.
+func parseTOML(s string) (map[string]any, error) {
+	p := tomlParser{s: s, line: 1}
+	root := map[string]any{}
+	cur := root
+	for {
+		p.skipBlank(true)
+		if p.eof() {
+			return root, nil
+		}
+		var err error
+		if p.s[p.pos] == '[' {
+			cur, err = p.parseHeader(root)
+		} else {
+			err = p.parseKeyValue(cur)
+			if err == nil {
+				err = p.endOfLine()
+			}
+		}
+		if err != nil {
+			return nil, fmt.Errorf("line %d: %w", p.line, err)
+		}
+	}
+}
+
+type tomlParser struct {
+	s    string
+	pos  int
+	line int
+}
+
+func (p *tomlParser) eof() bool {
+	return p.pos >= len(p.s)
+}
+
+// skipBlank skips spaces, tabs and comments, and newlines if newlines is true.
+func (p *tomlParser) skipBlank(newlines bool) {
+	for !p.eof() {
+		switch c := p.s[p.pos]; {
+		case c == ' ' || c == '\t' || c == '\r':
+			p.pos++
+		case c == '\n' && newlines:
+			p.pos++
+			p.line++
+		case c == '#':
+			for !p.eof() && p.s[p.pos] != '\n' {
+				p.pos++
+			}
+		default:
+			return
+		}
+	}
+}
+
+// endOfLine verifies nothing but a comment follows on the line.
+func (p *tomlParser) endOfLine() error {
+	p.skipBlank(false)
+	if p.eof() {
+		return nil
+	}
+	if p.s[p.pos] != '\n' {
+		return fmt.Errorf("unexpected %q after value", p.rest())
+	}
+	return nil
+}
+
+// rest returns the remainder of the line for error messages.
+func (p *tomlParser) rest() string {
+	r, _, _ := strings.Cut(p.s[p.pos:], "\n")
+	return r
+}
+
+// parseHeader parses [table] or [[array.of.tables]] and returns the table
+// that subsequent keys go into.
+func (p *tomlParser) parseHeader(root map[string]any) (map[string]any, error) {
+	array := strings.HasPrefix(p.s[p.pos:], "[[")
+	if array {
+		p.pos += 2
+	} else {
+		p.pos++
+	}
+	keys, err := p.parseKey()
+	if err != nil {
+		return nil, err
+	}
+	closing := "]"
+	if array {
+		closing = "]]"
+	}
+	if !strings.HasPrefix(p.s[p.pos:], closing) {
+		return nil, fmt.Errorf("expected %q to close the table header", closing)
+	}
+	p.pos += len(closing)
+	if err := p.endOfLine(); err != nil {
+		return nil, err
+	}
+	parent, err := tomlTable(root, keys[:len(keys)-1])
+	if err != nil {
+		return nil, err
+	}
+	last := keys[len(keys)-1]
+	if !array {
+		return tomlTable(parent, []string{last})
+	}
+	t := map[string]any{}
+	switch v := parent[last].(type) {
+	case nil:
+		parent[last] = []any{t}
+	case []any:
+		parent[last] = append(v, t)
+	default:
+		return nil, fmt.Errorf("key %q is already defined", last)
+	}
+	return t, nil
+}
+
+// tomlTable returns the table at keys below t, creating it as needed. When a
+// key is an array of tables, its last element is used.
+func tomlTable(t map[string]any, keys []string) (map[string]any, error) {
+	for _, k := range keys {
+		switch v := t[k].(type) {
+		case nil:
+			n := map[string]any{}
+			t[k] = n
+			t = n
+		case map[string]any:
+			t = v
+		case []any:
+			last, ok := v[len(v)-1].(map[string]any)
+			if !ok {
+				return nil, fmt.Errorf("key %q is not a table", k)
+			}
+			t = last
+		default:
+			return nil, fmt.Errorf("key %q is not a table", k)
+		}
+	}
+	return t, nil
+}
+
+// parseKey parses a dotted key made of bare and quoted parts.
+func (p *tomlParser) parseKey() ([]string, error) {
+	var keys []string
+	for {
+		p.skipBlank(false)
+		if p.eof() {
+			return nil, errors.New("expected a key")
+		}
+		var k string
+		switch p.s[p.pos] {
+		case '"', '\'':
+			v, err := p.parseString()
+			if err != nil {
+				return nil, err
+			}
+			k = v
+		default:
+			start := p.pos
+			for !p.eof() && isTOMLBareKey(p.s[p.pos]) {
+				p.pos++
+			}
+			if start == p.pos {
+				return nil, fmt.Errorf("invalid key at %q", p.rest())
+			}
+			k = p.s[start:p.pos]
+		}
+		keys = append(keys, k)
+		p.skipBlank(false)
+		if p.eof() || p.s[p.pos] != '.' {
+			return keys, nil
+		}
+		p.pos++
+	}
+}
+
+func isTOMLBareKey(c byte) bool {
+	return c == '_' || c == '-' || isDigit(c) || (c >= 'a' && c <= 'z') || (c >= 'A' && c <= 'Z')
+}
+
+// parseKeyValue parses key = value into t.
+func (p *tomlParser) parseKeyValue(t map[string]any) error {
+	keys, err := p.parseKey()
+	if err != nil {
+		return err
+	}
+	if p.eof() || p.s[p.pos] != '=' {
+		return fmt.Errorf("expected '=' after key %q", strings.Join(keys, "."))
+	}
+	p.pos++
+	p.skipBlank(false)
+	v, err := p.parseValue()
+	if err != nil {
+		return err
+	}
+	parent, err := tomlTable(t, keys[:len(keys)-1])
+	if err != nil {
+		return err
+	}
+	last := keys[len(keys)-1]
+	if _, ok := parent[last]; ok {
+		return fmt.Errorf("key %q is already defined", strings.Join(keys, "."))
+	}
+	parent[last] = v
+	return nil
+}
+
+var (
+	reTOMLInt   = regexp.MustCompile(`^[+-]?(0|[1-9](_?[0-9])*)$`)
+	reTOMLFloat = regexp.MustCompile(`^[+-]?(0|[1-9](_?[0-9])*)(\.[0-9](_?[0-9])*)?([eE][+-]?[0-9](_?[0-9])*)?$`)
+	reTOMLDate  = regexp.MustCompile(`^(\d{4}-\d{2}-\d{2}([Tt ]\d{2}:\d{2}(:\d{2}(\.\d+)?)?([Zz]|[+-]\d{2}:\d{2})?)?|\d{2}:\d{2}:\d{2}(\.\d+)?)$`)
+)
+
+func (p *tomlParser) parseValue() (any, error) {
+	if p.eof() {
+		return nil, errors.New("expected a value")
+	}
+	switch p.s[p.pos] {
+	case '"', '\'':
+		return p.parseString()
+	case '[':
+		return p.parseArray()
+	case '{':
+		return p.parseInlineTable()
+	}
+	start := p.pos
+	for !p.eof() && !strings.ContainsRune(",]}#\n", rune(p.s[p.pos])) {
+		p.pos++
+	}
+	tok := strings.TrimSpace(p.s[start:p.pos])
+	switch {
+	case tok == "true":
+		return true, nil
+	case tok == "false":
+		return false, nil
+	case reTOMLInt.MatchString(tok):
+		i, err := strconv.ParseInt(strings.ReplaceAll(tok, "_", ""), 10, 64)
+		if err != nil {
+			return nil, fmt.Errorf("invalid integer %q: %w", tok, err)
+		}
+		return i, nil
+	case strings.HasPrefix(tok, "0x"), strings.HasPrefix(tok, "0o"), strings.HasPrefix(tok, "0b"):
+		i, err := strconv.ParseInt(strings.ReplaceAll(tok, "_", ""), 0, 64)
+		if err != nil {
+			return nil, fmt.Errorf("invalid integer %q: %w", tok, err)
+		}
+		return i, nil
+	case reTOMLFloat.MatchString(tok):
+		f, err := strconv.ParseFloat(strings.ReplaceAll(tok, "_", ""), 64)
+		if err != nil || math.IsInf(f, 0) {
+			return nil, fmt.Errorf("invalid float %q", tok)
+		}
+		return f, nil
+	case reTOMLDate.MatchString(tok):
+		return tok, nil
+	case tok == "":
+		return nil, errors.New("expected a value")
+	default:
+		return nil, fmt.Errorf("invalid value %q", tok)
+	}
+}
+
+// parseString parses a basic, literal or multi-line string.
+func (p *tomlParser) parseString() (string, error) {
+	q := p.s[p.pos]
+	if triple := strings.Repeat(string(q), 3); strings.HasPrefix(p.s[p.pos:], triple) {
+		p.pos += 3
+		end := strings.Index(p.s[p.pos:], triple)
+		if end < 0 {
+			return "", errors.New("unterminated multi-line string")
+		}
+		raw := p.s[p.pos : p.pos+end]
+		p.line += strings.Count(raw, "\n")
+		p.pos += end + 3
+		// A newline immediately following the opening delimiter is trimmed.
+		raw = strings.TrimPrefix(strings.TrimPrefix(raw, "\r"), "\n")
+		if q == '\'' {
+			return raw, nil
+		}
+		return unescapeTOML(raw, true)
+	}
+	p.pos++
+	start := p.pos
+	for !p.eof() && p.s[p.pos] != q && p.s[p.pos] != '\n' {
+		if q == '"' && p.s[p.pos] == '\\' {
+			p.pos++
+		}
+		p.pos++
+	}
+	if p.eof() || p.s[p.pos] != q {
+		return "", errors.New("unterminated string")
+	}
+	raw := p.s[start:p.pos]
+	p.pos++
+	if q == '\'' {
+		return raw, nil
+	}
+	return unescapeTOML(raw, false)
+}
+
+// unescapeTOML processes the escapes of a basic string. In multi-line strings,
+// a backslash at the end of a line trims the following whitespace.
+func unescapeTOML(s string, multiline bool) (string, error) {
+	var b strings.Builder
+	for i := 0; i < len(s); i++ {
+		if s[i] != '\\' {
+			b.WriteByte(s[i])
+			continue
+		}
+		i++
+		if i == len(s) {
+			return "", errors.New("invalid escape at end of string")
+		}
+		switch c := s[i]; c {
+		case 'b':
+			b.WriteByte('\b')
+		case 't':
+			b.WriteByte('\t')
+		case 'n':
+			b.WriteByte('\n')
+		case 'f':
+			b.WriteByte('\f')
+		case 'r':
+			b.WriteByte('\r')
+		case '"', '\\':
+			b.WriteByte(c)
+		case 'u', 'U':
+			n := 4
+			if c == 'U' {
+				n = 8
+			}
+			if i+n >= len(s) {
+				return "", fmt.Errorf("invalid escape \\%c", c)
+			}
+			r, err := strconv.ParseUint(s[i+1:i+1+n], 16, 32)
+			if err != nil || !utf8.ValidRune(rune(r)) {
+				return "", fmt.Errorf("invalid escape \\%c%s", c, s[i+1:i+1+n])
+			}
+			b.WriteRune(rune(r))
+			i += n
+		case ' ', '\t', '\r', '\n':
+			if !multiline {
+				return "", fmt.Errorf("invalid escape \\%c", c)
+			}
+			j := i
+			for j < len(s) && strings.IndexByte(" \t\r\n", s[j]) >= 0 {
+				j++
+			}
+			if !strings.Contains(s[i:j], "\n") {
+				return "", errors.New("invalid line ending backslash")
+			}
+			i = j - 1
+		default:
+			return "", fmt.Errorf("invalid escape \\%c", c)
+		}
+	}
+	return b.String(), nil
+}
+
+// parseArray parses an array, which can span multiple lines.
+func (p *tomlParser) parseArray() ([]any, error) {
+	p.pos++
+	out := []any{}
+	for {
+		p.skipBlank(true)
+		if p.eof() {
+			return nil, errors.New("unterminated array")
+		}
+		if p.s[p.pos] == ']' {
+			p.pos++
+			return out, nil
+		}
+		v, err := p.parseValue()
+		if err != nil {
+			return nil, err
+		}
+		out = append(out, v)
+		p.skipBlank(true)
+		if p.eof() {
+			return nil, errors.New("unterminated array")
+		}
+		switch p.s[p.pos] {
+		case ',':
+			p.pos++
+		case ']':
+		default:
+			return nil, fmt.Errorf("expected ',' or ']' in array at %q", p.rest())
+		}
+	}
+}
+
+// parseInlineTable parses an inline table, which must fit on one line.
+func (p *tomlParser) parseInlineTable() (map[string]any, error) {
+	p.pos++
+	t := map[string]any{}
+	p.skipBlank(false)
+	if !p.eof() && p.s[p.pos] == '}' {
+		p.pos++
+		return t, nil
+	}
+	for {
+		if err := p.parseKeyValue(t); err != nil {
+			return nil, err
+		}
+		p.skipBlank(false)
+		if p.eof() {
+			return nil, errors.New("unterminated inline table")
+		}
+		switch p.s[p.pos] {
+		case ',':
+			p.pos++
+		case '}':
+			p.pos++
+			return t, nil
+		default:
+			return nil, fmt.Errorf("expected ',' or '}' in inline table at %q", p.rest())
+		}
+	}
+}
@@ -0,0 +1,59 @@
+// Copyright 2025 Marc-Antoine Ruel. All rights reserved.
+// Use of this source code is governed under the Apache License, Version 2.0
+// that can be found in the LICENSE file.
+
+package genaitools
+
+import (
+	"encoding/json"
+	"strings"
+	"testing"
+)
+
+func TestParseTOML(t *testing.T) {
+	tests := []struct {
+		name      string
+		in        string
+		expected  string
+		errSubstr string
+	}{
+		{"scalars", "a = 1\nb = -2.5e3\nc = true\nd = 'lit\\eral'\ne = \"x\\ty\\u00e9\"\nf = 1_000\ng = 0x1F", `{"a":1,"b":-2500,"c":true,"d":"lit\\eral","e":"x\tyé","f":1000,"g":31}`, ""},
+		{"dates", "a = 1979-05-27T07:32:00Z\nb = 1979-05-27 07:32:00\nc = 07:32:00", `{"a":"1979-05-27T07:32:00Z","b":"1979-05-27 07:32:00","c":"07:32:00"}`, ""},
+		{"keys", "a.b = 1\n\"quoted key\" = 2\nsite.\"x.y\" = 3", `{"a":{"b":1},"quoted key":2,"site":{"x.y":3}}`, ""},
+		{"tables", "[server]\nhost = \"h\"\n[server.tls]\non = true\n[other]\nx = 1", `{"other":{"x":1},"server":{"host":"h","tls":{"on":true}}}`, ""},
+		{"array_of_tables", "[[p]]\nn = 1\n[[p]]\nn = 2\n[p.sub]\nx = 3", `{"p":[{"n":1},{"n":2,"sub":{"x":3}}]}`, ""},
+		{"arrays", "a = [1, 2,\n  3, # comment\n]\nb = [[\"x\"], []]", `{"a":[1,2,3],"b":[["x"],[]]}`, ""},
+		{"inline_table", "a = { x = 1, y.z = \"w\" }\nb = {}", `{"a":{"x":1,"y":{"z":"w"}},"b":{}}`, ""},
+		{"multiline", "a = \"\"\"\nline1\nline2 \\\n   continued\"\"\"\nb = '''\nraw\\n'''", `{"a":"line1\nline2 continued","b":"raw\\n"}`, ""},
+		{"duplicate", "a = 1\na = 2", "", `line 2: key "a" is already defined`},
+		{"missing_equal", "a 1", "", `expected '=' after key "a"`},
+		{"trailing", "a = 1 2", "", "invalid value \"1 2\""},
+		{"unterminated_string", "a = \"x", "", "unterminated string"},
+		{"unterminated_array", "a = [1, 2", "", "unterminated array"},
+		{"bad_escape", "a = \"\\q\"", "", `invalid escape \q`},
+		{"short_unicode", "a = \"\\u12\"", "", `invalid escape \u`},
+		{"table_conflict", "a = 1\n[a]", "", `key "a" is not a table`},
+		{"bad_header", "[a\nb = 1", "", `expected "]" to close the table header`},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := parseTOML(tt.in)
+			if tt.errSubstr != "" {
+				if err == nil || !strings.Contains(err.Error(), tt.errSubstr) {
+					t.Fatalf("Expected error containing %q but got %v", tt.errSubstr, err)
+				}
+				return
+			}
+			if err != nil {
+				t.Fatalf("Unexpected error: %v", err)
+			}
+			b, err := json.Marshal(got)
+			if err != nil {
+				t.Fatal(err)
+			}
+			if string(b) != tt.expected {
+				t.Fatalf("Expected %s\ngot      %s", tt.expected, b)
+			}
+		})
+	}
+}
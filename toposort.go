@@ -0,0 +1,121 @@
+// Copyright 2025 Marc-Antoine Ruel. All rights reserved.
+// Use of this source code is governed under the Apache License, Version 2.0
+// that can be found in the LICENSE file.
+
+package genaitools
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"maps"
+	"slices"
+	"strings"
+
+	"github.com/maruel/genai"
+)
+
+// TopoSort returns a topological ordering of items given their dependencies,
+// using Kahn's algorithm.
+//
+// Dependencies come before the items depending on them. Among items whose
+// dependencies are satisfied, the order of the items list is kept, then the
+// alphabetical order, so the result is deterministic. A cycle is reported as
+// an error listing its path.
+var TopoSort = genai.ToolDef{
+	Name:        "topo_sort",
+	Description: "Orders items so each one comes after all its dependencies (topological sort) and returns the order as a JSON array, or reports a dependency cycle.",
+	Callback:    doTopoSort,
+}
+
+type topoSortArgs struct {
+	Items        []string            `json:"items,omitempty" jsonschema:"description=Items to order. Items only referenced in dependencies are added automatically"`
+	Dependencies map[string][]string `json:"dependencies" jsonschema:"description=Map of an item to the list of items it depends on"`
+}
+
+func doTopoSort(ctx context.Context, args *topoSortArgs) (string, error) {
+	// rank is the position used to break ties.
+	rank := map[string]int{}
+	var nodes []string
+	add := func(n string) {
+		if _, ok := rank[n]; !ok {
+			rank[n] = len(nodes)
+			nodes = append(nodes, n)
+		}
+	}
+	for _, n := range args.Items {
+		add(n)
+	}
+	keys := slices.Sorted(maps.Keys(args.Dependencies))
+	for _, n := range keys {
+		add(n)
+	}
+	for _, n := range keys {
+		for _, d := range slices.Sorted(slices.Values(args.Dependencies[n])) {
+			add(d)
+		}
+	}
+	// dependents is the reverse of the dependencies.
+	dependents := map[string][]string{}
+	pending := map[string]int{}
+	for _, n := range keys {
+		deps := slices.Compact(slices.Sorted(slices.Values(args.Dependencies[n])))
+		pending[n] = len(deps)
+		for _, d := range deps {
+			dependents[d] = append(dependents[d], n)
+		}
+	}
+	var ready []string
+	for _, n := range nodes {
+		if pending[n] == 0 {
+			ready = append(ready, n)
+		}
+	}
+	order := make([]string, 0, len(nodes))
+	for len(ready) != 0 {
+		n := ready[0]
+		ready = ready[1:]
+		order = append(order, n)
+		for _, m := range dependents[n] {
+			if pending[m]--; pending[m] == 0 {
+				// Keep ready sorted by rank.
+				i, _ := slices.BinarySearchFunc(ready, rank[m], func(s string, r int) int { return rank[s] - r })
+				ready = slices.Insert(ready, i, m)
+			}
+		}
+	}
+	if len(order) != len(nodes) {
+		return "", fmt.Errorf("cycle detected: %s", strings.Join(findCycle(nodes, pending, args.Dependencies), " -> "))
+	}
+	b, err := json.Marshal(order)
+	return string(b), err
+}
+
+// findCycle returns a cycle among the nodes left with pending dependencies,
+// with the first node repeated at the end.
+func findCycle(nodes []string, pending map[string]int, deps map[string][]string) []string {
+	// Every node left has at least one dependency left, so following them
+	// always ends up in a cycle.
+	start := ""
+	for _, n := range nodes {
+		if pending[n] != 0 {
+			start = n
+			break
+		}
+	}
+	seen := map[string]int{}
+	var path []string
+	for n := start; ; {
+		if i, ok := seen[n]; ok {
+			return append(path[i:], n)
+		}
+		seen[n] = len(path)
+		path = append(path, n)
+		for _, d := range slices.Sorted(slices.Values(deps[n])) {
+			if pending[d] != 0 {
+				n = d
+				break
+			}
+		}
+	}
+}
@@ -0,0 +1,65 @@
+// Copyright 2025 Marc-Antoine Ruel. All rights reserved.
+// Use of this source code is governed under the Apache License, Version 2.0
+// that can be found in the LICENSE file.
+
+package genaitools
+
+import (
+	"context"
+	"strings"
+	"testing"
+)
+
+func TestTopoSort(t *testing.T) {
+	callback := TopoSort.Callback.(func(context.Context, *topoSortArgs) (string, error))
+	tests := []struct {
+		name      string
+		items     []string
+		deps      map[string][]string
+		expected  string
+		errSubstr string
+	}{
+		{
+			"build",
+			nil,
+			map[string][]string{"app": {"lib", "config"}, "lib": {"base"}, "config": {"base"}, "test": {"app"}},
+			`["base","config","lib","app","test"]`,
+			"",
+		},
+		{
+			"items_order",
+			[]string{"z", "y", "x"},
+			map[string][]string{"x": {"y"}},
+			`["z","y","x"]`,
+			"",
+		},
+		{"no_deps", []string{"b", "a"}, nil, `["b","a"]`, ""},
+		{"duplicate_dep", nil, map[string][]string{"a": {"b", "b"}}, `["b","a"]`, ""},
+		{"empty", nil, nil, `[]`, ""},
+		{
+			"cycle",
+			nil,
+			map[string][]string{"a": {"b"}, "b": {"c"}, "c": {"a"}, "d": {"a"}, "e": nil},
+			"",
+			"cycle detected: a -> b -> c -> a",
+		},
+		{"self", nil, map[string][]string{"a": {"a"}}, "", "cycle detected: a -> a"},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := callback(t.Context(), &topoSortArgs{Items: tt.items, Dependencies: tt.deps})
+			if tt.errSubstr != "" {
+				if err == nil || !strings.Contains(err.Error(), tt.errSubstr) {
+					t.Fatalf("Expected error containing %q but got %v", tt.errSubstr, err)
+				}
+				return
+			}
+			if err != nil {
+				t.Fatalf("Unexpected error: %v", err)
+			}
+			if got != tt.expected {
+				t.Fatalf("Expected %s but got %s", tt.expected, got)
+			}
+		})
+	}
+}
@@ -0,0 +1,155 @@
+// Copyright 2025 Marc-Antoine Ruel. All rights reserved.
+// Use of this source code is governed under the Apache License, Version 2.0
+// that can be found in the LICENSE file.
+
+package genaitools
+
+import (
+	"context"
+	"crypto/hmac"
+	"crypto/sha1" //nolint:gosec // RFC 6238 defaults to HMAC-SHA1.
+	"crypto/sha256"
+	"crypto/sha512"
+	"crypto/subtle"
+	"encoding/base32"
+	"encoding/binary"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"hash"
+	"strings"
+	"time"
+
+	"github.com/maruel/genai"
+)
+
+// TOTP generates or verifies a time-based one-time password as defined in RFC
+// 6238.
+//
+// The secret is never included in the result or in errors.
+var TOTP = genai.ToolDef{
+	Name:        "totp",
+	Description: "Generates the current time-based one-time password (RFC 6238) for a base32 secret with its remaining validity in seconds, or verifies a code.",
+	Callback: func(ctx context.Context, args *totpArgs) (string, error) {
+		return doTOTP(args, time.Now())
+	},
+}
+
+type totpArgs struct {
+	Operation string `json:"operation" jsonschema:"enum=generate,enum=verify"`
+	Secret    string `json:"secret" jsonschema:"description=Shared secret encoded as base32"`
+	Code      string `json:"code,omitempty" jsonschema:"description=Code to verify. Only used with verify"`
+	Window    *int   `json:"window,omitempty" jsonschema:"description=Number of time steps before and after the current one to accept when verifying. 0 only accepts the current code. Defaults to 1"`
+	Digits    int    `json:"digits,omitempty" jsonschema:"description=Number of digits between 6 and 8. Defaults to 6"`
+	Period    int    `json:"period,omitempty" jsonschema:"description=Time step in seconds. Defaults to 30"`
+	Algorithm string `json:"algorithm,omitempty" jsonschema:"enum=sha1,enum=sha256,enum=sha512"`
+}
+
+type totpGenerateResult struct {
+	Code             string `json:"code"`
+	RemainingSeconds int    `json:"remaining_seconds"`
+}
+
+type totpVerifyResult struct {
+	Valid bool `json:"valid"`
+	// Offset is the number of time steps between the matching code and now.
+	Offset int `json:"offset,omitempty"`
+}
+
+func doTOTP(args *totpArgs, now time.Time) (string, error) {
+	key, err := decodeTOTPSecret(args.Secret)
+	if err != nil {
+		return "", err
+	}
+	digits := args.Digits
+	if digits == 0 {
+		digits = 6
+	}
+	if digits < 6 || digits > 8 {
+		return "", fmt.Errorf("invalid digits %d; must be between 6 and 8", digits)
+	}
+	period := args.Period
+	if period == 0 {
+		period = 30
+	}
+	if period < 1 {
+		return "", fmt.Errorf("invalid period %d", period)
+	}
+	var h func() hash.Hash
+	switch args.Algorithm {
+	case "", "sha1":
+		h = sha1.New
+	case "sha256":
+		h = sha256.New
+	case "sha512":
+		h = sha512.New
+	default:
+		return "", fmt.Errorf("unknown algorithm %q; supported algorithms are sha1, sha256 and sha512", args.Algorithm)
+	}
+	counter := now.Unix() / int64(period)
+	var res any
+	switch args.Operation {
+	case "generate":
+		res = &totpGenerateResult{
+			Code:             hotp(h, key, uint64(counter), digits),
+			RemainingSeconds: period - int(now.Unix()%int64(period)),
+		}
+	case "verify":
+		window := 1
+		if args.Window != nil {
+			window = *args.Window
+		}
+		if window < 0 || window > 10 {
+			return "", fmt.Errorf("invalid window %d; must be between 0 and 10", window)
+		}
+		if len(args.Code) != digits {
+			return "", fmt.Errorf("code must have %d digits", digits)
+		}
+		r := &totpVerifyResult{}
+		for i := -window; i <= window; i++ {
+			want := hotp(h, key, uint64(counter+int64(i)), digits)
+			if subtle.ConstantTimeCompare([]byte(want), []byte(args.Code)) == 1 {
+				r.Valid = true
+				r.Offset = i
+				break
+			}
+		}
+		res = r
+	default:
+		return "", fmt.Errorf("unknown operation %q; supported operations are generate and verify", args.Operation)
+	}
+	b, err := json.Marshal(res)
+	return string(b), err
+}
+
+// decodeTOTPSecret decodes a base32 secret, tolerating spaces, lowercase and
+// missing padding as commonly found in authenticator setup keys.
+func decodeTOTPSecret(s string) ([]byte, error) {
+	s = strings.ToUpper(strings.ReplaceAll(strings.ReplaceAll(s, " ", ""), "-", ""))
+	s = strings.TrimRight(s, "=")
+	if s == "" {
+		return nil, errors.New("secret is required")
+	}
+	key, err := base32.StdEncoding.WithPadding(base32.NoPadding).DecodeString(s)
+	if err != nil {
+		// Do not wrap the error since it may contain part of the secret.
+		return nil, errors.New("secret is not valid base32")
+	}
+	return key, nil
+}
+
+// hotp implements RFC 4226.
+func hotp(h func() hash.Hash, key []byte, counter uint64, digits int) string {
+	mac := hmac.New(h, key)
+	var msg [8]byte
+	binary.BigEndian.PutUint64(msg[:], counter)
+	mac.Write(msg[:])
+	sum := mac.Sum(nil)
+	offset := sum[len(sum)-1] & 0xf
+	v := binary.BigEndian.Uint32(sum[offset:offset+4]) & 0x7fffffff
+	mod := uint32(1)
+	for range digits {
+		mod *= 10
+	}
+	return fmt.Sprintf("%0*d", digits, v%mod)
+}
@@ -0,0 +1,116 @@
+// Copyright 2025 Marc-Antoine Ruel. All rights reserved.
+// Use of this source code is governed under the Apache License, Version 2.0
+// that can be found in the LICENSE file.
+
+package genaitools
+
+import (
+	"context"
+	"encoding/base32"
+	"encoding/json"
+	"strings"
+	"testing"
+	"time"
+)
+
+func TestTOTP(t *testing.T) {
+	// Test vectors from RFC 6238 Appendix B.
+	seed20 := base32.StdEncoding.EncodeToString([]byte("12345678901234567890"))
+	seed32 := base32.StdEncoding.EncodeToString([]byte("12345678901234567890123456789012"))
+	seed64 := base32.StdEncoding.EncodeToString([]byte("1234567890123456789012345678901234567890123456789012345678901234"))
+	t.Run("rfc6238", func(t *testing.T) {
+		tests := []struct {
+			unix   int64
+			secret string
+			algo   string
+			want   string
+		}{
+			{59, seed20, "sha1", "94287082"},
+			{59, seed32, "sha256", "46119246"},
+			{59, seed64, "sha512", "90693936"},
+			{1111111109, seed20, "sha1", "07081804"},
+			{1234567890, seed20, "", "89005924"},
+			{2000000000, seed32, "sha256", "90698825"},
+			{20000000000, seed64, "sha512", "47863826"},
+		}
+		for _, tt := range tests {
+			got, err := doTOTP(&totpArgs{Operation: "generate", Secret: tt.secret, Digits: 8, Algorithm: tt.algo}, time.Unix(tt.unix, 0))
+			if err != nil {
+				t.Fatal(err)
+			}
+			res := totpGenerateResult{}
+			if err := json.Unmarshal([]byte(got), &res); err != nil {
+				t.Fatal(err)
+			}
+			if res.Code != tt.want {
+				t.Fatalf("%d %s: want %q, got %q", tt.unix, tt.algo, tt.want, res.Code)
+			}
+			if want := 30 - int(tt.unix%30); res.RemainingSeconds != want {
+				t.Fatalf("remaining: want %d, got %d", want, res.RemainingSeconds)
+			}
+		}
+	})
+	t.Run("verify", func(t *testing.T) {
+		now := time.Unix(1111111109, 0)
+		zero, two := 0, 2
+		for _, tt := range []struct {
+			code   string
+			when   time.Time
+			window *int
+			want   string
+		}{
+			{"07081804", now, nil, `{"valid":true}`},
+			{"07081804", now.Add(30 * time.Second), nil, `{"valid":true,"offset":-1}`},
+			{"07081804", now.Add(30 * time.Second), &zero, `{"valid":false}`},
+			{"07081804", now.Add(90 * time.Second), nil, `{"valid":false}`},
+			{"07081804", now.Add(60 * time.Second), &two, `{"valid":true,"offset":-2}`},
+			{"00000000", now, nil, `{"valid":false}`},
+		} {
+			got, err := doTOTP(&totpArgs{Operation: "verify", Secret: seed20, Code: tt.code, Digits: 8, Window: tt.window}, tt.when)
+			if err != nil {
+				t.Fatal(err)
+			}
+			if got != tt.want {
+				t.Fatalf("want %s, got %s", tt.want, got)
+			}
+		}
+	})
+	t.Run("callback", func(t *testing.T) {
+		callback := TOTP.Callback.(func(context.Context, *totpArgs) (string, error))
+		// Lowercase with spaces and no padding, as shown by most services.
+		got, err := callback(t.Context(), &totpArgs{Operation: "generate", Secret: "gezd gnbv gy3t qojq"})
+		if err != nil {
+			t.Fatal(err)
+		}
+		res := totpGenerateResult{}
+		if err := json.Unmarshal([]byte(got), &res); err != nil {
+			t.Fatal(err)
+		}
+		if len(res.Code) != 6 || res.RemainingSeconds < 1 || res.RemainingSeconds > 30 {
+			t.Fatalf("unexpected result %s", got)
+		}
+	})
+	t.Run("errors", func(t *testing.T) {
+		negative := -1
+		for _, tt := range []struct {
+			args      totpArgs
+			errSubstr string
+		}{
+			{totpArgs{Operation: "generate"}, "secret is required"},
+			{totpArgs{Operation: "generate", Secret: "not!base32"}, "not valid base32"},
+			{totpArgs{Operation: "generate", Secret: seed20, Digits: 4}, "invalid digits"},
+			{totpArgs{Operation: "generate", Secret: seed20, Algorithm: "md5"}, "unknown algorithm"},
+			{totpArgs{Operation: "verify", Secret: seed20, Code: "123"}, "must have 6 digits"},
+			{totpArgs{Operation: "verify", Secret: seed20, Code: "123456", Window: &negative}, "invalid window -1"},
+			{totpArgs{Operation: "list", Secret: seed20}, "unknown operation"},
+		} {
+			_, err := doTOTP(&tt.args, time.Unix(59, 0))
+			if err == nil || !strings.Contains(err.Error(), tt.errSubstr) {
+				t.Fatalf("expected error containing %q, got %v", tt.errSubstr, err)
+			}
+			if tt.args.Secret != "" && strings.Contains(err.Error(), tt.args.Secret) {
+				t.Fatalf("error leaked the secret: %v", err)
+			}
+		}
+	})
+}
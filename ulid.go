@@ -0,0 +1,135 @@
+// Copyright 2025 Marc-Antoine Ruel. All rights reserved.
+// Use of this source code is governed under the Apache License, Version 2.0
+// that can be found in the LICENSE file.
+
+package genaitools
+
+import (
+	"context"
+	"crypto/rand"
+	"encoding/json"
+	"fmt"
+	"strings"
+	"time"
+
+	"github.com/maruel/genai"
+)
+
+// ULID generates ULIDs or parses one to extract its timestamp.
+//
+// A ULID is 128 bits: a 48 bits big endian Unix timestamp in milliseconds
+// followed by 80 bits of randomness from crypto/rand. It is encoded as 26
+// characters of Crockford's base32 (0-9 A-Z without I, L, O and U), so ULIDs
+// sort lexicographically by time.
+var ULID = genai.ToolDef{
+	Name:        "ulid",
+	Description: "Generates time-sortable ULIDs (one per line) or parses a ULID to return its embedded timestamp as JSON.",
+	Callback: func(ctx context.Context, args *ulidArgs) (string, error) {
+		return doULID(args, time.Now())
+	},
+}
+
+type ulidArgs struct {
+	Operation string `json:"operation" jsonschema:"enum=generate,enum=parse"`
+	Count     int    `json:"count,omitempty" jsonschema:"description=Number of ULIDs to generate. Defaults to 1,minimum=1,maximum=100"`
+	Value     string `json:"value,omitempty" jsonschema:"description=ULID to parse"`
+}
+
+type ulidParseResult struct {
+	Timestamp string `json:"timestamp"`
+	UnixMilli int64  `json:"unix_ms"`
+}
+
+const crockford = "0123456789ABCDEFGHJKMNPQRSTVWXYZ"
+
+func doULID(args *ulidArgs, now time.Time) (string, error) {
+	switch args.Operation {
+	case "generate":
+		n := args.Count
+		if n == 0 {
+			n = 1
+		}
+		if n < 1 || n > 100 {
+			return "", fmt.Errorf("invalid count %d; must be between 1 and 100", args.Count)
+		}
+		out := make([]string, n)
+		for i := range out {
+			var b [16]byte
+			ms := uint64(now.UnixMilli())
+			for j := range 6 {
+				b[j] = byte(ms >> (40 - 8*j))
+			}
+			_, _ = rand.Read(b[6:])
+			out[i] = encodeULID(b)
+		}
+		return strings.Join(out, "\n"), nil
+	case "parse":
+		b, err := decodeULID(args.Value)
+		if err != nil {
+			return "", err
+		}
+		ms := int64(0)
+		for j := range 6 {
+			ms = ms<<8 | int64(b[j])
+		}
+		res := ulidParseResult{Timestamp: time.UnixMilli(ms).UTC().Format("2006-01-02T15:04:05.000Z07:00"), UnixMilli: ms}
+		out, err := json.Marshal(res)
+		return string(out), err
+	default:
+		return "", fmt.Errorf("unknown operation %q; must be generate or parse", args.Operation)
+	}
+}
+
+// encodeULID encodes 128 bits as 26 base32 characters; the first character
+// only holds 3 bits.
+func encodeULID(b [16]byte) string {
+	var out [26]byte
+	// Process from the least significant bits.
+	for i := 25; i >= 0; i-- {
+		out[i] = crockford[b[15]&31]
+		// Shift the 128 bits right by 5.
+		for j := 15; j >= 0; j-- {
+			b[j] >>= 5
+			if j > 0 {
+				b[j] |= b[j-1] << 3
+			}
+		}
+	}
+	return string(out[:])
+}
+
+func decodeULID(s string) ([16]byte, error) {
+	var b [16]byte
+	if len(s) != 26 {
+		return b, fmt.Errorf("invalid ULID %q; must be 26 characters", s)
+	}
+	for i := range len(s) {
+		c := s[i]
+		if c >= 'a' && c <= 'z' {
+			c -= 'a' - 'A'
+		}
+		// Crockford's base32 aliases.
+		switch c {
+		case 'O':
+			c = '0'
+		case 'I', 'L':
+			c = '1'
+		}
+		v := strings.IndexByte(crockford, c)
+		if v < 0 {
+			return b, fmt.Errorf("invalid ULID %q; unexpected character %q", s, s[i])
+		}
+		if i == 0 && v > 7 {
+			return b, fmt.Errorf("invalid ULID %q; it overflows 128 bits", s)
+		}
+		// Shift the 128 bits left by 5 and add v.
+		for j := range 16 {
+			b[j] <<= 5
+			if j < 15 {
+				b[j] |= b[j+1] >> 3
+			}
+		}
+		b[15] |= byte(v)
+	}
+	return b, nil
+}
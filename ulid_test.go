@@ -0,0 +1,70 @@
+// Copyright 2025 Marc-Antoine Ruel. All rights reserved.
+// Use of this source code is governed under the Apache License, Version 2.0
+// that can be found in the LICENSE file.
+
+package genaitools
+
+import (
+	"strings"
+	"testing"
+	"time"
+)
+
+func TestULID(t *testing.T) {
+	now := time.Date(2016, 7, 30, 23, 54, 10, 259e6, time.UTC)
+	got, err := doULID(&ulidArgs{Operation: "generate", Count: 3}, now)
+	if err != nil {
+		t.Fatal(err)
+	}
+	ids := strings.Split(got, "\n")
+	if len(ids) != 3 || ids[0] == ids[1] {
+		t.Fatalf("Expected 3 unique ULIDs but got %q", got)
+	}
+	for _, id := range ids {
+		// Timestamp 1469922850259 from the ULID specification.
+		if len(id) != 26 || !strings.HasPrefix(id, "01ARZ3NDEK") {
+			t.Fatalf("Unexpected ULID %q", id)
+		}
+		p, err := doULID(&ulidArgs{Operation: "parse", Value: id}, now)
+		if err != nil {
+			t.Fatal(err)
+		}
+		if want := `{"timestamp":"2016-07-30T23:54:10.259Z","unix_ms":1469922850259}`; p != want {
+			t.Fatalf("Expected %s but got %s", want, p)
+		}
+	}
+	b, err := decodeULID("7ZZZZZZZZZZZZZZZZZZZZZZZZZ")
+	if err != nil {
+		t.Fatal(err)
+	}
+	if got := encodeULID(b); got != "7ZZZZZZZZZZZZZZZZZZZZZZZZZ" {
+		t.Fatalf("Expected round trip but got %q", got)
+	}
+	tests := []struct {
+		args      ulidArgs
+		expected  string
+		errSubstr string
+	}{
+		{ulidArgs{Operation: "parse", Value: "01arz3ndektsv4rrffq69g5fav"}, `{"timestamp":"2016-07-30T23:54:10.259Z","unix_ms":1469922850259}`, ""},
+		{ulidArgs{Operation: "parse", Value: "01ARZ3NDEK"}, "", "must be 26 characters"},
+		{ulidArgs{Operation: "parse", Value: "01ARZ3NDEKTSV4RRFFQ69G5FAU"}, "", "unexpected character 'U'"},
+		{ulidArgs{Operation: "parse", Value: "81ARZ3NDEKTSV4RRFFQ69G5FAV"}, "", "overflows"},
+		{ulidArgs{Operation: "generate", Count: 101}, "", "invalid count"},
+		{ulidArgs{Operation: "hash"}, "", "unknown operation"},
+	}
+	for _, tt := range tests {
+		got, err := doULID(&tt.args, now)
+		if tt.errSubstr != "" {
+			if err == nil || !strings.Contains(err.Error(), tt.errSubstr) {
+				t.Fatalf("Expected error containing %q but got %v", tt.errSubstr, err)
+			}
+			continue
+		}
+		if err != nil {
+			t.Fatalf("Unexpected error: %v", err)
+		}
+		if got != tt.expected {
+			t.Fatalf("Expected %s but got %s", tt.expected, got)
+		}
+	}
+}
@@ -0,0 +1,164 @@
+// Copyright 2025 Marc-Antoine Ruel. All rights reserved.
+// Use of this source code is governed under the Apache License, Version 2.0
+// that can be found in the LICENSE file.
+
+package genaitools
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"maps"
+	"regexp"
+	"slices"
+	"strconv"
+	"strings"
+	"unicode"
+	"unicode/utf16"
+	"unicode/utf8"
+
+	"github.com/maruel/genai"
+	"golang.org/x/text/unicode/runenames"
+)
+
+// maxUnicodeInfoRunes is the maximum number of runes described by
+// UnicodeInfo.
+const maxUnicodeInfoRunes = 64
+
+// UnicodeInfo describes each rune of a text: code point, name, general
+// category, script and its UTF-8 and UTF-16 encodings.
+//
+// A multi-rune character, like an emoji with a skin tone modifier or a letter
+// with a combining accent, is described rune by rune. A code point can also be
+// given in the U+XXXX notation.
+var UnicodeInfo = genai.ToolDef{
+	Name:        "unicode_info",
+	Description: "Describes each Unicode code point of a character or short text: code point, name, general category, script and UTF-8 and UTF-16 encodings, as JSON. Accepts U+XXXX notation.",
+	Callback:    doUnicodeInfo,
+}
+
+type unicodeInfoArgs struct {
+	Char string `json:"char" jsonschema:"description=Character or short text like € or 👍🏽\\, or a code point like U+20AC"`
+}
+
+type unicodeInfoResult struct {
+	Char         string `json:"char"`
+	CodePoint    string `json:"code_point"`
+	Name         string `json:"name,omitempty"`
+	Decimal      int    `json:"decimal"`
+	Category     string `json:"category"`
+	CategoryName string `json:"category_name"`
+	Script       string `json:"script"`
+	UTF8         string `json:"utf8"`
+	UTF16        string `json:"utf16"`
+}
+
+// unicodeCategoryNames are the Unicode general categories.
+var unicodeCategoryNames = map[string]string{
+	"Cc": "control",
+	"Cf": "format",
+	"Co": "private use",
+	"Cs": "surrogate",
+	"Ll": "lowercase letter",
+	"Lm": "modifier letter",
+	"Lo": "other letter",
+	"Lt": "titlecase letter",
+	"Lu": "uppercase letter",
+	"Mc": "spacing mark",
+	"Me": "enclosing mark",
+	"Mn": "nonspacing mark",
+	"Nd": "decimal number",
+	"Nl": "letter number",
+	"No": "other number",
+	"Pc": "connector punctuation",
+	"Pd": "dash punctuation",
+	"Pe": "close punctuation",
+	"Pf": "final punctuation",
+	"Pi": "initial punctuation",
+	"Po": "other punctuation",
+	"Ps": "open punctuation",
+	"Sc": "currency symbol",
+	"Sk": "modifier symbol",
+	"Sm": "math symbol",
+	"So": "other symbol",
+	"Zl": "line separator",
+	"Zp": "paragraph separator",
+	"Zs": "space separator",
+}
+
+var (
+	unicodeCategories = slices.Sorted(maps.Keys(unicodeCategoryNames))
+	unicodeScripts    = slices.Sorted(maps.Keys(unicode.Scripts))
+	reCodePoint       = regexp.MustCompile(`^(?i)U\+([0-9A-F]{1,6})$`)
+)
+
+func doUnicodeInfo(ctx context.Context, args *unicodeInfoArgs) (string, error) {
+	s := args.Char
+	if m := reCodePoint.FindStringSubmatch(strings.TrimSpace(s)); m != nil {
+		v, _ := strconv.ParseUint(m[1], 16, 32)
+		r := rune(v)
+		if r > unicode.MaxRune || (r >= 0xD800 && r <= 0xDFFF) {
+			return "", fmt.Errorf("invalid code point %s", m[0])
+		}
+		s = string(r)
+	}
+	if s == "" {
+		return "", errors.New("missing char")
+	}
+	if n := utf8.RuneCountInString(s); n > maxUnicodeInfoRunes {
+		return "", fmt.Errorf("text has %d code points; the maximum is %d", n, maxUnicodeInfoRunes)
+	}
+	var out []unicodeInfoResult
+	for _, r := range s {
+		res := unicodeInfoResult{
+			Char:      string(r),
+			CodePoint: fmt.Sprintf("U+%04X", r),
+			Name:      unicodeName(r),
+			Decimal:   int(r),
+			Category:  "Cn",
+			Script:    "Unknown",
+		}
+		for _, c := range unicodeCategories {
+			if unicode.Is(unicode.Categories[c], r) {
+				res.Category = c
+				break
+			}
+		}
+		res.CategoryName = unicodeCategoryNames[res.Category]
+		if res.Category == "Cn" {
+			res.CategoryName = "unassigned"
+		}
+		for _, name := range unicodeScripts {
+			if unicode.Is(unicode.Scripts[name], r) {
+				res.Script = name
+				break
+			}
+		}
+		b := []byte(string(r))
+		hex := make([]string, len(b))
+		for i, c := range b {
+			hex[i] = fmt.Sprintf("%02X", c)
+		}
+		res.UTF8 = strings.Join(hex, " ")
+		units := utf16.Encode([]rune{r})
+		hex = make([]string, len(units))
+		for i, u := range units {
+			hex[i] = fmt.Sprintf("%04X", u)
+		}
+		res.UTF16 = strings.Join(hex, " ")
+		out = append(out, res)
+	}
+	return marshalJSON(out)
+}
+
+// unicodeName returns the character name of r, or a label like "<control>"
+// for code points without a name.
+func unicodeName(r rune) string {
+	name := runenames.Name(r)
+	if strings.HasPrefix(name, "<CJK Ideograph") {
+		// runenames returns the label of the range; the name is derived from
+		// the code point.
+		return fmt.Sprintf("CJK UNIFIED IDEOGRAPH-%04X", r)
+	}
+	return name
+}
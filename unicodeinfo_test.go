@@ -0,0 +1,53 @@
+// Copyright 2025 Marc-Antoine Ruel. All rights reserved.
+// Use of this source code is governed under the Apache License, Version 2.0
+// that can be found in the LICENSE file.
+
+package genaitools
+
+import (
+	"context"
+	"strings"
+	"testing"
+)
+
+func TestUnicodeInfo(t *testing.T) {
+	callback := UnicodeInfo.Callback.(func(context.Context, *unicodeInfoArgs) (string, error))
+	tests := []struct {
+		name      string
+		char      string
+		expected  string
+		errSubstr string
+	}{
+		{"ascii", "A", `[{"char":"A","code_point":"U+0041","name":"LATIN CAPITAL LETTER A","decimal":65,"category":"Lu","category_name":"uppercase letter","script":"Latin","utf8":"41","utf16":"0041"}]`, ""},
+		{"accented", "é", `[{"char":"é","code_point":"U+00E9","name":"LATIN SMALL LETTER E WITH ACUTE","decimal":233,"category":"Ll","category_name":"lowercase letter","script":"Latin","utf8":"C3 A9","utf16":"00E9"}]`, ""},
+		{"combining", "é", `[{"char":"e","code_point":"U+0065","name":"LATIN SMALL LETTER E","decimal":101,"category":"Ll","category_name":"lowercase letter","script":"Latin","utf8":"65","utf16":"0065"},{"char":"́","code_point":"U+0301","name":"COMBINING ACUTE ACCENT","decimal":769,"category":"Mn","category_name":"nonspacing mark","script":"Inherited","utf8":"CC 81","utf16":"0301"}]`, ""},
+		{"euro", "€", `[{"char":"€","code_point":"U+20AC","name":"EURO SIGN","decimal":8364,"category":"Sc","category_name":"currency symbol","script":"Common","utf8":"E2 82 AC","utf16":"20AC"}]`, ""},
+		{"emoji", "😀", `[{"char":"😀","code_point":"U+1F600","name":"GRINNING FACE","decimal":128512,"category":"So","category_name":"other symbol","script":"Common","utf8":"F0 9F 98 80","utf16":"D83D DE00"}]`, ""},
+		{"emoji_skin_tone", "👍🏽", `[{"char":"👍","code_point":"U+1F44D","name":"THUMBS UP SIGN","decimal":128077,"category":"So","category_name":"other symbol","script":"Common","utf8":"F0 9F 91 8D","utf16":"D83D DC4D"},{"char":"🏽","code_point":"U+1F3FD","name":"EMOJI MODIFIER FITZPATRICK TYPE-4","decimal":127997,"category":"Sk","category_name":"modifier symbol","script":"Common","utf8":"F0 9F 8F BD","utf16":"D83C DFFD"}]`, ""},
+		{"cjk", "漢", `[{"char":"漢","code_point":"U+6F22","name":"CJK UNIFIED IDEOGRAPH-6F22","decimal":28450,"category":"Lo","category_name":"other letter","script":"Han","utf8":"E6 BC A2","utf16":"6F22"}]`, ""},
+		{"control", "\t", `[{"char":"\t","code_point":"U+0009","name":"<control>","decimal":9,"category":"Cc","category_name":"control","script":"Common","utf8":"09","utf16":"0009"}]`, ""},
+		{"notation", "u+20ac", `[{"char":"€","code_point":"U+20AC","name":"EURO SIGN","decimal":8364,"category":"Sc","category_name":"currency symbol","script":"Common","utf8":"E2 82 AC","utf16":"20AC"}]`, ""},
+		{"unassigned", "U+0378", `[{"char":"͸","code_point":"U+0378","decimal":888,"category":"Cn","category_name":"unassigned","script":"Unknown","utf8":"CD B8","utf16":"0378"}]`, ""},
+		{"surrogate", "U+D800", "", "invalid code point U+D800"},
+		{"too_large", "U+110000", "", "invalid code point U+110000"},
+		{"empty", "", "", "missing char"},
+		{"too_long", strings.Repeat("a", maxUnicodeInfoRunes+1), "", "text has 65 code points; the maximum is 64"},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := callback(t.Context(), &unicodeInfoArgs{Char: tt.char})
+			if tt.errSubstr != "" {
+				if err == nil || !strings.Contains(err.Error(), tt.errSubstr) {
+					t.Fatalf("Expected error containing %q but got %v", tt.errSubstr, err)
+				}
+				return
+			}
+			if err != nil {
+				t.Fatalf("Unexpected error: %v", err)
+			}
+			if got != tt.expected {
+				t.Fatalf("Expected %q but got %q", tt.expected, got)
+			}
+		})
+	}
+}
@@ -0,0 +1,112 @@
+// Copyright 2025 Marc-Antoine Ruel. All rights reserved.
+// Use of this source code is governed under the Apache License, Version 2.0
+// that can be found in the LICENSE file.
+
+package genaitools
+
+import (
+	"fmt"
+	"strings"
+)
+
+// unit is a unit of measure.
+type unit struct {
+	// name is the canonical symbol.
+	name string
+	// dim is the physical dimension, e.g. "length".
+	dim string
+	// factor converts a value in this unit to the base unit of the dimension.
+	factor float64
+}
+
+// unitTable is the list of supported units. The first unit of each dimension
+// is the base unit.
+var unitTable = []struct {
+	unit
+	aliases []string
+}{
+	// Length; base meter.
+	{unit{"m", "length", 1}, []string{"meter", "meters", "metre", "metres"}},
+	{unit{"km", "length", 1000}, []string{"kilometer", "kilometers", "kilometre", "kilometres"}},
+	{unit{"cm", "length", 0.01}, []string{"centimeter", "centimeters", "centimetre", "centimetres"}},
+	{unit{"mm", "length", 0.001}, []string{"millimeter", "millimeters", "millimetre", "millimetres"}},
+	{unit{"mi", "length", 1609.344}, []string{"mile", "miles"}},
+	{unit{"yd", "length", 0.9144}, []string{"yard", "yards"}},
+	{unit{"ft", "length", 0.3048}, []string{"foot", "feet"}},
+	{unit{"in", "length", 0.0254}, []string{"inch", "inches"}},
+	{unit{"nmi", "length", 1852}, []string{"nautical_mile", "nautical_miles"}},
+
+	// Mass; base kilogram.
+	{unit{"kg", "mass", 1}, []string{"kilogram", "kilograms"}},
+	{unit{"g", "mass", 0.001}, []string{"gram", "grams"}},
+	{unit{"mg", "mass", 1e-6}, []string{"milligram", "milligrams"}},
+	{unit{"t", "mass", 1000}, []string{"tonne", "tonnes"}},
+	{unit{"lb", "mass", 0.45359237}, []string{"lbs", "pound", "pounds"}},
+	{unit{"oz", "mass", 0.028349523125}, []string{"ounce", "ounces"}},
+
+	// Time; base second.
+	{unit{"s", "time", 1}, []string{"sec", "secs", "second", "seconds"}},
+	{unit{"ms", "time", 0.001}, []string{"millisecond", "milliseconds"}},
+	{unit{"min", "time", 60}, []string{"mins", "minute", "minutes"}},
+	{unit{"h", "time", 3600}, []string{"hr", "hrs", "hour", "hours"}},
+	{unit{"d", "time", 86400}, []string{"day", "days"}},
+	{unit{"wk", "time", 604800}, []string{"week", "weeks"}},
+
+	// Volume; base liter.
+	{unit{"l", "volume", 1}, []string{"L", "liter", "liters", "litre", "litres"}},
+	{unit{"ml", "volume", 0.001}, []string{"mL", "milliliter", "milliliters", "millilitre", "millilitres"}},
+	{unit{"m3", "volume", 1000}, []string{"cubic_meter", "cubic_meters"}},
+	{unit{"gal", "volume", 3.785411784}, []string{"gallon", "gallons"}},
+	{unit{"qt", "volume", 0.946352946}, []string{"quart", "quarts"}},
+	{unit{"cup", "volume", 0.2365882365}, []string{"cups"}},
+	{unit{"floz", "volume", 0.0295735295625}, []string{"fl_oz"}},
+
+	// Data; base byte.
+	{unit{"B", "data", 1}, []string{"byte", "bytes"}},
+	{unit{"KB", "data", 1e3}, []string{"kB", "kilobyte", "kilobytes"}},
+	{unit{"MB", "data", 1e6}, []string{"megabyte", "megabytes"}},
+	{unit{"GB", "data", 1e9}, []string{"gigabyte", "gigabytes"}},
+	{unit{"TB", "data", 1e12}, []string{"terabyte", "terabytes"}},
+	{unit{"KiB", "data", 1 << 10}, []string{"kibibyte", "kibibytes"}},
+	{unit{"MiB", "data", 1 << 20}, []string{"mebibyte", "mebibytes"}},
+	{unit{"GiB", "data", 1 << 30}, []string{"gibibyte", "gibibytes"}},
+	{unit{"TiB", "data", 1 << 40}, []string{"tebibyte", "tebibytes"}},
+}
+
+// units indexes unitTable by symbol and alias.
+var units = func() map[string]unit {
+	m := map[string]unit{}
+	for _, u := range unitTable {
+		m[u.name] = u.unit
+		for _, a := range u.aliases {
+			m[a] = u.unit
+		}
+	}
+	return m
+}()
+
+// baseUnit returns the base unit of the dimension dim.
+func baseUnit(dim string) unit {
+	for _, u := range unitTable {
+		if u.dim == dim {
+			return u.unit
+		}
+	}
+	return unit{}
+}
+
+// lookupUnit returns the unit for a symbol or alias.
+//
+// The lookup is case sensitive first, since "mB" and "MB" differ, then falls
+// back to a case insensitive match.
+func lookupUnit(s string) (unit, error) {
+	if u, ok := units[s]; ok {
+		return u, nil
+	}
+	for k, u := range units {
+		if strings.EqualFold(k, s) {
+			return u, nil
+		}
+	}
+	return unit{}, fmt.Errorf("unknown unit %q", s)
+}
@@ -0,0 +1,106 @@
+// Copyright 2025 Marc-Antoine Ruel. All rights reserved.
+// Use of this source code is governed under the Apache License, Version 2.0
+// that can be found in the LICENSE file.
+
+package genaitools
+
+import (
+	"context"
+	"encoding/json"
+	"slices"
+	"strings"
+
+	"github.com/maruel/genai"
+)
+
+// VersionSort sorts strings with embedded numbers in natural order, so "v2"
+// sorts before "v10", like sort -V.
+var VersionSort = genai.ToolDef{
+	Name:        "version_sort",
+	Description: "Sorts a list of strings containing numbers in natural/version order (v2 before v10), like sort -V. Returns the sorted list as a JSON array.",
+	Callback:    doVersionSort,
+}
+
+type versionSortArgs struct {
+	Items   []string `json:"items" jsonschema:"description=Strings to sort"`
+	Reverse bool     `json:"reverse,omitempty" jsonschema:"description=Sort in descending order"`
+}
+
+func doVersionSort(ctx context.Context, args *versionSortArgs) (string, error) {
+	items := slices.Clone(args.Items)
+	if items == nil {
+		items = []string{}
+	}
+	slices.SortStableFunc(items, func(a, b string) int {
+		if args.Reverse {
+			return naturalCompare(b, a)
+		}
+		return naturalCompare(a, b)
+	})
+	b, err := json.Marshal(items)
+	return string(b), err
+}
+
+// naturalCompare compares a and b split in runs of digits and non-digits.
+// Digit runs are compared by numeric value, other runs byte-wise. Strings that
+// are equal by value, like "1" and "01", fall back to a byte-wise comparison
+// so the order is total.
+func naturalCompare(a, b string) int {
+	i, j := 0, 0
+	for i < len(a) && j < len(b) {
+		ra, na := nextRun(a[i:])
+		rb, nb := nextRun(b[j:])
+		i += len(ra)
+		j += len(rb)
+		if na && nb {
+			if c := compareDigits(ra, rb); c != 0 {
+				return c
+			}
+			continue
+		}
+		// A number sorts before text, like sort -V.
+		if na != nb {
+			if na {
+				return -1
+			}
+			return 1
+		}
+		if c := strings.Compare(ra, rb); c != 0 {
+			return c
+		}
+	}
+	switch {
+	case i < len(a):
+		return 1
+	case j < len(b):
+		return -1
+	}
+	return strings.Compare(a, b)
+}
+
+// nextRun returns the leading run of digits or non-digits of s.
+func nextRun(s string) (string, bool) {
+	digit := isDigit(s[0])
+	n := 1
+	for n < len(s) && isDigit(s[n]) == digit {
+		n++
+	}
+	return s[:n], digit
+}
+
+func isDigit(c byte) bool {
+	return c >= '0' && c <= '9'
+}
+
+// compareDigits compares two runs of digits by value without overflowing.
+func compareDigits(a, b string) int {
+	a = strings.TrimLeft(a, "0")
+	b = strings.TrimLeft(b, "0")
+	if len(a) != len(b) {
+		if len(a) < len(b) {
+			return -1
+		}
+		return 1
+	}
+	return strings.Compare(a, b)
+}
@@ -0,0 +1,38 @@
+// Copyright 2025 Marc-Antoine Ruel. All rights reserved.
+// Use of this source code is governed under the Apache License, Version 2.0
+// that can be found in the LICENSE file.
+
+package genaitools
+
+import (
+	"context"
+	"testing"
+)
+
+func TestVersionSort(t *testing.T) {
+	callback := VersionSort.Callback.(func(context.Context, *versionSortArgs) (string, error))
+	tests := []struct {
+		name     string
+		items    []string
+		reverse  bool
+		expected string
+	}{
+		{"versions", []string{"v10", "v2", "v1.10", "v1.9", "v1.9.1"}, false, `["v1.9","v1.9.1","v1.10","v2","v10"]`},
+		{"reverse", []string{"file2.txt", "file10.txt", "file1.txt"}, true, `["file10.txt","file2.txt","file1.txt"]`},
+		{"leading_zeros", []string{"a01", "a1", "a001", "a2"}, false, `["a001","a01","a1","a2"]`},
+		{"big", []string{"99999999999999999999999", "100000000000000000000000", "7"}, false, `["7","99999999999999999999999","100000000000000000000000"]`},
+		{"prefix", []string{"1.0-rc1", "1.0", "abc", "1"}, false, `["1","1.0","1.0-rc1","abc"]`},
+		{"empty", nil, false, `[]`},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := callback(t.Context(), &versionSortArgs{Items: tt.items, Reverse: tt.reverse})
+			if err != nil {
+				t.Fatalf("Unexpected error: %v", err)
+			}
+			if got != tt.expected {
+				t.Fatalf("Expected %s but got %s", tt.expected, got)
+			}
+		})
+	}
+}
@@ -0,0 +1,107 @@
+// Copyright 2025 Marc-Antoine Ruel. All rights reserved.
+// Use of this source code is governed under the Apache License, Version 2.0
+// that can be found in the LICENSE file.
+
+package genaitools
+
+import (
+	"bufio"
+	"context"
+	"errors"
+	"fmt"
+	"io"
+	"os"
+	"strings"
+	"unicode"
+
+	"github.com/maruel/genai"
+)
+
+// NewWC returns a tool that counts the lines, words and bytes of a file inside
+// root or of the provided text, like the unix command wc.
+//
+// Files are streamed so large files are not loaded in memory. Paths passed by
+// the LLM are relative to root and cannot escape it.
+func NewWC(root string) genai.ToolDef {
+	return genai.ToolDef{
+		Name:        "wc",
+		Description: "Counts the lines, words and bytes of a file or of text, like the wc command. Returns \"lines=N words=N bytes=N\", limited to the selected counts.",
+		Callback: func(ctx context.Context, args *wcArgs) (string, error) {
+			return doWC(ctx, root, args)
+		},
+	}
+}
+
+type wcArgs struct {
+	Path  string `json:"path,omitempty" jsonschema:"description=Relative path of the file to count. Mutually exclusive with text"`
+	Text  string `json:"text,omitempty" jsonschema:"description=Text to count. Mutually exclusive with path"`
+	Lines bool   `json:"lines,omitempty" jsonschema:"description=Include the number of lines"`
+	Words bool   `json:"words,omitempty" jsonschema:"description=Include the number of words"`
+	Bytes bool   `json:"bytes,omitempty" jsonschema:"description=Include the number of bytes. All counts are included when none is selected"`
+}
+
+func doWC(ctx context.Context, root string, args *wcArgs) (string, error) {
+	var r io.Reader
+	switch {
+	case args.Path != "" && args.Text != "":
+		return "", errors.New("path and text are mutually exclusive")
+	case args.Path != "":
+		full, fi, err := statInRoot(root, args.Path)
+		if err != nil {
+			return "", err
+		}
+		if fi.IsDir() {
+			return "", fmt.Errorf("path %q is a directory", args.Path)
+		}
+		f, err := os.Open(full)
+		if err != nil {
+			return "", err
+		}
+		defer f.Close()
+		r = f
+	default:
+		r = strings.NewReader(args.Text)
+	}
+	lines, words, bytes, err := countWC(r)
+	if err != nil {
+		return "", err
+	}
+	all := !args.Lines && !args.Words && !args.Bytes
+	var out []string
+	if all || args.Lines {
+		out = append(out, fmt.Sprintf("lines=%d", lines))
+	}
+	if all || args.Words {
+		out = append(out, fmt.Sprintf("words=%d", words))
+	}
+	if all || args.Bytes {
+		out = append(out, fmt.Sprintf("bytes=%d", bytes))
+	}
+	return strings.Join(out, " "), nil
+}
+
+// countWC counts like wc: lines are newline characters and words are
+// sequences of non-space characters.
+func countWC(r io.Reader) (lines, words, bytes int64, err error) {
+	br := bufio.NewReader(r)
+	inWord := false
+	for {
+		c, size, err := br.ReadRune()
+		if err == io.EOF {
+			return lines, words, bytes, nil
+		}
+		if err != nil {
+			return lines, words, bytes, err
+		}
+		bytes += int64(size)
+		if c == '\n' {
+			lines++
+		}
+		if unicode.IsSpace(c) {
+			inWord = false
+		} else if !inWord {
+			inWord = true
+			words++
+		}
+	}
+}
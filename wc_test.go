@@ -0,0 +1,57 @@
+// Copyright 2025 Marc-Antoine Ruel. All rights reserved.
+// Use of this source code is governed under the Apache License, Version 2.0
+// that can be found in the LICENSE file.
+
+package genaitools
+
+import (
+	"context"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+)
+
+func TestWC(t *testing.T) {
+	root := t.TempDir()
+	if err := os.WriteFile(filepath.Join(root, "a.txt"), []byte("hello world\nsecond  line\n\nlast"), 0o644); err != nil {
+		t.Fatal(err)
+	}
+	if err := os.Mkdir(filepath.Join(root, "dir"), 0o755); err != nil {
+		t.Fatal(err)
+	}
+	callback := NewWC(root).Callback.(func(context.Context, *wcArgs) (string, error))
+	tests := []struct {
+		name      string
+		args      wcArgs
+		expected  string
+		errSubstr string
+	}{
+		{"file", wcArgs{Path: "a.txt"}, "lines=3 words=5 bytes=30", ""},
+		{"text", wcArgs{Text: "héllo wörld\n"}, "lines=1 words=2 bytes=14", ""},
+		{"empty", wcArgs{}, "lines=0 words=0 bytes=0", ""},
+		{"select", wcArgs{Path: "a.txt", Lines: true, Bytes: true}, "lines=3 bytes=30", ""},
+		{"words_only", wcArgs{Text: " a\tb \n c ", Words: true}, "words=3", ""},
+		{"both", wcArgs{Path: "a.txt", Text: "x"}, "", "mutually exclusive"},
+		{"dir", wcArgs{Path: "dir"}, "", "is a directory"},
+		{"missing", wcArgs{Path: "missing"}, "", `path "missing"`},
+		{"escape", wcArgs{Path: "../a.txt"}, "", "escapes the root directory"},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := callback(t.Context(), &tt.args)
+			if tt.errSubstr != "" {
+				if err == nil || !strings.Contains(err.Error(), tt.errSubstr) {
+					t.Fatalf("Expected error containing %q but got %v", tt.errSubstr, err)
+				}
+				return
+			}
+			if err != nil {
+				t.Fatalf("Unexpected error: %v", err)
+			}
+			if got != tt.expected {
+				t.Fatalf("Expected %q but got %q", tt.expected, got)
+			}
+		})
+	}
+}
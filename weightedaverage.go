@@ -0,0 +1,93 @@
+// Copyright 2025 Marc-Antoine Ruel. All rights reserved.
+// Use of this source code is governed under the Apache License, Version 2.0
+// that can be found in the LICENSE file.
+
+package genaitools
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"math"
+	"strconv"
+
+	"github.com/maruel/genai"
+)
+
+// WeightedAverage computes the weighted mean of values.
+//
+// Like Arithmetic, it first tries to do the calculation using int64, then
+// using float64.
+var WeightedAverage = genai.ToolDef{
+	Name:        "weighted_average",
+	Description: "Computes the weighted average (weighted mean) of a list of values given a parallel list of weights, i.e. sum(value*weight)/sum(weight).",
+	Callback:    doWeightedAverage,
+}
+
+type weightedAverageArgs struct {
+	Values  []json.Number `json:"values" jsonschema:"description=Values to average"`
+	Weights []json.Number `json:"weights" jsonschema:"description=Weight of each value. Must have the same length as values"`
+}
+
+func doWeightedAverage(ctx context.Context, args *weightedAverageArgs) (string, error) {
+	if len(args.Values) != len(args.Weights) {
+		return "", fmt.Errorf("values and weights must have the same length; got %d values and %d weights", len(args.Values), len(args.Weights))
+	}
+	if len(args.Values) == 0 {
+		return "", errors.New("at least one value is required")
+	}
+	if s, ok := weightedAverageInt(args); ok {
+		return s, nil
+	}
+	sum, total := 0., 0.
+	for i := range args.Values {
+		v, err := args.Values[i].Float64()
+		if err != nil {
+			return "", fmt.Errorf("couldn't understand value %d: %w", i, err)
+		}
+		w, err := args.Weights[i].Float64()
+		if err != nil {
+			return "", fmt.Errorf("couldn't understand weight %d: %w", i, err)
+		}
+		sum += v * w
+		total += w
+	}
+	if total == 0 {
+		return "", errors.New("total weight is zero")
+	}
+	return formatFloat(sum / total), nil
+}
+
+// weightedAverageInt computes the weighted average when all the numbers are
+// integers and the result is an integer without overflow.
+func weightedAverageInt(args *weightedAverageArgs) (string, bool) {
+	var sum, total int64
+	for i := range args.Values {
+		v, err := args.Values[i].Int64()
+		if err != nil {
+			return "", false
+		}
+		w, err := args.Weights[i].Int64()
+		if err != nil {
+			return "", false
+		}
+		p := v * w
+		if v != 0 && (p/v != w || (v == -1 && w == math.MinInt64)) {
+			return "", false
+		}
+		if (p > 0 && sum > math.MaxInt64-p) || (p < 0 && sum < math.MinInt64-p) {
+			return "", false
+		}
+		if (w > 0 && total > math.MaxInt64-w) || (w < 0 && total < math.MinInt64-w) {
+			return "", false
+		}
+		sum += p
+		total += w
+	}
+	if total == 0 || sum%total != 0 {
+		// Let the float path report the error or compute the fraction.
+		return "", false
+	}
+	return strconv.FormatInt(sum/total, 10), true
+}
@@ -0,0 +1,51 @@
+// Copyright 2025 Marc-Antoine Ruel. All rights reserved.
+// Use of this source code is governed under the Apache License, Version 2.0
+// that can be found in the LICENSE file.
+
+package genaitools
+
+import (
+	"context"
+	"encoding/json"
+	"strings"
+	"testing"
+)
+
+func TestWeightedAverage(t *testing.T) {
+	callback := WeightedAverage.Callback.(func(context.Context, *weightedAverageArgs) (string, error))
+	tests := []struct {
+		name      string
+		values    []json.Number
+		weights   []json.Number
+		expected  string
+		errSubstr string
+	}{
+		{"int", []json.Number{"80", "90", "100"}, []json.Number{"1", "2", "1"}, "90", ""},
+		{"int_fraction", []json.Number{"1", "2"}, []json.Number{"1", "2"}, "1.666667", ""},
+		{"float", []json.Number{"3.5", "4.0"}, []json.Number{"0.25", "0.75"}, "3.875000", ""},
+		{"not_simple_average", []json.Number{"10", "20"}, []json.Number{"3", "1"}, "12.500000", ""},
+		{"negative_weight", []json.Number{"10", "20"}, []json.Number{"2", "-1"}, "0", ""},
+		{"overflow", []json.Number{"9223372036854775807", "9223372036854775807"}, []json.Number{"2", "2"}, "9223372036854775808", ""},
+		{"mismatch", []json.Number{"1", "2"}, []json.Number{"1"}, "", "same length; got 2 values and 1 weights"},
+		{"zero_weight", []json.Number{"1", "2"}, []json.Number{"1", "-1"}, "", "total weight is zero"},
+		{"empty", nil, nil, "", "at least one value"},
+		{"bad_value", []json.Number{"x"}, []json.Number{"1"}, "", "couldn't understand value 0"},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := callback(t.Context(), &weightedAverageArgs{Values: tt.values, Weights: tt.weights})
+			if tt.errSubstr != "" {
+				if err == nil || !strings.Contains(err.Error(), tt.errSubstr) {
+					t.Fatalf("Expected error containing %q but got %v", tt.errSubstr, err)
+				}
+				return
+			}
+			if err != nil {
+				t.Fatalf("Unexpected error: %v", err)
+			}
+			if got != tt.expected {
+				t.Fatalf("Expected %q but got %q", tt.expected, got)
+			}
+		})
+	}
+}
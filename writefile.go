@@ -0,0 +1,98 @@
+// Copyright 2025 Marc-Antoine Ruel. All rights reserved.
+// Use of this source code is governed under the Apache License, Version 2.0
+// that can be found in the LICENSE file.
+
+package genaitools
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"io/fs"
+	"os"
+	"path/filepath"
+
+	"github.com/maruel/genai"
+)
+
+// maxWriteFile is the maximum number of bytes written by one call of the
+// write file tool.
+const maxWriteFile = 1 << 20
+
+// NewWriteFile returns a tool that writes or appends to a file inside root.
+//
+// Paths passed by the LLM are relative to root and cannot escape it, either
+// via ".." or via symlinks. Missing parent directories are created. The
+// content is limited to 1MiB per call.
+func NewWriteFile(root string) genai.ToolDef {
+	return genai.ToolDef{
+		Name:        "write_file",
+		Description: "Writes content to a file, replacing it or appending to it, and creates the missing parent directories. Returns the number of bytes written.",
+		Callback: func(ctx context.Context, args *writeFileArgs) (string, error) {
+			return doWriteFile(root, args)
+		},
+	}
+}
+
+type writeFileArgs struct {
+	Path    string `json:"path" jsonschema:"description=Relative path of the file to write"`
+	Content string `json:"content" jsonschema:"description=Content to write"`
+	Append  bool   `json:"append,omitempty" jsonschema:"description=Append to the file instead of replacing it"`
+}
+
+func doWriteFile(root string, args *writeFileArgs) (string, error) {
+	if len(args.Content) > maxWriteFile {
+		return "", fmt.Errorf("content is %d bytes; the maximum is %d bytes", len(args.Content), maxWriteFile)
+	}
+	// resolveInRoot gives clear errors; the write itself goes through os.Root
+	// so a symlink swapped in after the check still cannot escape root.
+	if _, err := resolveInRoot(root, args.Path); err != nil {
+		return "", err
+	}
+	r, err := os.OpenRoot(root)
+	if err != nil {
+		return "", fmt.Errorf("invalid root directory: %w", err)
+	}
+	defer r.Close()
+	rel := filepath.Clean(args.Path)
+	if fi, err := r.Stat(rel); err == nil {
+		if !fi.Mode().IsRegular() {
+			return "", fmt.Errorf("path %q is not a regular file", args.Path)
+		}
+	} else if !errors.Is(err, fs.ErrNotExist) {
+		return "", fmt.Errorf("path %q: %w", args.Path, errors.Unwrap(err))
+	}
+	if err = mkdirAllInRoot(r, filepath.Dir(rel)); err != nil {
+		return "", fmt.Errorf("failed to create the parent directory of %q: %w", args.Path, errors.Unwrap(err))
+	}
+	flag := os.O_WRONLY | os.O_CREATE | os.O_TRUNC
+	if args.Append {
+		flag = os.O_WRONLY | os.O_CREATE | os.O_APPEND
+	}
+	f, err := r.OpenFile(rel, flag, 0o644)
+	if err != nil {
+		return "", fmt.Errorf("path %q: %w", args.Path, errors.Unwrap(err))
+	}
+	n, err := f.WriteString(args.Content)
+	if err2 := f.Close(); err == nil {
+		err = err2
+	}
+	if err != nil {
+		return "", fmt.Errorf("failed to write %q: %w", args.Path, err)
+	}
+	return fmt.Sprintf("wrote %d bytes to %s", n, args.Path), nil
+}
+
+// mkdirAllInRoot is os.MkdirAll within r.
+func mkdirAllInRoot(r *os.Root, dir string) error {
+	if dir == "." {
+		return nil
+	}
+	if err := mkdirAllInRoot(r, filepath.Dir(dir)); err != nil {
+		return err
+	}
+	if err := r.Mkdir(dir, 0o755); err != nil && !errors.Is(err, fs.ErrExist) {
+		return err
+	}
+	return nil
+}
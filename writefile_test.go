@@ -0,0 +1,85 @@
+// Copyright 2025 Marc-Antoine Ruel. All rights reserved.
+// Use of this source code is governed under the Apache License, Version 2.0
+// that can be found in the LICENSE file.
+
+package genaitools
+
+import (
+	"context"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+)
+
+func TestWriteFile(t *testing.T) {
+	parent := t.TempDir()
+	root := filepath.Join(parent, "root")
+	if err := os.Mkdir(root, 0o755); err != nil {
+		t.Fatal(err)
+	}
+	if err := os.Symlink(parent, filepath.Join(root, "link")); err != nil {
+		t.Fatal(err)
+	}
+	if err := os.Symlink(filepath.Join("..", "outside.txt"), filepath.Join(root, "dangling")); err != nil {
+		t.Fatal(err)
+	}
+	callback := NewWriteFile(root).Callback.(func(context.Context, *writeFileArgs) (string, error))
+	tests := []struct {
+		name      string
+		args      writeFileArgs
+		expected  string
+		errSubstr string
+	}{
+		{"create", writeFileArgs{Path: "a.txt", Content: "hello\n"}, "wrote 6 bytes to a.txt", ""},
+		{"append", writeFileArgs{Path: "a.txt", Content: "wörld\n", Append: true}, "wrote 7 bytes to a.txt", ""},
+		{"nested", writeFileArgs{Path: "x/y/z.txt", Content: "deep"}, "wrote 4 bytes to x/y/z.txt", ""},
+		{"append_new", writeFileArgs{Path: "x/new.txt", Content: "n", Append: true}, "wrote 1 bytes to x/new.txt", ""},
+		{"overwrite", writeFileArgs{Path: "x/y/z.txt", Content: "replaced"}, "wrote 8 bytes to x/y/z.txt", ""},
+		{"empty", writeFileArgs{Path: "empty.txt"}, "wrote 0 bytes to empty.txt", ""},
+		{"dir", writeFileArgs{Path: "x", Content: "a"}, "", `path "x" is not a regular file`},
+		{"root", writeFileArgs{Path: ".", Content: "a"}, "", "is not a regular file"},
+		{"dotdot", writeFileArgs{Path: "../escape.txt", Content: "a"}, "", "escapes the root directory"},
+		{"dotdot_nested", writeFileArgs{Path: "x/../../escape.txt", Content: "a"}, "", "escapes the root directory"},
+		{"absolute", writeFileArgs{Path: filepath.Join(parent, "escape.txt"), Content: "a"}, "", "must be relative"},
+		{"symlink", writeFileArgs{Path: "link/sub/escape.txt", Content: "a"}, "", "escapes the root directory via a symlink"},
+		{"dangling_symlink", writeFileArgs{Path: "dangling", Content: "pwned"}, "", `path "dangling" goes through a dangling symlink`},
+		{"too_large", writeFileArgs{Path: "big.txt", Content: strings.Repeat("a", maxWriteFile+1)}, "", "content is 1048577 bytes; the maximum is 1048576 bytes"},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := callback(t.Context(), &tt.args)
+			if tt.errSubstr != "" {
+				if err == nil || !strings.Contains(err.Error(), tt.errSubstr) {
+					t.Fatalf("Expected error containing %q but got %v", tt.errSubstr, err)
+				}
+				return
+			}
+			if err != nil {
+				t.Fatalf("Unexpected error: %v", err)
+			}
+			if got != tt.expected {
+				t.Fatalf("Expected %q but got %q", tt.expected, got)
+			}
+		})
+	}
+	for name, want := range map[string]string{"a.txt": "hello\nwörld\n", "x/y/z.txt": "replaced", "x/new.txt": "n"} {
+		b, err := os.ReadFile(filepath.Join(root, name))
+		if err != nil {
+			t.Fatal(err)
+		}
+		if string(b) != want {
+			t.Fatalf("%s: Expected %q but got %q", name, want, b)
+		}
+	}
+	entries, err := os.ReadDir(parent)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(entries) != 1 {
+		t.Fatalf("Expected nothing written outside of the root but got %d entries", len(entries))
+	}
+	if _, err := os.Stat(filepath.Join(root, "big.txt")); err == nil {
+		t.Fatal("Expected the large file to not be created")
+	}
+}
@@ -0,0 +1,325 @@
+// Copyright 2025 Marc-Antoine Ruel. All rights reserved.
+// Use of this source code is governed under the Apache License, Version 2.0
+// that can be found in the LICENSE file.
+
+package genaitools
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"math"
+	"strconv"
+	"strings"
+
+	"github.com/maruel/genai"
+	"gopkg.in/yaml.v3"
+)
+
+// maxYAMLNodes bounds the expansion of YAML aliases, e.g. "billion laughs".
+const maxYAMLNodes = 1 << 20
+
+// YAMLToJSON converts YAML to JSON.
+//
+// A stream of multiple documents is converted to a JSON array of the
+// documents. The key order is preserved, aliases and merge keys ("<<") are
+// resolved and timestamps are converted to strings as written. Non-string
+// keys are converted to strings.
+var YAMLToJSON = genai.ToolDef{
+	Name:        "yaml_to_json",
+	Description: "Converts a YAML document to indented JSON, preserving the key order. A stream of multiple documents separated by --- becomes a JSON array.",
+	Callback:    doYAMLToJSON,
+}
+
+// JSONToYAML converts JSON to YAML, preserving the key order and the number
+// literals.
+var JSONToYAML = genai.ToolDef{
+	Name:        "json_to_yaml",
+	Description: "Converts a JSON document to YAML, preserving the key order.",
+	Callback:    doJSONToYAML,
+}
+
+type yamlToJSONArgs struct {
+	YAML string `json:"yaml" jsonschema:"description=YAML document or stream of documents"`
+}
+
+type jsonToYAMLArgs struct {
+	JSON string `json:"json" jsonschema:"description=JSON document"`
+}
+
+func doYAMLToJSON(ctx context.Context, args *yamlToJSONArgs) (string, error) {
+	d := yaml.NewDecoder(strings.NewReader(args.YAML))
+	var docs []string
+	budget := maxYAMLNodes
+	for {
+		var n yaml.Node
+		if err := d.Decode(&n); err == io.EOF {
+			break
+		} else if err != nil {
+			return "", fmt.Errorf("invalid YAML: %w", err)
+		}
+		var b strings.Builder
+		if err := writeYAMLNodeJSON(&b, &n, &budget); err != nil {
+			return "", fmt.Errorf("cannot convert YAML to JSON: %w", err)
+		}
+		docs = append(docs, b.String())
+	}
+	var out string
+	switch len(docs) {
+	case 0:
+		out = "null"
+	case 1:
+		out = docs[0]
+	default:
+		out = "[" + strings.Join(docs, ",") + "]"
+	}
+	var buf bytes.Buffer
+	if err := json.Indent(&buf, []byte(out), "", "  "); err != nil {
+		return "", err
+	}
+	return buf.String(), nil
+}
+
+// writeYAMLNodeJSON writes n as compact JSON. budget is decremented for each
+// node written.
+func writeYAMLNodeJSON(w *strings.Builder, n *yaml.Node, budget *int) error {
+	if *budget--; *budget < 0 {
+		return errors.New("too many nodes; aliases expand to more than 1048576 values")
+	}
+	switch n.Kind {
+	case yaml.DocumentNode:
+		if len(n.Content) == 0 {
+			w.WriteString("null")
+			return nil
+		}
+		return writeYAMLNodeJSON(w, n.Content[0], budget)
+	case yaml.AliasNode:
+		return writeYAMLNodeJSON(w, n.Alias, budget)
+	case yaml.SequenceNode:
+		w.WriteByte('[')
+		for i, c := range n.Content {
+			if i != 0 {
+				w.WriteByte(',')
+			}
+			if err := writeYAMLNodeJSON(w, c, budget); err != nil {
+				return err
+			}
+		}
+		w.WriteByte(']')
+		return nil
+	case yaml.MappingNode:
+		pairs, err := yamlMappingPairs(n)
+		if err != nil {
+			return err
+		}
+		w.WriteByte('{')
+		for i, p := range pairs {
+			if i != 0 {
+				w.WriteByte(',')
+			}
+			k, _ := marshalJSON(p.key)
+			w.WriteString(k + ":")
+			if err := writeYAMLNodeJSON(w, p.value, budget); err != nil {
+				return err
+			}
+		}
+		w.WriteByte('}')
+		return nil
+	case yaml.ScalarNode:
+		s, err := yamlScalarJSON(n)
+		if err != nil {
+			return fmt.Errorf("line %d: %w", n.Line, err)
+		}
+		w.WriteString(s)
+		return nil
+	default:
+		return fmt.Errorf("line %d: unsupported node kind %d", n.Line, n.Kind)
+	}
+}
+
+type yamlPair struct {
+	key   string
+	value *yaml.Node
+}
+
+// yamlMappingPairs returns the key/value pairs of a mapping in order, with
+// the merge keys resolved. Explicit keys override merged ones.
+func yamlMappingPairs(n *yaml.Node) ([]yamlPair, error) {
+	var pairs []yamlPair
+	seen := map[string]int{}
+	var merged []*yaml.Node
+	for i := 0; i+1 < len(n.Content); i += 2 {
+		k, v := n.Content[i], n.Content[i+1]
+		if k.Kind == yaml.ScalarNode && k.ShortTag() == "!!merge" {
+			merged = append(merged, v)
+			continue
+		}
+		key, err := yamlKey(k)
+		if err != nil {
+			return nil, err
+		}
+		if j, ok := seen[key]; ok {
+			pairs[j].value = v
+			continue
+		}
+		seen[key] = len(pairs)
+		pairs = append(pairs, yamlPair{key, v})
+	}
+	for _, m := range merged {
+		if m.Kind == yaml.AliasNode {
+			m = m.Alias
+		}
+		sources := []*yaml.Node{m}
+		if m.Kind == yaml.SequenceNode {
+			sources = m.Content
+		}
+		for _, s := range sources {
+			if s.Kind == yaml.AliasNode {
+				s = s.Alias
+			}
+			if s.Kind != yaml.MappingNode {
+				return nil, fmt.Errorf("line %d: merge key value must be a mapping or a list of mappings", s.Line)
+			}
+			sub, err := yamlMappingPairs(s)
+			if err != nil {
+				return nil, err
+			}
+			for _, p := range sub {
+				if _, ok := seen[p.key]; !ok {
+					seen[p.key] = len(pairs)
+					pairs = append(pairs, p)
+				}
+			}
+		}
+	}
+	return pairs, nil
+}
+
+func yamlKey(k *yaml.Node) (string, error) {
+	if k.Kind == yaml.AliasNode {
+		k = k.Alias
+	}
+	if k.Kind != yaml.ScalarNode {
+		return "", fmt.Errorf("line %d: unsupported complex key; JSON keys must be strings", k.Line)
+	}
+	if k.ShortTag() == "!!null" {
+		return "null", nil
+	}
+	return k.Value, nil
+}
+
+// yamlScalarJSON returns the JSON representation of a scalar.
+func yamlScalarJSON(n *yaml.Node) (string, error) {
+	switch n.ShortTag() {
+	case "!!null":
+		return "null", nil
+	case "!!bool":
+		var b bool
+		if err := n.Decode(&b); err != nil {
+			return "", err
+		}
+		return strconv.FormatBool(b), nil
+	case "!!int":
+		// Handle 0x1F, 0o17 and 1_000.
+		var i int64
+		if err := n.Decode(&i); err == nil {
+			return strconv.FormatInt(i, 10), nil
+		}
+		var u uint64
+		if err := n.Decode(&u); err == nil {
+			return strconv.FormatUint(u, 10), nil
+		}
+		return "", fmt.Errorf("integer %q overflows 64 bits", n.Value)
+	case "!!float":
+		var f float64
+		if err := n.Decode(&f); err != nil {
+			return "", err
+		}
+		if math.IsInf(f, 0) || math.IsNaN(f) {
+			return "", fmt.Errorf("%s cannot be represented in JSON", n.Value)
+		}
+		return marshalJSON(f)
+	default:
+		// !!str, !!timestamp, !!binary and custom tags are kept as written.
+		return marshalJSON(n.Value)
+	}
+}
+
+func doJSONToYAML(ctx context.Context, args *jsonToYAMLArgs) (string, error) {
+	src := []byte(args.JSON)
+	if len(bytes.TrimSpace(src)) == 0 {
+		return "", errors.New("empty JSON document")
+	}
+	d := json.NewDecoder(bytes.NewReader(src))
+	d.UseNumber()
+	n, err := jsonToYAMLNode(d)
+	if err != nil {
+		return "", jsonSyntaxError(src, err)
+	}
+	if _, err = d.Token(); err != io.EOF {
+		return "", errors.New("invalid JSON: trailing data after the document")
+	}
+	var buf bytes.Buffer
+	e := yaml.NewEncoder(&buf)
+	e.SetIndent(2)
+	if err = e.Encode(n); err != nil {
+		return "", err
+	}
+	if err = e.Close(); err != nil {
+		return "", err
+	}
+	return strings.TrimSuffix(buf.String(), "\n"), nil
+}
+
+// jsonToYAMLNode reads the next JSON value from d as a YAML node.
+func jsonToYAMLNode(d *json.Decoder) (*yaml.Node, error) {
+	tok, err := d.Token()
+	if err == io.EOF {
+		return nil, io.ErrUnexpectedEOF
+	}
+	if err != nil {
+		return nil, err
+	}
+	switch t := tok.(type) {
+	case json.Delim:
+		n := &yaml.Node{Kind: yaml.SequenceNode, Tag: "!!seq"}
+		if t == '{' {
+			n.Kind, n.Tag = yaml.MappingNode, "!!map"
+		}
+		for d.More() {
+			if n.Kind == yaml.MappingNode {
+				k, err := d.Token()
+				if err != nil {
+					return nil, err
+				}
+				n.Content = append(n.Content, &yaml.Node{Kind: yaml.ScalarNode, Tag: "!!str", Value: k.(string)})
+			}
+			c, err := jsonToYAMLNode(d)
+			if err != nil {
+				return nil, err
+			}
+			n.Content = append(n.Content, c)
+		}
+		// Consume the closing delimiter.
+		if _, err = d.Token(); err != nil {
+			return nil, err
+		}
+		if len(n.Content) == 0 {
+			n.Style = yaml.FlowStyle
+		}
+		return n, nil
+	case string:
+		return &yaml.Node{Kind: yaml.ScalarNode, Tag: "!!str", Value: t}, nil
+	case json.Number:
+		// Leave the tag empty so the literal is written as is, even for integers
+		// overflowing 64 bits.
+		return &yaml.Node{Kind: yaml.ScalarNode, Value: t.String()}, nil
+	case bool:
+		return &yaml.Node{Kind: yaml.ScalarNode, Tag: "!!bool", Value: strconv.FormatBool(t)}, nil
+	default:
+		return &yaml.Node{Kind: yaml.ScalarNode, Tag: "!!null", Value: "null"}, nil
+	}
+}
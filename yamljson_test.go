@@ -0,0 +1,92 @@
+// Copyright 2025 Marc-Antoine Ruel. All rights reserved.
+// Use of this source code is governed under the Apache License, Version 2.0
+// that can be found in the LICENSE file.
+
+package genaitools
+
+import (
+	"context"
+	"strings"
+	"testing"
+)
+
+func TestYAMLToJSON(t *testing.T) {
+	callback := YAMLToJSON.Callback.(func(context.Context, *yamlToJSONArgs) (string, error))
+	tests := []struct {
+		name      string
+		yaml      string
+		expected  string
+		errSubstr string
+	}{
+		{"scalar", "hello", `"hello"`, ""},
+		{"empty", "", "null", ""},
+		{"nested_maps", "b:\n  z: 1\n  a: [x, y]\na: true\n", "{\n  \"b\": {\n    \"z\": 1,\n    \"a\": [\n      \"x\",\n      \"y\"\n    ]\n  },\n  \"a\": true\n}", ""},
+		{"sequence", "- 1\n- 2.5\n- null\n- ~\n- \"3\"\n- yes\n", "[\n  1,\n  2.5,\n  null,\n  null,\n  \"3\",\n  \"yes\"\n]", ""},
+		{"multi_document", "a: 1\n---\n- b\n---\nc\n", "[\n  {\n    \"a\": 1\n  },\n  [\n    \"b\"\n  ],\n  \"c\"\n]", ""},
+		{"ints", "[0x1F, 0o17, 1_000, -7, 18446744073709551615]", "[\n  31,\n  15,\n  1000,\n  -7,\n  18446744073709551615\n]", ""},
+		{"timestamp", "t: 2024-01-02", "{\n  \"t\": \"2024-01-02\"\n}", ""},
+		{"non_string_keys", "1: a\ntrue: b\n~: c\n", "{\n  \"1\": \"a\",\n  \"true\": \"b\",\n  \"null\": \"c\"\n}", ""},
+		{"html", "a: <b>&", "{\n  \"a\": \"<b>&\"\n}", ""},
+		{"alias_merge", "base: &b {x: 1, y: 2}\nd:\n  <<: *b\n  y: 3\n", "{\n  \"base\": {\n    \"x\": 1,\n    \"y\": 2\n  },\n  \"d\": {\n    \"y\": 3,\n    \"x\": 1\n  }\n}", ""},
+		{"infinity", "a: 1\nb: .inf\n", "", "line 2: .inf cannot be represented in JSON"},
+		{"complex_key", "? [a]\n: b\n", "", "complex key"},
+		{"float", "[1e3, -.5, 1.0]", "[\n  1000,\n  -0.5,\n  1\n]", ""},
+		{"syntax", "a: b\nc: d: e\n", "", "invalid YAML: yaml: line 2:"},
+		{"bad_merge", "a:\n  <<: 1\n", "", "merge key value must be a mapping"},
+		{"laughs", "a: &a [1, 1, 1, 1, 1, 1, 1, 1, 1, 1]\nb: &b [*a, *a, *a, *a, *a, *a, *a, *a, *a, *a]\nc: &c [*b, *b, *b, *b, *b, *b, *b, *b, *b, *b]\nd: &d [*c, *c, *c, *c, *c, *c, *c, *c, *c, *c]\ne: &e [*d, *d, *d, *d, *d, *d, *d, *d, *d, *d]\nf: &f [*e, *e, *e, *e, *e, *e, *e, *e, *e, *e]\ng: [*f, *f]\n", "", "too many nodes"},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := callback(t.Context(), &yamlToJSONArgs{YAML: tt.yaml})
+			if tt.errSubstr != "" {
+				if err == nil || !strings.Contains(err.Error(), tt.errSubstr) {
+					t.Fatalf("Expected error containing %q but got %v", tt.errSubstr, err)
+				}
+				return
+			}
+			if err != nil {
+				t.Fatalf("Unexpected error: %v", err)
+			}
+			if got != tt.expected {
+				t.Fatalf("Expected %q but got %q", tt.expected, got)
+			}
+		})
+	}
+}
+
+func TestJSONToYAML(t *testing.T) {
+	callback := JSONToYAML.Callback.(func(context.Context, *jsonToYAMLArgs) (string, error))
+	tests := []struct {
+		name      string
+		json      string
+		expected  string
+		errSubstr string
+	}{
+		{"nested_maps", `{"z": {"b": 1, "a": [1.50, "x"]}, "a": null}`, "z:\n  b: 1\n  a:\n    - 1.50\n    - x\na: null", ""},
+		{"ambiguous_strings", `["true", "123", "", "null", "a: b", true]`, "- \"true\"\n- \"123\"\n- \"\"\n- \"null\"\n- 'a: b'\n- true", ""},
+		{"empty", `{"a": {}, "b": []}`, "a: {}\nb: []", ""},
+		{"multiline", `{"s": "a\nb"}`, "s: |-\n  a\n  b", ""},
+		{"big_number", `10000000000000000001`, "10000000000000000001", ""},
+		{"blank", " ", "", "empty JSON document"},
+		{"syntax", "{\n  \"a\": 1,\n}", "", "invalid JSON at byte"},
+		{"truncated", `{"a": [1`, "", "unexpected end of JSON input"},
+		{"trailing", `{} {}`, "", "trailing data"},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := callback(t.Context(), &jsonToYAMLArgs{JSON: tt.json})
+			if tt.errSubstr != "" {
+				if err == nil || !strings.Contains(err.Error(), tt.errSubstr) {
+					t.Fatalf("Expected error containing %q but got %v", tt.errSubstr, err)
+				}
+				return
+			}
+			if err != nil {
+				t.Fatalf("Unexpected error: %v", err)
+			}
+			if got != tt.expected {
+				t.Fatalf("Expected %q but got %q", tt.expected, got)
+			}
+		})
+	}
+}